@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// FileDownload is an open streaming file download.
+type FileDownload struct {
+	Body io.ReadCloser
+	// Size is the total file size, or -1 when the server did not report it.
+	Size int64
+	// Offset is the position Body starts at. It is zero unless a ranged
+	// request was honored by the server.
+	Offset int64
+}
+
+// DownloadSandboxFile opens a sandbox file for streaming. When offset is
+// positive a ranged read is requested; servers that ignore the range return
+// the whole file with Offset set to zero.
+func DownloadSandboxFile(ctx context.Context, c *sandbox0.Client, sandboxID, filePath string, offset int64) (*FileDownload, error) {
+	stream := &fileDownloadStream{offset: offset}
+	resp, err := c.API().APIV1SandboxesIDFilesGet(ctx, apispec.APIV1SandboxesIDFilesGetParams{
+		ID:   sandboxID,
+		Path: filePath,
+	}, stream.options()...)
+	if stream.download != nil {
+		return stream.download, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response, ok := resp.(*apispec.APIV1SandboxesIDFilesGetOKApplicationJSON); ok {
+		return decodeFileContent(response.Data)
+	}
+	return nil, unexpectedFileResponse(resp)
+}
+
+// DownloadVolumeFile opens a volume file for streaming, like
+// DownloadSandboxFile.
+func DownloadVolumeFile(ctx context.Context, c *sandbox0.Client, volumeID, filePath string, offset int64) (*FileDownload, error) {
+	stream := &fileDownloadStream{offset: offset}
+	resp, err := c.API().APIV1SandboxvolumesIDFilesGet(ctx, apispec.APIV1SandboxvolumesIDFilesGetParams{
+		ID:   volumeID,
+		Path: filePath,
+	}, stream.options()...)
+	if stream.download != nil {
+		return stream.download, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if response, ok := resp.(*apispec.APIV1SandboxvolumesIDFilesGetOKApplicationJSON); ok {
+		return decodeFileContent(response.Data)
+	}
+	return nil, unexpectedFileResponse(resp)
+}
+
+// UploadSandboxFile streams body into a sandbox file, replacing any existing
+// content. size is sent as the content length when non-negative.
+func UploadSandboxFile(ctx context.Context, c *sandbox0.Client, sandboxID, filePath string, body io.Reader, size int64) error {
+	resp, err := c.API().APIV1SandboxesIDFilesPost(ctx, apispec.APIV1SandboxesIDFilesPostReq{Data: body}, apispec.APIV1SandboxesIDFilesPostParams{
+		ID:   sandboxID,
+		Path: filePath,
+	}, withContentLength(size))
+	if err != nil {
+		return err
+	}
+	if _, ok := resp.(*apispec.SuccessWrittenResponse); !ok {
+		return unexpectedFileResponse(resp)
+	}
+	return nil
+}
+
+// UploadVolumeFile streams body into a volume file, like UploadSandboxFile.
+func UploadVolumeFile(ctx context.Context, c *sandbox0.Client, volumeID, filePath string, body io.Reader, size int64) error {
+	resp, err := c.API().APIV1SandboxvolumesIDFilesPost(ctx, apispec.APIV1SandboxvolumesIDFilesPostReq{Data: body}, apispec.APIV1SandboxvolumesIDFilesPostParams{
+		ID:   volumeID,
+		Path: filePath,
+	}, withContentLength(size))
+	if err != nil {
+		return err
+	}
+	if _, ok := resp.(*apispec.SuccessWrittenResponse); !ok {
+		return unexpectedFileResponse(resp)
+	}
+	return nil
+}
+
+func withContentLength(size int64) apispec.RequestOption {
+	return apispec.WithEditRequest(func(req *http.Request) error {
+		if size >= 0 {
+			req.ContentLength = size
+		}
+		return nil
+	})
+}
+
+// errFileDownloadTaken stops the generated client once the download body has
+// been handed to the caller.
+var errFileDownloadTaken = errors.New("file download body taken")
+
+// fileDownloadStream takes over the body of a successful file download. The
+// generated client reads octet-stream responses fully into memory, so the
+// body is detached before the response is decoded. Requests still go through
+// the SDK for authentication, headers, and error responses.
+type fileDownloadStream struct {
+	offset   int64
+	download *FileDownload
+}
+
+func (s *fileDownloadStream) options() []apispec.RequestOption {
+	return []apispec.RequestOption{
+		apispec.WithRequestClient(s),
+		apispec.WithEditRequest(func(req *http.Request) error {
+			req.Header.Set("Accept", "application/octet-stream")
+			if s.offset > 0 {
+				req.Header.Set("Range", fmt.Sprintf("bytes=%d-", s.offset))
+			}
+			return nil
+		}),
+		apispec.WithEditResponse(func(*http.Response) error {
+			if s.download != nil {
+				return errFileDownloadTaken
+			}
+			return nil
+		}),
+	}
+}
+
+// Do sends req and detaches the body of a raw file response.
+func (s *fileDownloadStream) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil || (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent) {
+		return resp, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType == "application/json" {
+		return resp, nil
+	}
+
+	download := &FileDownload{Body: resp.Body, Size: resp.ContentLength}
+	if resp.StatusCode == http.StatusPartialContent {
+		download.Offset = s.offset
+		download.Size = contentRangeSize(resp.Header.Get("Content-Range"))
+	}
+	s.download = download
+	resp.Body = http.NoBody
+	return resp, nil
+}
+
+// decodeFileContent returns the base64 JSON form of a file as a download.
+func decodeFileContent(data apispec.OptFileContentResponse) (*FileDownload, error) {
+	content, ok := data.Value.Content.Get()
+	if !data.Set || !ok {
+		return nil, &sandbox0.APIError{Code: "unexpected_response", Message: "file content missing from response"}
+	}
+	if encoding, ok := data.Value.Encoding.Get(); ok && encoding != apispec.FileContentResponseEncodingBase64 {
+		return nil, &sandbox0.APIError{Code: "unexpected_response", Message: fmt.Sprintf("unsupported file encoding: %s", encoding)}
+	}
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, err
+	}
+	return &FileDownload{Body: io.NopCloser(strings.NewReader(string(decoded))), Size: int64(len(decoded))}, nil
+}
+
+func unexpectedFileResponse(resp any) error {
+	return &sandbox0.APIError{Code: "unexpected_response", Message: fmt.Sprintf("unexpected file response %T", resp)}
+}
+
+// contentRangeSize returns the complete length from a Content-Range header such
+// as "bytes 100-199/200", or -1 when it is unknown.
+func contentRangeSize(value string) int64 {
+	_, total, ok := strings.Cut(value, "/")
+	if !ok || total == "*" {
+		return -1
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
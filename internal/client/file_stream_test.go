@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+)
+
+func newFileStreamTestClient(t *testing.T, handler http.HandlerFunc, opts ...sandbox0.Option) *sandbox0.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := sandbox0.NewClient(append([]sandbox0.Option{sandbox0.WithBaseURL(server.URL), sandbox0.WithToken("token-1")}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestUploadSandboxFileStreamsBody(t *testing.T) {
+	var gotPath, gotQuery, gotAuth, gotTeam, gotBody string
+	var gotLength int64
+	c := newFileStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query().Get("path")
+		gotAuth = r.Header.Get("Authorization")
+		gotTeam = r.Header.Get("X-Team-ID")
+		gotLength = r.ContentLength
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"written":true}}`))
+	}, sandbox0.WithRequestEditor(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("X-Team-ID", "team-1")
+		return nil
+	}))
+
+	// A reader of unknown length is sent as-is with the given content length.
+	body := io.MultiReader(strings.NewReader("wei"), strings.NewReader("ghts"))
+	if err := UploadSandboxFile(context.Background(), c, "sb_1", "/data/model.bin", body, 7); err != nil {
+		t.Fatalf("UploadSandboxFile() error = %v", err)
+	}
+
+	if gotPath != "/api/v1/sandboxes/sb_1/files" {
+		t.Fatalf("path = %q", gotPath)
+	}
+	if gotQuery != "/data/model.bin" {
+		t.Fatalf("query path = %q", gotQuery)
+	}
+	if gotAuth != "Bearer token-1" || gotTeam != "team-1" {
+		t.Fatalf("headers = %q %q", gotAuth, gotTeam)
+	}
+	if gotBody != "weights" || gotLength != 7 {
+		t.Fatalf("body = %q length = %d", gotBody, gotLength)
+	}
+}
+
+func TestDownloadVolumeFileHonorsRange(t *testing.T) {
+	content := []byte("0123456789")
+	c := newFileStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sandboxvolumes/vol_1/files" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	})
+
+	download, err := DownloadVolumeFile(context.Background(), c, "vol_1", "/file", 4)
+	if err != nil {
+		t.Fatalf("DownloadVolumeFile() error = %v", err)
+	}
+	defer download.Body.Close()
+
+	body, _ := io.ReadAll(download.Body)
+	if string(body) != "456789" {
+		t.Fatalf("body = %q, want 456789", string(body))
+	}
+	if download.Offset != 4 || download.Size != 10 {
+		t.Fatalf("offset = %d size = %d, want 4 and 10", download.Offset, download.Size)
+	}
+}
+
+func TestDownloadSandboxFileStreamsBody(t *testing.T) {
+	release := make(chan struct{})
+	c := newFileStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("first "))
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = w.Write([]byte("second"))
+	})
+
+	// The download opens before the server finishes the body, so the body
+	// was not read into memory first.
+	download, err := DownloadSandboxFile(context.Background(), c, "sb_1", "/file", 3)
+	close(release)
+	if err != nil {
+		t.Fatalf("DownloadSandboxFile() error = %v", err)
+	}
+	defer download.Body.Close()
+	body, _ := io.ReadAll(download.Body)
+	if string(body) != "first second" || download.Offset != 0 {
+		t.Fatalf("body = %q offset = %d, want the whole file from 0", string(body), download.Offset)
+	}
+}
+
+func TestDownloadSandboxFileDecodesJSONContent(t *testing.T) {
+	c := newFileStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":{"content":"aGVsbG8=","encoding":"base64"}}`))
+	})
+
+	download, err := DownloadSandboxFile(context.Background(), c, "sb_1", "/file", 0)
+	if err != nil {
+		t.Fatalf("DownloadSandboxFile() error = %v", err)
+	}
+	body, _ := io.ReadAll(download.Body)
+	if string(body) != "hello" || download.Size != 5 {
+		t.Fatalf("body = %q size = %d", string(body), download.Size)
+	}
+}
+
+func TestDownloadSandboxFileReturnsAPIError(t *testing.T) {
+	c := newFileStreamTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"success":false,"error":{"code":"unavailable","message":"storage busy"}}`))
+	})
+
+	_, err := DownloadSandboxFile(context.Background(), c, "sb_1", "/file", 0)
+	var apiErr *sandbox0.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("DownloadSandboxFile() error = %v, want *sandbox0.APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusServiceUnavailable || apiErr.Code != "unavailable" || apiErr.Message != "storage busy" {
+		t.Fatalf("APIError = %+v", apiErr)
+	}
+	if apiErr.RetryAfterSeconds != 2 {
+		t.Fatalf("RetryAfterSeconds = %d, want 2", apiErr.RetryAfterSeconds)
+	}
+}
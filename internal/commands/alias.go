@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sandbox0-ai/s0/internal/config"
+	"github.com/sandbox0-ai/s0/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// aliasCmd manages local aliases for resource IDs.
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage local aliases for sandbox, volume, and snapshot IDs",
+	Long: `Manage local friendly names for resource IDs.
+
+Aliases are stored next to the config file and scoped by profile and current
+team. Any positional ID argument or *-id flag written as @name is replaced by
+the aliased ID, for example 's0 sandbox get @api-dev'.`,
+}
+
+var aliasSetCmd = &cobra.Command{
+	Use:   "set <name> <id>",
+	Short: "Create or update an alias",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := strings.TrimPrefix(args[0], config.AliasPrefix)
+		if err := setAlias(name, args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error setting alias: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Alias @%s set to %s\n", name, args[1])
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List aliases of the current profile and team",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		profileName, teamID, err := aliasScope()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		aliases, err := config.LoadAliases()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading aliases: %v\n", err)
+			os.Exit(1)
+		}
+		if err := getFormatter().Format(os.Stdout, output.NewAliasList(aliases.List(profileName, teamID))); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var aliasDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"unset"},
+	Short:   "Delete an alias",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := strings.TrimPrefix(args[0], config.AliasPrefix)
+		profileName, teamID, err := aliasScope()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		aliases, err := config.LoadAliases()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading aliases: %v\n", err)
+			os.Exit(1)
+		}
+		if !aliases.Delete(profileName, teamID, name) {
+			fmt.Fprintf(os.Stderr, "Error: alias @%s not found\n", name)
+			os.Exit(1)
+		}
+		if err := aliases.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving aliases: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Alias @%s deleted\n", name)
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd, aliasListCmd, aliasDeleteCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
+
+// aliasScope returns the active profile and its current team, which scope
+// alias lookups.
+func aliasScope() (string, string, error) {
+	cfg, err := getConfig()
+	if err != nil {
+		return "", "", err
+	}
+	profileName := cfg.GetActiveProfile()
+	profile, err := cfg.GetProfile(profileName)
+	if err != nil {
+		return "", "", err
+	}
+	return profileName, profile.GetCurrentTeamID(), nil
+}
+
+// setAlias records name for id in the current profile and team scope.
+func setAlias(name, id string) error {
+	if err := config.ValidateAliasName(name); err != nil {
+		return err
+	}
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("alias target ID cannot be empty")
+	}
+	profileName, teamID, err := aliasScope()
+	if err != nil {
+		return err
+	}
+	aliases, err := config.LoadAliases()
+	if err != nil {
+		return err
+	}
+	aliases.Set(profileName, teamID, name, id)
+	return aliases.Save()
+}
+
+// resolveAliasArgs replaces @name references in the positional arguments
+// before "--" and in *-id flags with the aliased IDs. A reference may be
+// followed by a ":path" suffix, as in "@api-dev:/workspace".
+func resolveAliasArgs(cmd *cobra.Command, args []string) error {
+	if cmd.HasParent() && cmd.Parent() == aliasCmd {
+		// Alias commands take alias names, not references.
+		return nil
+	}
+	end := len(args)
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		end = min(dash, len(args))
+	}
+	hasAlias := false
+	for _, arg := range args[:end] {
+		hasAlias = hasAlias || strings.HasPrefix(arg, config.AliasPrefix)
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		hasAlias = hasAlias || isAliasIDFlag(flag)
+	})
+	if !hasAlias {
+		return nil
+	}
+
+	profileName, teamID, err := aliasScope()
+	if err != nil {
+		return err
+	}
+	aliases, err := config.LoadAliases()
+	if err != nil {
+		return err
+	}
+	resolve := func(value string) (string, error) {
+		name, suffix, _ := strings.Cut(strings.TrimPrefix(value, config.AliasPrefix), ":")
+		id, ok := aliases.Get(profileName, teamID, name)
+		if !ok {
+			return "", fmt.Errorf("unknown alias @%s; run 's0 alias list' to see aliases for profile %q", name, profileName)
+		}
+		if strings.Contains(value, ":") {
+			return id + ":" + suffix, nil
+		}
+		return id, nil
+	}
+
+	for i, arg := range args[:end] {
+		if !strings.HasPrefix(arg, config.AliasPrefix) {
+			continue
+		}
+		if args[i], err = resolve(arg); err != nil {
+			return err
+		}
+	}
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if err != nil || !isAliasIDFlag(flag) {
+			return
+		}
+		var id string
+		if id, err = resolve(flag.Value.String()); err == nil {
+			err = flag.Value.Set(id)
+		}
+	})
+	return err
+}
+
+// isAliasIDFlag reports whether flag is a set *-id flag holding an alias.
+func isAliasIDFlag(flag *pflag.Flag) bool {
+	return flag.Changed && strings.HasSuffix(flag.Name, "-id") &&
+		flag.Value.Type() == "string" && strings.HasPrefix(flag.Value.String(), config.AliasPrefix)
+}
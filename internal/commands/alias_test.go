@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sandbox0-ai/s0/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func useTempAliasConfig(t *testing.T) {
+	t.Helper()
+	originalPath := *config.GetConfigFile()
+	originalProfile := *config.GetProfileVar()
+	t.Cleanup(func() {
+		config.SetConfigFile(originalPath)
+		config.SetProfile(originalProfile)
+	})
+	config.SetConfigFile(t.TempDir() + "/config.yaml")
+	config.SetProfile("")
+}
+
+func TestResolveAliasArgs(t *testing.T) {
+	useTempAliasConfig(t)
+	if err := setAlias("api-dev", "sb_abc123"); err != nil {
+		t.Fatalf("setAlias() error = %v", err)
+	}
+
+	var sandboxID string
+	cmd := &cobra.Command{Use: "exec", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().StringVar(&sandboxID, "sandbox-id", "", "")
+	if err := cmd.ParseFlags([]string{"--sandbox-id", "@api-dev", "@api-dev:/workspace", "plain", "--", "echo", "@api-dev"}); err != nil {
+		t.Fatal(err)
+	}
+	args := cmd.Flags().Args()
+	if err := resolveAliasArgs(cmd, args); err != nil {
+		t.Fatalf("resolveAliasArgs() error = %v", err)
+	}
+
+	want := []string{"sb_abc123:/workspace", "plain", "echo", "@api-dev"}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("args = %q, want %q", args, want)
+		}
+	}
+	if sandboxID != "sb_abc123" {
+		t.Fatalf("--sandbox-id = %q, want sb_abc123", sandboxID)
+	}
+
+	if err := resolveAliasArgs(cmd, []string{"@missing"}); err == nil {
+		t.Fatal("resolveAliasArgs() error = nil for unknown alias")
+	}
+}
+
+func TestResolveAliasArgsIsScopedByProfile(t *testing.T) {
+	useTempAliasConfig(t)
+	if err := setAlias("api-dev", "sb_default"); err != nil {
+		t.Fatalf("setAlias() error = %v", err)
+	}
+	config.SetProfile("prod")
+	if err := setAlias("api-dev", "sb_prod"); err != nil {
+		t.Fatalf("setAlias() error = %v", err)
+	}
+
+	args := []string{"@api-dev"}
+	if err := resolveAliasArgs(&cobra.Command{Use: "get"}, args); err != nil {
+		t.Fatalf("resolveAliasArgs() error = %v", err)
+	}
+	if args[0] != "sb_prod" {
+		t.Fatalf("resolved = %q, want sb_prod", args[0])
+	}
+}
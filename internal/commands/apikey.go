@@ -132,7 +132,7 @@ var apiKeyCreateCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
 			os.Exit(1)
 		}
-		if output.ParseFormat(cfgFormat) == output.FormatTable {
+		if !isStructuredOutput() {
 			fmt.Fprintln(os.Stderr, "Warning: API key is shown only once. Save it now; it cannot be retrieved again.")
 		}
 	},
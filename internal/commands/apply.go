@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	manifestFiles     []string
+	manifestRecursive bool
+	diffExitCode      bool
+)
+
+const manifestExample = `Manifest format:
+  kind: Template
+  metadata:
+    name: python-dev
+  spec:
+    mainContainer:
+      image: python:3.12
+      resources:
+        memory: 2Gi
+  ---
+  kind: Volume
+  metadata:
+    id: vol_abc123        # required; create the volume with s0 volume create
+  spec:
+    access_mode: RWO
+  ---
+  kind: CredentialSource
+  metadata:
+    name: github
+  spec:
+    resolverKind: static_headers
+    staticHeaders:
+      values:
+        Authorization: Bearer ...
+  ---
+  kind: NetworkPolicy
+  metadata:
+    sandbox: sb_abc123
+  spec:
+    mode: block-all
+    egress:
+      allowedDomains: [api.github.com]
+  ---
+  kind: Services
+  metadata:
+    sandbox: sb_abc123
+  spec:
+    services:
+      - id: web
+        port: 3000
+        ingress:
+          public: true
+          routes:
+            - id: web
+              path_prefix: /`
+
+// applyCmd creates or updates the objects described by manifests.
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file|dir|->",
+	Short: "Create or update resources from manifests",
+	Long: `Create or update templates, volumes, credential sources, network policies,
+and services from YAML or JSON manifests.
+
+Each document declares a kind, metadata identifying the object, and a spec.
+Objects are applied in dependency order and only changed objects are updated.
+Volume documents must reference an existing volume by metadata.id; create
+volumes with s0 volume create. Volumes cannot be changed after creation.
+
+` + manifestExample + `
+
+Examples:
+  s0 apply -f s0/
+  s0 apply -f stack.yaml
+  cat stack.yaml | s0 apply -f -`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		objects, err := loadManifestObjects(false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading manifests: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		failed := false
+		for _, object := range objects {
+			plan, err := planManifestObject(cmd.Context(), client, object)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error applying %s: %v\n", object.Ref(), err)
+				failed = true
+				continue
+			}
+
+			switch plan.Action {
+			case manifestActionCreate:
+				ref, err := object.Create(cmd.Context(), client)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", object.Ref(), err)
+					failed = true
+					continue
+				}
+				fmt.Printf("%s created\n", ref)
+			case manifestActionUpdate:
+				if err := object.Update(cmd.Context(), client); err != nil {
+					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", object.Ref(), err)
+					failed = true
+					continue
+				}
+				fmt.Printf("%s configured\n", object.Ref())
+			default:
+				fmt.Printf("%s unchanged\n", object.Ref())
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// diffCmd shows what apply would change.
+var diffCmd = &cobra.Command{
+	Use:   "diff -f <file|dir|->",
+	Short: "Show changes apply would make",
+	Long: `Compare manifests with the server and print field-level differences.
+
+Only fields declared in the manifest are compared; fields set by the server
+are ignored. Credential source secrets are never returned by the server, so
+existing credential sources are always reported as updated.
+
+Lines are prefixed with "+" for added, "-" for removed, and "~" for changed
+fields. With --exit-code, diff exits with status 1 when there are changes.
+
+Examples:
+  s0 diff -f stack.yaml
+  s0 diff -f s0/ -R --exit-code`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		objects, err := loadManifestObjects(false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading manifests: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		changed := false
+		for _, object := range objects {
+			plan, err := planManifestObject(cmd.Context(), client, object)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error comparing %s: %v\n", object.Ref(), err)
+				os.Exit(2)
+			}
+			writeManifestPlan(os.Stdout, plan)
+			if plan.Action != manifestActionUnchanged {
+				changed = true
+			}
+		}
+		if changed && diffExitCode {
+			os.Exit(1)
+		}
+	},
+}
+
+// deleteCmd deletes the objects described by manifests.
+var deleteCmd = &cobra.Command{
+	Use:   "delete -f <file|dir|->",
+	Short: "Delete resources described by manifests",
+	Long: `Delete the templates, volumes, and credential sources described by manifests.
+
+Objects are deleted in reverse dependency order. NetworkPolicy documents reset
+the sandbox to allow-all, and Services documents remove all exposed services.
+Objects that no longer exist are reported and skipped.
+
+Examples:
+  s0 delete -f stack.yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		objects, err := loadManifestObjects(true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading manifests: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		failed := false
+		for _, object := range objects {
+			err := object.Delete(cmd.Context(), client)
+			switch {
+			case isNotFoundError(err):
+				fmt.Printf("%s not found\n", object.Ref())
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "Error deleting %s: %v\n", object.Ref(), err)
+				failed = true
+			default:
+				fmt.Printf("%s deleted\n", object.Ref())
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+// loadManifestObjects reads and validates --filename manifests in apply order,
+// or reverse order for deletes.
+func loadManifestObjects(reverse bool) ([]manifestObject, error) {
+	documents, err := readManifestFiles(manifestFiles, manifestRecursive)
+	if err != nil {
+		return nil, err
+	}
+	sortManifestDocuments(documents, reverse)
+
+	objects := make([]manifestObject, 0, len(documents))
+	for _, document := range documents {
+		object, err := buildManifestObject(document)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{applyCmd, diffCmd, deleteCmd} {
+		cmd.Flags().StringSliceVarP(&manifestFiles, "filename", "f", nil, "manifest file, directory, or - for stdin (repeatable)")
+		cmd.Flags().BoolVarP(&manifestRecursive, "recursive", "R", false, "read directories recursively")
+		_ = cmd.MarkFlagRequired("filename")
+		rootCmd.AddCommand(cmd)
+	}
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "exit with status 1 when there are differences")
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -168,16 +167,6 @@ func resolveBuiltinCredentials() (string, string) {
 	return email, password
 }
 
-func setTerminalEcho(enabled bool) error {
-	mode := "echo"
-	if !enabled {
-		mode = "-echo"
-	}
-	cmd := exec.Command("stty", mode)
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
-}
-
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(authLoginCmd)
@@ -0,0 +1,562 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/docker"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+// defaultDevcontainerMemory is the template memory used when devcontainer.json
+// and --memory do not set one.
+const defaultDevcontainerMemory = "2Gi"
+
+// devcontainerTemplateTag marks templates created from a devcontainer. Only
+// templates carrying it are updated by --devcontainer, so an existing template
+// named with --template is never replaced by accident.
+const devcontainerTemplateTag = "devcontainer"
+
+// devcontainerFileCandidates are the locations searched when --devcontainer
+// names a directory, in the order the Dev Containers spec uses.
+var devcontainerFileCandidates = []string{
+	filepath.Join(".devcontainer", "devcontainer.json"),
+	".devcontainer.json",
+	"devcontainer.json",
+}
+
+// devcontainerConfig is the subset of devcontainer.json that maps onto a
+// sandbox template and claim.
+type devcontainerConfig struct {
+	// Dir is the directory of devcontainer.json; build paths are relative to it.
+	Dir string
+	// TemplateID is the template created or updated for the devcontainer.
+	TemplateID string
+	Image      string
+	Build      *devcontainerBuild
+	Memory     string
+	Env        map[string]string
+	Ports      []int32
+	Mounts     []apispec.ClaimMountRequest
+	// PostCreate holds the postCreateCommand, one argv per command.
+	PostCreate [][]string
+}
+
+// devcontainerBuild is the build section of devcontainer.json.
+type devcontainerBuild struct {
+	Dockerfile string
+	Context    string
+	Args       map[string]string
+}
+
+// findDevcontainerFile resolves path to a devcontainer.json file. A directory
+// is searched for the standard file locations.
+func findDevcontainerFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	for _, candidate := range devcontainerFileCandidates {
+		file := filepath.Join(path, candidate)
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		}
+	}
+	return "", fmt.Errorf("no devcontainer.json found in %s", path)
+}
+
+// loadDevcontainer reads the devcontainer.json at path. The template ID is
+// templateID when set, otherwise it is derived from the devcontainer name or
+// the project directory.
+func loadDevcontainer(path, templateID string) (*devcontainerConfig, error) {
+	file, err := findDevcontainerFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	dc, name, err := parseDevcontainer(data, os.LookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	dc.Dir = filepath.Dir(abs)
+
+	dc.TemplateID = templateID
+	if dc.TemplateID == "" {
+		if name == "" {
+			project := dc.Dir
+			if filepath.Base(project) == ".devcontainer" {
+				project = filepath.Dir(project)
+			}
+			name = filepath.Base(project)
+		}
+		slug := devcontainerSlug(name)
+		if slug == "" {
+			return nil, fmt.Errorf("cannot derive a template ID from %q; set --template", name)
+		}
+		dc.TemplateID = "devcontainer-" + slug
+	}
+	return dc, nil
+}
+
+// parseDevcontainer parses devcontainer.json content and returns the config
+// and the devcontainer name. Keys that cannot be mapped onto a sandbox are
+// reported as an error instead of being silently ignored.
+func parseDevcontainer(data []byte, lookupEnv func(string) (string, bool)) (*devcontainerConfig, string, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(stripJSONC(data), &document); err != nil {
+		return nil, "", fmt.Errorf("parse devcontainer.json: %w", err)
+	}
+
+	dc := &devcontainerConfig{}
+	var name string
+	var unsupported []string
+	for key, raw := range document {
+		var err error
+		switch key {
+		case "$schema":
+		case "name":
+			err = json.Unmarshal(raw, &name)
+		case "image":
+			err = json.Unmarshal(raw, &dc.Image)
+		case "build":
+			dc.Build, err = parseDevcontainerBuild(raw, &unsupported)
+		case "containerEnv":
+			dc.Env, err = parseDevcontainerEnv(raw, lookupEnv)
+		case "forwardPorts":
+			dc.Ports, err = parseDevcontainerPorts(raw)
+		case "postCreateCommand":
+			dc.PostCreate, err = parseDevcontainerCommand(raw)
+		case "mounts":
+			dc.Mounts, err = parseDevcontainerMounts(raw)
+		case "hostRequirements":
+			dc.Memory, err = parseDevcontainerHostRequirements(raw, &unsupported)
+		default:
+			unsupported = append(unsupported, key)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return nil, "", fmt.Errorf("unsupported devcontainer.json keys: %s; remove them to create a sandbox from this file", strings.Join(unsupported, ", "))
+	}
+
+	switch {
+	case dc.Image == "" && dc.Build == nil:
+		return nil, "", fmt.Errorf("image or build.dockerfile is required")
+	case dc.Image != "" && dc.Build != nil:
+		return nil, "", fmt.Errorf("image and build cannot be combined")
+	}
+	return dc, name, nil
+}
+
+func parseDevcontainerBuild(raw json.RawMessage, unsupported *[]string) (*devcontainerBuild, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, err
+	}
+	build := &devcontainerBuild{Context: "."}
+	for key, value := range document {
+		var err error
+		switch key {
+		case "dockerfile":
+			err = json.Unmarshal(value, &build.Dockerfile)
+		case "context":
+			err = json.Unmarshal(value, &build.Context)
+		case "args":
+			err = json.Unmarshal(value, &build.Args)
+		default:
+			*unsupported = append(*unsupported, "build."+key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if build.Dockerfile == "" {
+		return nil, fmt.Errorf("dockerfile is required")
+	}
+	return build, nil
+}
+
+func parseDevcontainerHostRequirements(raw json.RawMessage, unsupported *[]string) (string, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return "", err
+	}
+	var memory string
+	for key, value := range document {
+		if key != "memory" {
+			// CPU and storage are derived by the platform, like template CPU.
+			*unsupported = append(*unsupported, "hostRequirements."+key)
+			continue
+		}
+		var quantity string
+		if err := json.Unmarshal(value, &quantity); err != nil {
+			return "", fmt.Errorf("memory: %w", err)
+		}
+		var err error
+		if memory, err = devcontainerMemoryQuantity(quantity); err != nil {
+			return "", err
+		}
+	}
+	return memory, nil
+}
+
+var devcontainerMemoryPattern = regexp.MustCompile(`^(?i)\s*(\d+(?:\.\d+)?)\s*(kb|mb|gb|tb)\s*$`)
+
+// devcontainerMemoryQuantity converts a devcontainer memory size such as
+// "4gb" to a Kubernetes quantity such as "4Gi".
+func devcontainerMemoryQuantity(value string) (string, error) {
+	match := devcontainerMemoryPattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", fmt.Errorf("invalid memory %q, expected a size such as 4gb", value)
+	}
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return "", err
+	}
+	unitMiB := map[string]float64{"kb": 1.0 / 1024, "mb": 1, "gb": 1024, "tb": 1024 * 1024}[strings.ToLower(match[2])]
+	mib := int64(math.Ceil(amount * unitMiB))
+	if mib <= 0 {
+		return "", fmt.Errorf("invalid memory %q, must be positive", value)
+	}
+	if mib%1024 == 0 {
+		return fmt.Sprintf("%dGi", mib/1024), nil
+	}
+	return fmt.Sprintf("%dMi", mib), nil
+}
+
+var devcontainerVariablePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// parseDevcontainerEnv parses containerEnv, expanding ${localEnv:NAME} and
+// ${localEnv:NAME:default}. Other variables refer to container state the CLI
+// does not know and are rejected.
+func parseDevcontainerEnv(raw json.RawMessage, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	var env map[string]string
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, err
+	}
+	for key, value := range env {
+		var expandErr error
+		env[key] = devcontainerVariablePattern.ReplaceAllStringFunc(value, func(variable string) string {
+			name, ok := strings.CutPrefix(variable[2:len(variable)-1], "localEnv:")
+			if !ok {
+				expandErr = fmt.Errorf("%s: unsupported variable %s", key, variable)
+				return variable
+			}
+			name, fallback, _ := strings.Cut(name, ":")
+			if local, ok := lookupEnv(name); ok {
+				return local
+			}
+			return fallback
+		})
+		if expandErr != nil {
+			return nil, expandErr
+		}
+	}
+	return env, nil
+}
+
+func parseDevcontainerPorts(raw json.RawMessage) ([]int32, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+	ports := make([]int32, 0, len(values))
+	for i, value := range values {
+		var port int32
+		if err := json.Unmarshal(value, &port); err != nil {
+			return nil, fmt.Errorf("[%d]: %s is not supported; only ports of the main container can be forwarded", i, value)
+		}
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("[%d]: invalid port %d", i, port)
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// parseDevcontainerCommand parses a lifecycle command: a shell string, an
+// argv array, or an object of named commands, which run in name order.
+func parseDevcontainerCommand(raw json.RawMessage) ([][]string, error) {
+	var shell string
+	if err := json.Unmarshal(raw, &shell); err == nil {
+		return [][]string{{"/bin/sh", "-c", shell}}, nil
+	}
+	var argv []string
+	if err := json.Unmarshal(raw, &argv); err == nil {
+		if len(argv) == 0 {
+			return nil, nil
+		}
+		return [][]string{argv}, nil
+	}
+	var named map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &named); err != nil {
+		return nil, fmt.Errorf("expected a string, an array, or an object of commands")
+	}
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var commands [][]string
+	for _, name := range names {
+		if bytes.HasPrefix(bytes.TrimSpace(named[name]), []byte("{")) {
+			return nil, fmt.Errorf("%s: expected a string or an array", name)
+		}
+		parsed, err := parseDevcontainerCommand(named[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		commands = append(commands, parsed...)
+	}
+	return commands, nil
+}
+
+// parseDevcontainerMounts maps volume mounts whose source is a sandbox volume
+// ID to bootstrap mounts. Mounts are strings such as
+// "type=volume,source=vol_abc,target=/data" or objects with the same keys.
+func parseDevcontainerMounts(raw json.RawMessage) ([]apispec.ClaimMountRequest, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+	mounts := make([]apispec.ClaimMountRequest, 0, len(values))
+	for i, value := range values {
+		fields := map[string]string{}
+		var spec string
+		if err := json.Unmarshal(value, &spec); err == nil {
+			for _, part := range strings.Split(spec, ",") {
+				key, item, _ := strings.Cut(strings.TrimSpace(part), "=")
+				fields[key] = item
+			}
+		} else if err := json.Unmarshal(value, &fields); err != nil {
+			return nil, fmt.Errorf("[%d]: expected a string or an object", i)
+		}
+
+		mountType := fields["type"]
+		source := firstNonEmpty(fields["source"], fields["src"])
+		target := firstNonEmpty(fields["target"], fields["destination"], fields["dst"])
+		if mountType != "volume" {
+			return nil, fmt.Errorf("[%d]: %s mounts are not supported; use type=volume with a sandbox volume ID as source", i, firstNonEmpty(mountType, "untyped"))
+		}
+		if source == "" || !strings.HasPrefix(target, "/") {
+			return nil, fmt.Errorf("[%d]: source and an absolute target are required", i)
+		}
+		mounts = append(mounts, apispec.ClaimMountRequest{SandboxvolumeID: source, MountPoint: target})
+	}
+	return mounts, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// stripJSONC removes comments and trailing commas, which devcontainer.json
+// allows, so the content can be decoded as JSON.
+func stripJSONC(data []byte) []byte {
+	var out bytes.Buffer
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out.WriteByte(data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			out.WriteByte('\n')
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(data)
+			} else {
+				i += end + 3
+			}
+			out.WriteByte(' ')
+		case c == ']' || c == '}':
+			trimmed := bytes.TrimRight(out.Bytes(), " \t\r\n")
+			if len(trimmed) > 0 && trimmed[len(trimmed)-1] == ',' {
+				out.Truncate(len(trimmed) - 1)
+			}
+			out.WriteByte(c)
+		default:
+			out.WriteByte(c)
+		}
+	}
+	return out.Bytes()
+}
+
+var devcontainerSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+func devcontainerSlug(name string) string {
+	return strings.Trim(devcontainerSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// applyDevcontainerClaim points the claim at the devcontainer template and
+// adds its environment, forwarded ports, and mounts. Values already present
+// in the claim take precedence over containerEnv.
+func applyDevcontainerClaim(request *apispec.ClaimRequest, dc *devcontainerConfig) {
+	request.Template = apispec.NewOptString(dc.TemplateID)
+	request.Mounts = append(request.Mounts, dc.Mounts...)
+	if len(dc.Env) == 0 && len(dc.Ports) == 0 {
+		return
+	}
+
+	config := request.Config.Or(apispec.SandboxConfig{})
+	if len(dc.Env) > 0 {
+		env := apispec.SandboxConfigEnvVars{}
+		for key, value := range dc.Env {
+			env[key] = value
+		}
+		for key, value := range config.EnvVars.Or(nil) {
+			env[key] = value
+		}
+		config.EnvVars = apispec.NewOptSandboxConfigEnvVars(env)
+	}
+	for _, port := range dc.Ports {
+		id := fmt.Sprintf("port-%d", port)
+		config.Services = append(config.Services, apispec.SandboxAppService{
+			ID:   id,
+			Port: apispec.NewOptInt32(port),
+			Ingress: apispec.SandboxAppServiceIngress{
+				Routes: []apispec.SandboxAppServiceRoute{{ID: id, PathPrefix: apispec.NewOptString("/"), Resume: true}},
+			},
+		})
+	}
+	request.Config = apispec.NewOptSandboxConfig(config)
+}
+
+// devcontainerTemplateSpec returns the template spec for a devcontainer image.
+func devcontainerTemplateSpec(image, memory string) apispec.SandboxTemplateSpec {
+	return apispec.SandboxTemplateSpec{
+		Tags: []string{devcontainerTemplateTag},
+		MainContainer: apispec.NewOptContainerSpec(apispec.ContainerSpec{
+			Image:     image,
+			Resources: apispec.ResourceQuota{Memory: memory},
+		}),
+	}
+}
+
+// prepareDevcontainerTemplate builds and pushes the devcontainer image when
+// it has a build section, then creates or updates its template. Progress is
+// written to stderr so stdout only carries the created sandbox.
+func prepareDevcontainerTemplate(cmd *cobra.Command, client *sandbox0.Client, dc *devcontainerConfig) error {
+	ctx := cmd.Context()
+	image := dc.Image
+	if dc.Build != nil {
+		tag := fmt.Sprintf("%s:%s", dc.TemplateID, time.Now().UTC().Format("20060102150405"))
+		builder, err := docker.NewBuilder()
+		if err != nil {
+			return err
+		}
+		args := make(map[string]*string, len(dc.Build.Args))
+		for key, value := range dc.Build.Args {
+			args[key] = &value
+		}
+		err = builder.Build(ctx, docker.BuildOptions{
+			Context:    filepath.Join(dc.Dir, dc.Build.Context),
+			Dockerfile: filepath.Join(dc.Dir, dc.Build.Dockerfile),
+			Tags:       []string{tag},
+			BuildArgs:  args,
+			Progress:   os.Stderr,
+		})
+		if err != nil {
+			return err
+		}
+		registry, err := getClient(cmd)
+		if err != nil {
+			return err
+		}
+		if _, image, err = pushTemplateImage(ctx, registry, tag, tag, os.Stderr); err != nil {
+			return fmt.Errorf("pushing image: %w", err)
+		}
+	}
+
+	memory := firstNonEmpty(dc.Memory, sandboxMemory, defaultDevcontainerMemory)
+	object := &templateManifestObject{id: dc.TemplateID, spec: devcontainerTemplateSpec(image, memory)}
+	plan, err := planManifestObject(ctx, client, object)
+	if err != nil {
+		return err
+	}
+	switch plan.Action {
+	case manifestActionCreate:
+		if _, err := object.Create(ctx, client); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s created\n", object.Ref())
+	case manifestActionUpdate:
+		template, err := client.GetTemplate(ctx, dc.TemplateID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(template.Spec.Tags, devcontainerTemplateTag) {
+			return fmt.Errorf("template %s was not created from a devcontainer and will not be replaced; choose another --template or omit it", dc.TemplateID)
+		}
+		if err := object.Update(ctx, client); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s configured\n", object.Ref())
+	default:
+		fmt.Fprintf(os.Stderr, "%s unchanged\n", object.Ref())
+	}
+	return nil
+}
+
+// runDevcontainerPostCreate runs the postCreateCommand in the sandbox and
+// streams its output to stderr.
+func runDevcontainerPostCreate(ctx context.Context, sandbox *sandbox0.Sandbox, dc *devcontainerConfig) error {
+	for _, argv := range dc.PostCreate {
+		fmt.Fprintf(os.Stderr, "Running postCreateCommand: %s\n", strings.Join(argv, " "))
+		result, err := sandbox.Cmd(ctx, strings.Join(argv, " "), sandbox0.WithCommand(argv))
+		if err != nil {
+			return err
+		}
+		if err := writeCmdResultOutput(os.Stderr, os.Stderr, result); err != nil {
+			return err
+		}
+		if code, failed := remoteExecFailureCode(result.ExitCode); failed {
+			return fmt.Errorf("postCreateCommand exited with status %d", code)
+		}
+	}
+	return nil
+}
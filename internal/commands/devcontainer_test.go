@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+const testDevcontainerJSON = `{
+  // Python development container
+  "name": "API Dev",
+  "image": "mcr.microsoft.com/devcontainers/python:3.12",
+  "containerEnv": {
+    "APP_ENV": "dev",
+    "TOKEN": "${localEnv:API_TOKEN}",
+    "REGION": "${localEnv:MISSING:us-east-1}", /* default applies */
+  },
+  "forwardPorts": [8000, 5432],
+  "hostRequirements": {"memory": "4gb"},
+  "mounts": [
+    "type=volume,source=vol_data,target=/workspace/data",
+    {"type": "volume", "source": "vol_cache", "target": "/cache"},
+  ],
+  "postCreateCommand": {"deps": "pip install -r requirements.txt", "db": ["make", "migrate"]},
+}`
+
+func testLookupEnv(name string) (string, bool) {
+	if name == "API_TOKEN" {
+		return "secret", true
+	}
+	return "", false
+}
+
+func TestParseDevcontainer(t *testing.T) {
+	dc, name, err := parseDevcontainer([]byte(testDevcontainerJSON), testLookupEnv)
+	if err != nil {
+		t.Fatalf("parseDevcontainer() error = %v", err)
+	}
+	if name != "API Dev" || dc.Image != "mcr.microsoft.com/devcontainers/python:3.12" || dc.Memory != "4Gi" {
+		t.Fatalf("name = %q, image = %q, memory = %q", name, dc.Image, dc.Memory)
+	}
+	wantEnv := map[string]string{"APP_ENV": "dev", "TOKEN": "secret", "REGION": "us-east-1"}
+	if !reflect.DeepEqual(dc.Env, wantEnv) {
+		t.Fatalf("env = %v, want %v", dc.Env, wantEnv)
+	}
+	if !reflect.DeepEqual(dc.Ports, []int32{8000, 5432}) {
+		t.Fatalf("ports = %v", dc.Ports)
+	}
+	wantMounts := []apispec.ClaimMountRequest{
+		{SandboxvolumeID: "vol_data", MountPoint: "/workspace/data"},
+		{SandboxvolumeID: "vol_cache", MountPoint: "/cache"},
+	}
+	if !reflect.DeepEqual(dc.Mounts, wantMounts) {
+		t.Fatalf("mounts = %+v", dc.Mounts)
+	}
+	wantPostCreate := [][]string{{"make", "migrate"}, {"/bin/sh", "-c", "pip install -r requirements.txt"}}
+	if !reflect.DeepEqual(dc.PostCreate, wantPostCreate) {
+		t.Fatalf("postCreate = %q, want %q", dc.PostCreate, wantPostCreate)
+	}
+}
+
+func TestParseDevcontainerReportsUnsupportedKeys(t *testing.T) {
+	data := `{"image": "python:3.12", "features": {}, "runArgs": ["--privileged"], "hostRequirements": {"cpus": 4}}`
+	_, _, err := parseDevcontainer([]byte(data), testLookupEnv)
+	if err == nil {
+		t.Fatal("parseDevcontainer() error = nil, want unsupported keys")
+	}
+	if !strings.Contains(err.Error(), "features, hostRequirements.cpus, runArgs") {
+		t.Fatalf("error = %v, want sorted unsupported keys", err)
+	}
+
+	for name, data := range map[string]string{
+		"no image":        `{"name": "x"}`,
+		"bind mount":      `{"image": "x", "mounts": ["type=bind,source=/src,target=/src"]}`,
+		"compose port":    `{"image": "x", "forwardPorts": ["db:5432"]}`,
+		"container var":   `{"image": "x", "containerEnv": {"P": "${containerWorkspaceFolder}"}}`,
+		"image and build": `{"image": "x", "build": {"dockerfile": "Dockerfile"}}`,
+	} {
+		if _, _, err := parseDevcontainer([]byte(data), testLookupEnv); err == nil {
+			t.Fatalf("%s: parseDevcontainer() error = nil, want error", name)
+		}
+	}
+}
+
+func TestStripJSONCKeepsStrings(t *testing.T) {
+	got := string(stripJSONC([]byte(`{"url": "http://x//y", "a": [1, 2,], /* c */ "b": "/*no*/"} // end`)))
+	want := `{"url": "http://x//y", "a": [1, 2],   "b": "/*no*/"} ` + "\n"
+	if got != want {
+		t.Fatalf("stripJSONC() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadDevcontainerDerivesTemplateID(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "My_API")
+	dir := filepath.Join(project, ".devcontainer")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "devcontainer.json"), []byte(`{"build": {"dockerfile": "Dockerfile", "context": ".."}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dc, err := loadDevcontainer(project, "")
+	if err != nil {
+		t.Fatalf("loadDevcontainer() error = %v", err)
+	}
+	if dc.TemplateID != "devcontainer-my-api" || dc.Dir != dir || dc.Build.Context != ".." {
+		t.Fatalf("devcontainer = %+v", dc)
+	}
+	if dc, err = loadDevcontainer(project, "custom"); err != nil || dc.TemplateID != "custom" {
+		t.Fatalf("loadDevcontainer(custom) = %+v, %v", dc, err)
+	}
+}
+
+func TestBuildSandboxCreateRequestWithDevcontainer(t *testing.T) {
+	resetSandboxFlagsForTest()
+	t.Cleanup(resetSandboxFlagsForTest)
+	sandboxTTL = 600
+
+	dc := &devcontainerConfig{
+		TemplateID: "devcontainer-api",
+		Env:        map[string]string{"APP_ENV": "dev"},
+		Ports:      []int32{8000},
+		Mounts:     []apispec.ClaimMountRequest{{SandboxvolumeID: "vol_data", MountPoint: "/data"}},
+	}
+	request, err := buildSandboxCreateRequest(dc)
+	if err != nil {
+		t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+	}
+	if request.Template.Or("") != "devcontainer-api" || len(request.Mounts) != 1 {
+		t.Fatalf("request = %+v", request)
+	}
+	config := request.Config.Or(apispec.SandboxConfig{})
+	if config.TTL.Or(0) != 600 || config.EnvVars.Or(nil)["APP_ENV"] != "dev" {
+		t.Fatalf("config = %+v", config)
+	}
+	if len(config.Services) != 1 || config.Services[0].ID != "port-8000" || config.Services[0].Port.Or(0) != 8000 {
+		t.Fatalf("services = %+v", config.Services)
+	}
+}
+
+func TestDevcontainerMemoryQuantity(t *testing.T) {
+	for value, want := range map[string]string{"4gb": "4Gi", "512MB": "512Mi", "1.5gb": "1536Mi", "1tb": "1024Gi"} {
+		if got, err := devcontainerMemoryQuantity(value); err != nil || got != want {
+			t.Fatalf("devcontainerMemoryQuantity(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := devcontainerMemoryQuantity("4"); err == nil {
+		t.Fatal("devcontainerMemoryQuantity(\"4\") error = nil, want error")
+	}
+}
+
+func TestPrepareDevcontainerTemplateRefusesForeignTemplate(t *testing.T) {
+	var updated bool
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/templates/python":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"success":true,"data":{"template_id":"python","scope":"team","spec":{"tags":["ml"],"mainContainer":{"image":"python:3.12","resources":{"memory":"2Gi"}}},"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}}`))
+		case r.Method == http.MethodPut:
+			updated = true
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	dc := &devcontainerConfig{TemplateID: "python", Image: "mcr.microsoft.com/devcontainers/python:3.12"}
+	err := prepareDevcontainerTemplate(cmd, client, dc)
+	if err == nil || !strings.Contains(err.Error(), "not created from a devcontainer") {
+		t.Fatalf("prepareDevcontainerTemplate() error = %v, want refusal", err)
+	}
+	if updated {
+		t.Fatal("prepareDevcontainerTemplate() updated a template it did not create")
+	}
+}
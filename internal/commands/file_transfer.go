@@ -0,0 +1,357 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultTransferRetries = 3
+	partialDownloadSuffix  = ".s0part"
+	progressRedrawInterval = 200 * time.Millisecond
+)
+
+// fileTransferFlags holds the streaming transfer flags of an upload or
+// download command.
+type fileTransferFlags struct {
+	progress bool
+	verify   bool
+	resume   bool
+	retries  int
+}
+
+// fileTransferOptions are the resolved streaming transfer settings.
+type fileTransferOptions struct {
+	Progress bool
+	Verify   bool
+	Resume   bool
+	Retries  int
+}
+
+func addFileTransferFlags(cmd *cobra.Command, flags *fileTransferFlags) {
+	cmd.Flags().BoolVar(&flags.progress, "progress", false, "show a progress bar on stderr for single files (default when stderr is a terminal)")
+	cmd.Flags().BoolVar(&flags.verify, "verify", false, "verify the sha256 checksum after the transfer completes")
+	cmd.Flags().IntVar(&flags.retries, "retries", defaultTransferRetries, "number of times to retry after a network failure")
+}
+
+func (f *fileTransferFlags) options(cmd *cobra.Command) (fileTransferOptions, error) {
+	opts := fileTransferOptions{
+		Progress: isTerminalFile(os.Stderr),
+		Verify:   f.verify,
+		Resume:   f.resume,
+		Retries:  f.retries,
+	}
+	if cmd.Flags().Changed("progress") {
+		opts.Progress = f.progress
+	}
+	if opts.Retries < 0 {
+		return opts, fmt.Errorf("--retries must not be negative")
+	}
+	return opts, nil
+}
+
+// transferProgress renders a single-line progress bar with bytes, rate, and ETA.
+type transferProgress struct {
+	out      io.Writer
+	label    string
+	total    int64
+	done     int64
+	start    time.Time
+	lastDraw time.Time
+	lastLen  int
+	now      func() time.Time
+}
+
+func newTransferProgress(enabled bool, label string, total int64) *transferProgress {
+	if !enabled {
+		return nil
+	}
+	now := time.Now
+	return &transferProgress{out: os.Stderr, label: label, total: total, start: now(), now: now}
+}
+
+// Set records the absolute number of transferred bytes.
+func (p *transferProgress) Set(done int64) {
+	if p == nil {
+		return
+	}
+	p.done = done
+	p.draw(false)
+}
+
+// Add records n more transferred bytes.
+func (p *transferProgress) Add(n int64) {
+	if p == nil {
+		return
+	}
+	p.Set(p.done + n)
+}
+
+// SetTotal updates the expected transfer size; negative means unknown.
+func (p *transferProgress) SetTotal(total int64) {
+	if p == nil {
+		return
+	}
+	p.total = total
+}
+
+// Finish draws the final state and ends the progress line.
+func (p *transferProgress) Finish() {
+	if p == nil {
+		return
+	}
+	p.draw(true)
+	fmt.Fprintln(p.out)
+}
+
+func (p *transferProgress) draw(force bool) {
+	now := p.now()
+	if !force && now.Sub(p.lastDraw) < progressRedrawInterval {
+		return
+	}
+	p.lastDraw = now
+	line := p.line(now)
+	padding := ""
+	if p.lastLen > len(line) {
+		padding = strings.Repeat(" ", p.lastLen-len(line))
+	}
+	p.lastLen = len(line)
+	fmt.Fprintf(p.out, "\r%s%s", line, padding)
+}
+
+func (p *transferProgress) line(now time.Time) string {
+	elapsed := now.Sub(p.start).Seconds()
+	rate := float64(0)
+	if elapsed > 0 {
+		rate = float64(p.done) / elapsed
+	}
+	rateText := output.FormatBytes(int64(rate)) + "/s"
+	if p.total < 0 {
+		return fmt.Sprintf("%s  %s  %s", p.label, output.FormatBytes(p.done), rateText)
+	}
+
+	percent := 100.0
+	if p.total > 0 {
+		percent = float64(p.done) * 100 / float64(p.total)
+	}
+	eta := "--"
+	if p.done >= p.total {
+		eta = "0s"
+	} else if rate > 0 {
+		eta = (time.Duration(float64(p.total-p.done)/rate) * time.Second).Round(time.Second).String()
+	}
+	return fmt.Sprintf("%s  %5.1f%%  %s / %s  %s  ETA %s",
+		p.label, percent, output.FormatBytes(p.done), output.FormatBytes(p.total), rateText, eta)
+}
+
+type progressReader struct {
+	r        io.Reader
+	progress *transferProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.progress.Add(int64(n))
+	return n, err
+}
+
+// retryTransfer runs fn until it succeeds, returns a permanent error, or the
+// retry budget is exhausted.
+func retryTransfer(ctx context.Context, retries int, what string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries || !isRetryableTransferError(err) || ctx.Err() != nil {
+			return err
+		}
+
+		delay := time.Duration(attempt+1) * time.Second
+		var apiErr *sandbox0.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfterSeconds > 0 {
+			delay = time.Duration(apiErr.RetryAfterSeconds) * time.Second
+		}
+		fmt.Fprintf(os.Stderr, "\n%s failed (%v); retrying in %s (%d/%d)\n", what, err, delay, attempt+1, retries)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isRetryableTransferError reports whether err looks like a transient network
+// or server failure rather than a request the server rejected.
+func isRetryableTransferError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		return false
+	}
+	var apiErr *sandbox0.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var pathErr *os.PathError
+	return !errors.As(err, &pathErr)
+}
+
+// uploadFile uploads a single local file. With opts.Resume, the upload is
+// skipped when the remote file already has the same size and is not older,
+// so rerunning an interrupted transfer does not send it again. It returns the
+// number of bytes sent.
+func uploadFile(ctx context.Context, fs remoteFileSystem, localPath, remotePath string, opts fileTransferOptions) (int64, error) {
+	if opts.Resume {
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return 0, err
+		}
+		remote, err := fs.StatFile(ctx, remotePath)
+		if err != nil && !isNotFoundError(err) {
+			return 0, err
+		}
+		if err == nil && remote.Type.Or("") == apispec.FileInfoTypeFile && remote.Size.Or(-1) == info.Size() &&
+			!info.ModTime().Truncate(time.Second).After(remote.ModTime.Or(time.Time{}).Truncate(time.Second)) {
+			return 0, nil
+		}
+	}
+	return uploadFileStream(ctx, fs, localPath, remotePath, opts)
+}
+
+// uploadFileStream streams localPath to remotePath with bounded memory,
+// restarting the transfer after transient failures.
+func uploadFileStream(ctx context.Context, fs remoteFileSystem, localPath, remotePath string, opts fileTransferOptions) (int64, error) {
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	progress := newTransferProgress(opts.Progress, "Uploading "+path.Base(remotePath), size)
+	defer progress.Finish()
+
+	err = retryTransfer(ctx, opts.Retries, "Upload", func() error {
+		file, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		progress.Set(0)
+		return fs.UploadFile(ctx, remotePath, &progressReader{r: file, progress: progress}, size)
+	})
+	return size, err
+}
+
+// downloadFileStream streams remotePath into localPath through a partial file
+// that is renamed into place once complete. Interrupted transfers continue
+// from the partial file when the server honors ranged reads.
+func downloadFileStream(ctx context.Context, fs remoteFileSystem, remotePath, localPath string, opts fileTransferOptions) (int64, error) {
+	partPath := localPath + partialDownloadSuffix
+	var offset int64
+	if opts.Resume {
+		if info, err := os.Stat(partPath); err == nil {
+			offset = info.Size()
+		}
+	} else if err := os.Remove(partPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	progress := newTransferProgress(opts.Progress, "Downloading "+path.Base(remotePath), -1)
+	defer progress.Finish()
+
+	err := retryTransfer(ctx, opts.Retries, "Download", func() error {
+		download, err := fs.OpenFile(ctx, remotePath, offset)
+		var apiErr *sandbox0.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+			offset = 0
+			download, err = fs.OpenFile(ctx, remotePath, 0)
+		}
+		if err != nil {
+			return err
+		}
+		defer download.Body.Close()
+
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		offset = download.Offset
+		if offset > 0 {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		file, err := os.OpenFile(partPath, flags, 0644)
+		if err != nil {
+			return err
+		}
+		progress.SetTotal(download.Size)
+		progress.Set(offset)
+		n, copyErr := io.Copy(file, &progressReader{r: download.Body, progress: progress})
+		offset += n
+		if err := file.Close(); err != nil && copyErr == nil {
+			copyErr = err
+		}
+		if copyErr != nil {
+			return copyErr
+		}
+		if download.Size >= 0 && offset != download.Size {
+			return fmt.Errorf("incomplete download: received %d of %d bytes", offset, download.Size)
+		}
+		return nil
+	})
+	if err != nil {
+		return offset, fmt.Errorf("%w (rerun with --resume to continue)", err)
+	}
+	return offset, os.Rename(partPath, localPath)
+}
+
+// verifyFileTransfer compares the sha256 of a local file with the remote copy,
+// which is streamed back and hashed locally.
+func verifyFileTransfer(ctx context.Context, fs remoteFileSystem, localPath, remotePath string) (string, error) {
+	localSum, err := localFileSHA256(localPath)
+	if err != nil {
+		return "", fmt.Errorf("hash local file: %w", err)
+	}
+	remoteSum, err := remoteFileSHA256(ctx, fs, remotePath)
+	if err != nil {
+		return "", fmt.Errorf("hash remote file: %w", err)
+	}
+	if localSum != remoteSum {
+		return "", fmt.Errorf("sha256 mismatch: local %s, remote %s", localSum, remoteSum)
+	}
+	return localSum, nil
+}
+
+func localFileSHA256(localPath string) (string, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return readerSHA256(file)
+}
+
+func remoteFileSHA256(ctx context.Context, fs remoteFileSystem, remotePath string) (string, error) {
+	download, err := fs.OpenFile(ctx, remotePath, 0)
+	if err != nil {
+		return "", err
+	}
+	defer download.Body.Close()
+	return readerSHA256(download.Body)
+}
+
+func readerSHA256(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func TestFileTransferFlags(t *testing.T) {
+	for _, name := range []string{"progress", "verify", "retries", "resume"} {
+		if sandboxFilesUploadCmd.Flags().Lookup(name) == nil {
+			t.Fatalf("expected sandbox upload --%s flag", name)
+		}
+	}
+	for _, name := range []string{"progress", "verify", "retries", "resume"} {
+		if sandboxFilesDownloadCmd.Flags().Lookup(name) == nil {
+			t.Fatalf("expected sandbox download --%s flag", name)
+		}
+		if volumeFilesDownloadCmd.Flags().Lookup(name) == nil {
+			t.Fatalf("expected volume download --%s flag", name)
+		}
+	}
+	for _, name := range []string{"verify", "resume"} {
+		if volumeFilesUploadCmd.Flags().Lookup(name) == nil {
+			t.Fatalf("expected volume upload --%s flag", name)
+		}
+	}
+}
+
+func TestTransferProgressLine(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &transferProgress{label: "Uploading model.bin", total: 4 << 20, done: 1 << 20, start: start}
+
+	got := p.line(start.Add(time.Second))
+	want := "Uploading model.bin   25.0%  1.0 MiB / 4.0 MiB  1.0 MiB/s  ETA 3s"
+	if got != want {
+		t.Fatalf("line() = %q, want %q", got, want)
+	}
+
+	p.total = -1
+	if got := p.line(start.Add(time.Second)); got != "Uploading model.bin  1.0 MiB  1.0 MiB/s" {
+		t.Fatalf("line() = %q", got)
+	}
+}
+
+func TestDownloadFileStreamResumesPartialFile(t *testing.T) {
+	content := []byte(strings.Repeat("sandbox0", 1024))
+	var ranges []string
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sandboxvolumes/vol_1/files" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	})
+
+	localPath := filepath.Join(t.TempDir(), "file.bin")
+	if err := os.WriteFile(localPath+partialDownloadSuffix, content[:100], 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	fs := volumeFileSystem{client: client, volumeID: "vol_1"}
+	n, err := downloadFileStream(context.Background(), fs, "/file.bin", localPath, fileTransferOptions{Resume: true})
+	if err != nil {
+		t.Fatalf("downloadFileStream() error = %v", err)
+	}
+	if n != int64(len(content)) {
+		t.Fatalf("downloaded = %d, want %d", n, len(content))
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=100-" {
+		t.Fatalf("ranges = %v, want [bytes=100-]", ranges)
+	}
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Fatal("downloaded content does not match")
+	}
+	if _, err := os.Stat(localPath + partialDownloadSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("partial file still exists: %v", err)
+	}
+
+	sum, err := verifyFileTransfer(context.Background(), fs, localPath, "/file.bin")
+	if err != nil {
+		t.Fatalf("verifyFileTransfer() error = %v", err)
+	}
+	if len(sum) != 64 {
+		t.Fatalf("sha256 = %q", sum)
+	}
+}
+
+func TestUploadFileResumeSkipsUpToDateFile(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "weights.bin")
+	if err := os.WriteFile(localPath, []byte("weights"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	modTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(localPath, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	var remoteSize int64
+	var uploads int
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sandboxes/sb_1/files/stat":
+			writeTestSuccess(t, w, &apispec.FileInfo{
+				Name:    apispec.NewOptString("weights.bin"),
+				Type:    apispec.NewOptFileInfoType(apispec.FileInfoTypeFile),
+				Size:    apispec.NewOptInt64(remoteSize),
+				ModTime: apispec.NewOptDateTime(modTime),
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/sandboxes/sb_1/files":
+			uploads++
+			writeTestSuccess(t, w, &apispec.SuccessWrittenResponseData{Written: apispec.NewOptBool(true)})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	fs := newSandboxFileSystem(client, "sb_1")
+	opts := fileTransferOptions{Resume: true}
+
+	remoteSize = 3
+	if n, err := uploadFile(context.Background(), fs, localPath, "/data/weights.bin", opts); err != nil || n != 7 {
+		t.Fatalf("uploadFile() = %d, %v; want 7 bytes uploaded", n, err)
+	}
+	remoteSize = 7
+	if n, err := uploadFile(context.Background(), fs, localPath, "/data/weights.bin", opts); err != nil || n != 0 {
+		t.Fatalf("uploadFile() = %d, %v; want the up-to-date file skipped", n, err)
+	}
+	if uploads != 1 {
+		t.Fatalf("uploads = %d, want 1", uploads)
+	}
+}
+
+func TestIsRetryableTransferError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: errors.New("connection reset by peer"), want: true},
+		{err: &sandbox0.APIError{StatusCode: http.StatusBadGateway}, want: true},
+		{err: &sandbox0.APIError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{err: &sandbox0.APIError{StatusCode: http.StatusNotFound}, want: false},
+		{err: &os.PathError{Op: "open", Path: "x", Err: os.ErrPermission}, want: false},
+		{err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableTransferError(tt.err); got != tt.want {
+			t.Fatalf("isRetryableTransferError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 
+	"github.com/sandbox0-ai/s0/internal/client"
 	"github.com/sandbox0-ai/s0/internal/docker"
 
 	"github.com/spf13/cobra"
@@ -90,47 +92,53 @@ var imagePushCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Get registry credentials
-		creds, err := client.GetRegistryCredentials(cmd.Context(), imageTag)
+		targetImage, templateImage, err := pushTemplateImage(cmd.Context(), client, localImage, imageTag, os.Stdout)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting registry credentials: %v\n", err)
-			os.Exit(1)
-		}
-
-		pusher, err := docker.NewPusher()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating pusher: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Prepend registry to tag if not already present
-		targetImage := imageTag
-		if creds.PushRegistry != "" {
-			targetImage = fmt.Sprintf("%s/%s", creds.PushRegistry, imageTag)
-		}
-
-		opts := docker.PushOptions{
-			SourceImage: localImage,
-			TargetImage: targetImage,
-			Registry:    creds.PushRegistry,
-			Username:    creds.Username,
-			Password:    creds.Password,
-			Progress:    os.Stdout,
-		}
-
-		if err := pusher.Push(cmd.Context(), opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error pushing image: %v\n", err)
 			os.Exit(1)
 		}
-
-		templateImage := imageTag
-		if creds.PullRegistry != "" {
-			templateImage = fmt.Sprintf("%s/%s", creds.PullRegistry, imageTag)
-		}
 		writeImagePushResult(os.Stdout, targetImage, templateImage)
 	},
 }
 
+// pushTemplateImage pushes localImage to the Sandbox0 registry as tag. It
+// returns the pushed image and the reference templates should use to pull it.
+func pushTemplateImage(ctx context.Context, registry *client.Client, localImage, tag string, progress io.Writer) (string, string, error) {
+	creds, err := registry.GetRegistryCredentials(ctx, tag)
+	if err != nil {
+		return "", "", fmt.Errorf("getting registry credentials: %w", err)
+	}
+
+	pusher, err := docker.NewPusher()
+	if err != nil {
+		return "", "", err
+	}
+
+	// Prepend registry to tag if not already present
+	targetImage := tag
+	if creds.PushRegistry != "" {
+		targetImage = fmt.Sprintf("%s/%s", creds.PushRegistry, tag)
+	}
+
+	opts := docker.PushOptions{
+		SourceImage: localImage,
+		TargetImage: targetImage,
+		Registry:    creds.PushRegistry,
+		Username:    creds.Username,
+		Password:    creds.Password,
+		Progress:    progress,
+	}
+	if err := pusher.Push(ctx, opts); err != nil {
+		return "", "", err
+	}
+
+	templateImage := tag
+	if creds.PullRegistry != "" {
+		templateImage = fmt.Sprintf("%s/%s", creds.PullRegistry, tag)
+	}
+	return targetImage, templateImage, nil
+}
+
 func writeImagePushResult(w io.Writer, targetImage, templateImage string) {
 	fmt.Fprintf(w, "\nImage pushed successfully: %s\n", targetImage)
 	fmt.Fprintf(w, "Template image reference: %s\n", templateImage)
@@ -0,0 +1,694 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// Manifest kinds accepted by apply, diff, and delete.
+const (
+	manifestKindCredentialSource = "CredentialSource"
+	manifestKindTemplate         = "Template"
+	manifestKindVolume           = "Volume"
+	manifestKindNetworkPolicy    = "NetworkPolicy"
+	manifestKindServices         = "Services"
+)
+
+// manifestKindOrder is the order objects are applied in; delete uses the
+// reverse. Credential sources come first because network policies bind them.
+var manifestKindOrder = map[string]int{
+	manifestKindCredentialSource: 0,
+	manifestKindTemplate:         1,
+	manifestKindVolume:           2,
+	manifestKindNetworkPolicy:    3,
+	manifestKindServices:         4,
+}
+
+// manifestDocument is one document of a manifest file.
+type manifestDocument struct {
+	Kind     string           `json:"kind"`
+	Metadata manifestMetadata `json:"metadata"`
+	Spec     json.RawMessage  `json:"spec"`
+
+	// Source identifies the file and document index for error messages.
+	Source string `json:"-"`
+}
+
+// manifestMetadata identifies the object a document manages.
+type manifestMetadata struct {
+	// Name is the template ID or credential source name.
+	Name string `json:"name,omitempty"`
+	// ID is the volume ID. Volumes have no name to match them by, so a Volume
+	// document must name an existing volume.
+	ID string `json:"id,omitempty"`
+	// Sandbox is the sandbox a network policy or services document targets.
+	Sandbox string `json:"sandbox,omitempty"`
+}
+
+// manifestCredentialSourceSpec is the spec of a CredentialSource document. It
+// mirrors the credential source spec file with the name moved to metadata.
+type manifestCredentialSourceSpec struct {
+	ResolverKind string `json:"resolverKind"`
+	credentialSourceWriteRequestSpecFile
+}
+
+// manifestObject reconciles one manifest document against the server.
+type manifestObject interface {
+	// Ref returns a kind/name reference such as "template/python".
+	Ref() string
+	// Desired returns the declared state used for diffs.
+	Desired() any
+	// Live returns the server state comparable with Desired, or false when the
+	// object does not exist.
+	Live(ctx context.Context, client *sandbox0.Client) (any, bool, error)
+	// Create creates the object and returns its reference.
+	Create(ctx context.Context, client *sandbox0.Client) (string, error)
+	Update(ctx context.Context, client *sandbox0.Client) error
+	Delete(ctx context.Context, client *sandbox0.Client) error
+}
+
+// manifestOpaqueObject is implemented by objects whose desired state cannot be
+// fully compared because the server does not return it. They are always
+// updated and the note is shown in diffs.
+type manifestOpaqueObject interface {
+	OpaqueNote() string
+}
+
+// readManifestFiles reads manifest documents from files, directories, or "-"
+// for stdin. Directories include *.yaml, *.yml, and *.json files, descending
+// into subdirectories when recursive is set.
+func readManifestFiles(paths []string, recursive bool) ([]manifestDocument, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("-f is required")
+	}
+
+	var documents []manifestDocument
+	for _, p := range paths {
+		files, err := expandManifestPath(p, recursive)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := readConfigFile(file)
+			if err != nil {
+				return nil, err
+			}
+			name := file
+			if file == "-" {
+				name = "<stdin>"
+			}
+			parsed, err := parseManifestDocuments(name, data)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, parsed...)
+		}
+	}
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("no manifest documents found")
+	}
+	return documents, nil
+}
+
+func expandManifestPath(p string, recursive bool) ([]string, error) {
+	if p == "-" {
+		return []string{p}, nil
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{p}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(p, func(current string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if current != p && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(current)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, current)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// parseManifestDocuments parses a multi-document YAML or JSON manifest.
+func parseManifestDocuments(name string, data []byte) ([]manifestDocument, error) {
+	var documents []manifestDocument
+	for i, raw := range splitYAMLDocuments(data) {
+		source := fmt.Sprintf("%s (document %d)", name, i+1)
+		docJSON, err := yaml.YAMLToJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if bytes.Equal(bytes.TrimSpace(docJSON), []byte("null")) {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(docJSON))
+		decoder.DisallowUnknownFields()
+		var document manifestDocument
+		if err := decoder.Decode(&document); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		document.Source = source
+		if _, ok := manifestKindOrder[document.Kind]; !ok {
+			return nil, fmt.Errorf("%s: unsupported kind %q (expected %s)", source, document.Kind, strings.Join(manifestKinds(), ", "))
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// splitYAMLDocuments splits data on "---" document separators.
+func splitYAMLDocuments(data []byte) [][]byte {
+	var documents [][]byte
+	var current bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if trimmed := strings.TrimRight(line, " \t\r"); trimmed == "---" || strings.HasPrefix(trimmed, "--- ") {
+			documents = append(documents, append([]byte(nil), current.Bytes()...))
+			current.Reset()
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	documents = append(documents, current.Bytes())
+
+	nonEmpty := documents[:0]
+	for _, document := range documents {
+		if len(bytes.TrimSpace(document)) > 0 {
+			nonEmpty = append(nonEmpty, document)
+		}
+	}
+	return nonEmpty
+}
+
+func manifestKinds() []string {
+	kinds := make([]string, 0, len(manifestKindOrder))
+	for kind := range manifestKindOrder {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return manifestKindOrder[kinds[i]] < manifestKindOrder[kinds[j]] })
+	return kinds
+}
+
+// sortManifestDocuments orders documents by kind dependency, keeping file
+// order within a kind. reverse is used for deletes.
+func sortManifestDocuments(documents []manifestDocument, reverse bool) {
+	sort.SliceStable(documents, func(i, j int) bool {
+		if reverse {
+			return manifestKindOrder[documents[i].Kind] > manifestKindOrder[documents[j].Kind]
+		}
+		return manifestKindOrder[documents[i].Kind] < manifestKindOrder[documents[j].Kind]
+	})
+}
+
+// buildManifestObject validates a document and returns its reconciler.
+func buildManifestObject(document manifestDocument) (manifestObject, error) {
+	object, err := buildManifestObjectForKind(document)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", document.Source, err)
+	}
+	return object, nil
+}
+
+func buildManifestObjectForKind(document manifestDocument) (manifestObject, error) {
+	spec := document.Spec
+	if len(spec) == 0 {
+		spec = json.RawMessage("{}")
+	}
+
+	switch document.Kind {
+	case manifestKindTemplate:
+		if strings.TrimSpace(document.Metadata.Name) == "" {
+			return nil, fmt.Errorf("metadata.name is required for kind Template")
+		}
+		wrapped, err := json.Marshal(map[string]json.RawMessage{"spec": spec})
+		if err != nil {
+			return nil, err
+		}
+		if err := rejectTemplateCPU(wrapped); err != nil {
+			return nil, err
+		}
+		var templateSpec apispec.SandboxTemplateSpec
+		if err := json.Unmarshal(spec, &templateSpec); err != nil {
+			return nil, err
+		}
+		return &templateManifestObject{id: strings.TrimSpace(document.Metadata.Name), spec: templateSpec}, nil
+
+	case manifestKindVolume:
+		if strings.TrimSpace(document.Metadata.ID) == "" {
+			return nil, fmt.Errorf("metadata.id is required for kind Volume; create the volume with 's0 volume create' and set metadata.id to its ID")
+		}
+		var request apispec.CreateSandboxVolumeRequest
+		if err := json.Unmarshal(spec, &request); err != nil {
+			return nil, err
+		}
+		return &volumeManifestObject{id: strings.TrimSpace(document.Metadata.ID), request: request}, nil
+
+	case manifestKindCredentialSource:
+		var file manifestCredentialSourceSpec
+		if err := json.Unmarshal(spec, &file); err != nil {
+			return nil, err
+		}
+		request, err := credentialSourceWriteRequestFromFile(credentialSourceWriteRequestFile{
+			Name:         document.Metadata.Name,
+			ResolverKind: file.ResolverKind,
+			Spec:         file.credentialSourceWriteRequestSpecFile,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &credentialSourceManifestObject{request: *request}, nil
+
+	case manifestKindNetworkPolicy:
+		if strings.TrimSpace(document.Metadata.Sandbox) == "" {
+			return nil, fmt.Errorf("metadata.sandbox is required for kind NetworkPolicy")
+		}
+		policy, err := parseNetworkPolicyUpdateFile(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &networkPolicyManifestObject{sandboxID: strings.TrimSpace(document.Metadata.Sandbox), policy: *policy}, nil
+
+	case manifestKindServices:
+		if strings.TrimSpace(document.Metadata.Sandbox) == "" {
+			return nil, fmt.Errorf("metadata.sandbox is required for kind Services")
+		}
+		services, err := parseSandboxServices(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &servicesManifestObject{sandboxID: strings.TrimSpace(document.Metadata.Sandbox), services: services.Services}, nil
+	}
+	return nil, fmt.Errorf("unsupported kind %q", document.Kind)
+}
+
+type templateManifestObject struct {
+	id   string
+	spec apispec.SandboxTemplateSpec
+}
+
+func (o *templateManifestObject) Ref() string  { return "template/" + o.id }
+func (o *templateManifestObject) Desired() any { return o.spec }
+
+func (o *templateManifestObject) Live(ctx context.Context, client *sandbox0.Client) (any, bool, error) {
+	template, err := client.GetTemplate(ctx, o.id)
+	if isNotFoundError(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return template.Spec, true, nil
+}
+
+func (o *templateManifestObject) Create(ctx context.Context, client *sandbox0.Client) (string, error) {
+	_, err := client.CreateTemplate(ctx, apispec.TemplateCreateRequest{TemplateID: o.id, Spec: o.spec})
+	return o.Ref(), err
+}
+
+func (o *templateManifestObject) Update(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.UpdateTemplate(ctx, o.id, apispec.TemplateUpdateRequest{Spec: o.spec})
+	return err
+}
+
+func (o *templateManifestObject) Delete(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.DeleteTemplate(ctx, o.id)
+	return err
+}
+
+type volumeManifestObject struct {
+	id      string
+	request apispec.CreateSandboxVolumeRequest
+}
+
+func (o *volumeManifestObject) Ref() string { return "volume/" + o.id }
+
+// Desired omits create-only inputs and write-only S3 credentials, which the
+// server never returns.
+func (o *volumeManifestObject) Desired() any {
+	request := o.request
+	request.SnapshotID = apispec.OptString{}
+	value, err := normalizeManifestValue(request)
+	if err != nil {
+		return request
+	}
+	if object, ok := value.(map[string]any); ok {
+		if s3, ok := object["s3"].(map[string]any); ok {
+			delete(s3, "access_key")
+			delete(s3, "secret_key")
+			delete(s3, "session_token")
+		}
+	}
+	return value
+}
+
+func (o *volumeManifestObject) Live(ctx context.Context, client *sandbox0.Client) (any, bool, error) {
+	volume, err := client.GetVolume(ctx, o.id)
+	if isNotFoundError(err) {
+		return nil, false, fmt.Errorf("volume %s not found; create it with 's0 volume create' and set metadata.id to the new ID", o.id)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return volume, true, nil
+}
+
+// Create is never reached: Live reports a missing volume as an error, because
+// a created volume could not be matched by a later apply.
+func (o *volumeManifestObject) Create(context.Context, *sandbox0.Client) (string, error) {
+	return o.Ref(), fmt.Errorf("volumes are not created by apply; create the volume with 's0 volume create' and set metadata.id to its ID")
+}
+
+func (o *volumeManifestObject) Update(context.Context, *sandbox0.Client) error {
+	return fmt.Errorf("volume settings cannot be changed after creation; delete and recreate the volume")
+}
+
+func (o *volumeManifestObject) Delete(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.DeleteVolume(ctx, o.id)
+	return err
+}
+
+type credentialSourceManifestObject struct {
+	request apispec.CredentialSourceWriteRequest
+}
+
+func (o *credentialSourceManifestObject) Ref() string { return "credentialsource/" + o.request.Name }
+
+func (o *credentialSourceManifestObject) Desired() any {
+	return map[string]any{"resolverKind": string(o.request.ResolverKind)}
+}
+
+func (o *credentialSourceManifestObject) OpaqueNote() string {
+	return "secret values are not returned by the server and are always re-applied"
+}
+
+func (o *credentialSourceManifestObject) Live(ctx context.Context, client *sandbox0.Client) (any, bool, error) {
+	source, err := client.GetCredentialSource(ctx, o.request.Name)
+	if isNotFoundError(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return map[string]any{"resolverKind": string(source.ResolverKind)}, true, nil
+}
+
+func (o *credentialSourceManifestObject) Create(ctx context.Context, client *sandbox0.Client) (string, error) {
+	_, err := client.CreateCredentialSource(ctx, o.request)
+	return o.Ref(), err
+}
+
+func (o *credentialSourceManifestObject) Update(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.UpdateCredentialSource(ctx, o.request.Name, o.request)
+	return err
+}
+
+func (o *credentialSourceManifestObject) Delete(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.DeleteCredentialSource(ctx, o.request.Name)
+	return err
+}
+
+type networkPolicyManifestObject struct {
+	sandboxID string
+	policy    apispec.SandboxNetworkPolicy
+}
+
+func (o *networkPolicyManifestObject) Ref() string  { return "networkpolicy/" + o.sandboxID }
+func (o *networkPolicyManifestObject) Desired() any { return o.policy }
+
+func (o *networkPolicyManifestObject) Live(ctx context.Context, client *sandbox0.Client) (any, bool, error) {
+	policy, err := client.Sandbox(o.sandboxID).GetNetworkPolicy(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	return policy, true, nil
+}
+
+func (o *networkPolicyManifestObject) Create(ctx context.Context, client *sandbox0.Client) (string, error) {
+	return o.Ref(), o.Update(ctx, client)
+}
+
+func (o *networkPolicyManifestObject) Update(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.Sandbox(o.sandboxID).UpdateNetworkPolicy(ctx, o.policy)
+	return err
+}
+
+// Delete resets the sandbox to an allow-all policy without credential bindings.
+func (o *networkPolicyManifestObject) Delete(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.Sandbox(o.sandboxID).UpdateNetworkPolicy(ctx, apispec.SandboxNetworkPolicy{
+		Mode: apispec.SandboxNetworkPolicyModeAllowAll,
+	})
+	return err
+}
+
+type servicesManifestObject struct {
+	sandboxID string
+	services  []apispec.SandboxAppService
+}
+
+func (o *servicesManifestObject) Ref() string { return "services/" + o.sandboxID }
+
+func (o *servicesManifestObject) Desired() any {
+	return apispec.SandboxServicesUpdateRequest{Services: o.services}
+}
+
+func (o *servicesManifestObject) Live(ctx context.Context, client *sandbox0.Client) (any, bool, error) {
+	result, err := client.Sandbox(o.sandboxID).GetServices(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	services := make([]apispec.SandboxAppService, 0, len(result.Services))
+	for _, service := range result.Services {
+		services = append(services, sandboxServiceViewToService(service))
+	}
+	return apispec.SandboxServicesUpdateRequest{Services: services}, true, nil
+}
+
+func (o *servicesManifestObject) Create(ctx context.Context, client *sandbox0.Client) (string, error) {
+	return o.Ref(), o.Update(ctx, client)
+}
+
+func (o *servicesManifestObject) Update(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.Sandbox(o.sandboxID).UpdateServices(ctx, o.services)
+	return err
+}
+
+// Delete removes all services from the sandbox.
+func (o *servicesManifestObject) Delete(ctx context.Context, client *sandbox0.Client) error {
+	_, err := client.Sandbox(o.sandboxID).UpdateServices(ctx, []apispec.SandboxAppService{})
+	return err
+}
+
+// manifestAction is the change apply would make to an object.
+type manifestAction string
+
+const (
+	manifestActionCreate    manifestAction = "create"
+	manifestActionUpdate    manifestAction = "update"
+	manifestActionUnchanged manifestAction = "unchanged"
+)
+
+// manifestFieldDiff is one changed field. Op is "+", "-", or "~".
+type manifestFieldDiff struct {
+	Op   string
+	Path string
+	From any
+	To   any
+}
+
+// manifestPlan is the planned change for one object.
+type manifestPlan struct {
+	Object manifestObject
+	Action manifestAction
+	Diffs  []manifestFieldDiff
+	Note   string
+}
+
+// planManifestObject compares the desired state of object with the server.
+func planManifestObject(ctx context.Context, client *sandbox0.Client, object manifestObject) (manifestPlan, error) {
+	plan := manifestPlan{Object: object}
+	desired, err := normalizeManifestValue(object.Desired())
+	if err != nil {
+		return plan, err
+	}
+
+	live, exists, err := object.Live(ctx, client)
+	if err != nil {
+		return plan, err
+	}
+	if !exists {
+		plan.Action = manifestActionCreate
+		plan.Diffs = diffManifestValues("spec", desired, nil)
+		return plan, nil
+	}
+
+	liveValue, err := normalizeManifestValue(live)
+	if err != nil {
+		return plan, err
+	}
+	plan.Diffs = diffManifestValues("spec", desired, liveValue)
+	plan.Action = manifestActionUnchanged
+	if len(plan.Diffs) > 0 {
+		plan.Action = manifestActionUpdate
+	}
+	if opaque, ok := object.(manifestOpaqueObject); ok {
+		plan.Action = manifestActionUpdate
+		plan.Note = opaque.OpaqueNote()
+	}
+	return plan, nil
+}
+
+// normalizeManifestValue converts value to its generic JSON representation.
+// Struct values are marshaled through a pointer because the generated API
+// types implement json.Marshaler on pointer receivers.
+func normalizeManifestValue(value any) (any, error) {
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Struct {
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		value = ptr.Interface()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// diffManifestValues returns field-level differences between desired and
+// live. Object fields that are only present on the server are treated as
+// server-side defaults and ignored; extra list items are reported as removed.
+func diffManifestValues(path string, desired, live any) []manifestFieldDiff {
+	if isEmptyManifestValue(desired) {
+		if live == nil || isEmptyManifestValue(live) {
+			return nil
+		}
+	}
+	if live == nil {
+		if desiredObject, ok := desired.(map[string]any); ok {
+			return diffManifestObject(path, desiredObject, map[string]any{})
+		}
+		return []manifestFieldDiff{{Op: "+", Path: path, To: desired}}
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		liveObject, ok := live.(map[string]any)
+		if !ok {
+			return []manifestFieldDiff{{Op: "~", Path: path, From: live, To: desired}}
+		}
+		return diffManifestObject(path, desiredValue, liveObject)
+	case []any:
+		liveList, ok := live.([]any)
+		if !ok {
+			return []manifestFieldDiff{{Op: "~", Path: path, From: live, To: desired}}
+		}
+		var diffs []manifestFieldDiff
+		for i, item := range desiredValue {
+			itemPath := path + "[" + strconv.Itoa(i) + "]"
+			if i >= len(liveList) {
+				diffs = append(diffs, diffManifestValues(itemPath, item, nil)...)
+				continue
+			}
+			diffs = append(diffs, diffManifestValues(itemPath, item, liveList[i])...)
+		}
+		for i := len(desiredValue); i < len(liveList); i++ {
+			diffs = append(diffs, manifestFieldDiff{Op: "-", Path: path + "[" + strconv.Itoa(i) + "]", From: liveList[i]})
+		}
+		return diffs
+	default:
+		if reflect.DeepEqual(desired, live) {
+			return nil
+		}
+		return []manifestFieldDiff{{Op: "~", Path: path, From: live, To: desired}}
+	}
+}
+
+func diffManifestObject(path string, desired, live map[string]any) []manifestFieldDiff {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var diffs []manifestFieldDiff
+	for _, key := range keys {
+		diffs = append(diffs, diffManifestValues(path+"."+key, desired[key], live[key])...)
+	}
+	return diffs
+}
+
+func isEmptyManifestValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// writeManifestPlan prints a plan in a compact field-level diff format.
+func writeManifestPlan(w io.Writer, plan manifestPlan) {
+	fmt.Fprintf(w, "%s: %s\n", plan.Object.Ref(), plan.Action)
+	for _, diff := range plan.Diffs {
+		switch diff.Op {
+		case "+":
+			fmt.Fprintf(w, "  + %s: %s\n", diff.Path, formatManifestValue(diff.To))
+		case "-":
+			fmt.Fprintf(w, "  - %s: %s\n", diff.Path, formatManifestValue(diff.From))
+		default:
+			fmt.Fprintf(w, "  ~ %s: %s -> %s\n", diff.Path, formatManifestValue(diff.From), formatManifestValue(diff.To))
+		}
+	}
+	if plan.Note != "" {
+		fmt.Fprintf(w, "  ~ spec: %s\n", plan.Note)
+	}
+}
+
+func formatManifestValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifestDocumentsSplitsMultiDocumentYAML(t *testing.T) {
+	data := []byte(`---
+kind: Services
+metadata:
+  sandbox: sb_1
+spec:
+  services: []
+---
+# comment only
+---
+kind: Template
+metadata:
+  name: python
+spec:
+  mainContainer:
+    image: python:3.12
+`)
+	documents, err := parseManifestDocuments("stack.yaml", data)
+	if err != nil {
+		t.Fatalf("parseManifestDocuments() error = %v", err)
+	}
+	if len(documents) != 2 {
+		t.Fatalf("documents = %d, want 2", len(documents))
+	}
+
+	sortManifestDocuments(documents, false)
+	if documents[0].Kind != manifestKindTemplate || documents[1].Kind != manifestKindServices {
+		t.Fatalf("apply order = %s, %s; want Template, Services", documents[0].Kind, documents[1].Kind)
+	}
+	sortManifestDocuments(documents, true)
+	if documents[0].Kind != manifestKindServices {
+		t.Fatalf("delete order starts with %s, want Services", documents[0].Kind)
+	}
+}
+
+func TestParseManifestDocumentsRejectsInvalidDocuments(t *testing.T) {
+	for name, data := range map[string]string{
+		"unknown kind":  "kind: Sandbox\nmetadata:\n  name: x\n",
+		"unknown field": "kind: Template\nmetadata:\n  name: x\nstatus: {}\n",
+	} {
+		_, err := parseManifestDocuments("bad.yaml", []byte(data))
+		if err == nil || !strings.Contains(err.Error(), "bad.yaml (document 1)") {
+			t.Fatalf("%s: error = %v, want error naming the document", name, err)
+		}
+	}
+}
+
+func TestReadManifestFilesExpandsDirectories(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	if err := os.Mkdir(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	template := "kind: Template\nmetadata:\n  name: %s\nspec: {}\n"
+	for path, name := range map[string]string{
+		filepath.Join(dir, "b.yaml"):    "b",
+		filepath.Join(dir, "a.yml"):     "a",
+		filepath.Join(nested, "c.yaml"): "c",
+	} {
+		if err := os.WriteFile(path, []byte(strings.ReplaceAll(template, "%s", name)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(recursive bool) []string {
+		documents, err := readManifestFiles([]string{dir}, recursive)
+		if err != nil {
+			t.Fatalf("readManifestFiles() error = %v", err)
+		}
+		var got []string
+		for _, document := range documents {
+			got = append(got, document.Metadata.Name)
+		}
+		return got
+	}
+	if got := names(false); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("non-recursive names = %v, want [a b]", got)
+	}
+	if got := names(true); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("recursive names = %v, want [a b c]", got)
+	}
+}
+
+func TestBuildManifestObjectValidatesMetadata(t *testing.T) {
+	for _, data := range []string{
+		"kind: Template\nspec: {}\n",
+		"kind: NetworkPolicy\nspec:\n  mode: allow-all\n",
+		"kind: Services\nspec:\n  services: []\n",
+		"kind: Volume\nspec:\n  access_mode: RWO\n",
+		"kind: CredentialSource\nmetadata:\n  name: gh\nspec:\n  resolverKind: static_headers\n",
+	} {
+		documents, err := parseManifestDocuments("m.yaml", []byte(data))
+		if err != nil {
+			t.Fatalf("parseManifestDocuments(%q) error = %v", data, err)
+		}
+		if _, err := buildManifestObject(documents[0]); err == nil {
+			t.Fatalf("buildManifestObject(%q) error = nil, want error", data)
+		}
+	}
+}
+
+func TestVolumeManifestDesiredOmitsWriteOnlyFields(t *testing.T) {
+	documents, err := parseManifestDocuments("v.yaml", []byte(`kind: Volume
+metadata:
+  id: vol_1
+spec:
+  snapshot_id: snap_1
+  access_mode: RWX
+  backend: s3
+  s3:
+    provider: aws
+    bucket: data
+    region: us-east-1
+    access_key: AKIA
+    secret_key: secret
+`))
+	if err != nil {
+		t.Fatalf("parseManifestDocuments() error = %v", err)
+	}
+	object, err := buildManifestObject(documents[0])
+	if err != nil {
+		t.Fatalf("buildManifestObject() error = %v", err)
+	}
+	desired, err := normalizeManifestValue(object.Desired())
+	if err != nil {
+		t.Fatalf("normalizeManifestValue() error = %v", err)
+	}
+	want := map[string]any{
+		"access_mode":       "RWX",
+		"backend":           "s3",
+		"default_posix_uid": float64(0),
+		"default_posix_gid": float64(0),
+		"s3":                map[string]any{"provider": "aws", "bucket": "data", "region": "us-east-1"},
+	}
+	if !reflect.DeepEqual(desired, want) {
+		t.Fatalf("Desired() = %#v, want %#v", desired, want)
+	}
+}
+
+func TestDiffManifestValues(t *testing.T) {
+	desired := map[string]any{
+		"mode": "block-all",
+		"egress": map[string]any{
+			"allowedDomains": []any{"api.github.com"},
+		},
+		"credentialBindings": []any{},
+	}
+	live := map[string]any{
+		"mode": "allow-all",
+		"egress": map[string]any{
+			"allowedDomains": []any{"example.com", "pypi.org"},
+			"allowedCidrs":   []any{"10.0.0.0/8"},
+		},
+	}
+
+	got := diffManifestValues("spec", desired, live)
+	want := []manifestFieldDiff{
+		{Op: "~", Path: "spec.egress.allowedDomains[0]", From: "example.com", To: "api.github.com"},
+		{Op: "-", Path: "spec.egress.allowedDomains[1]", From: "pypi.org"},
+		{Op: "~", Path: "spec.mode", From: "allow-all", To: "block-all"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diffManifestValues() = %#v, want %#v", got, want)
+	}
+
+	if diffs := diffManifestValues("spec", desired, desired); len(diffs) != 0 {
+		t.Fatalf("diffManifestValues(equal) = %#v, want none", diffs)
+	}
+}
+
+func TestPlanManifestObjectForTemplate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/templates/python":
+			_, _ = w.Write([]byte(`{"success":true,"data":{"template_id":"python","scope":"team","spec":{"description":"old","tags":["ml"],"displayName":"Python"},"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":{"code":"not_found","message":"template not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := newSDKClientForBaseURL(server.URL, "token-1")
+	if err != nil {
+		t.Fatalf("newSDKClientForBaseURL() error = %v", err)
+	}
+
+	documents, err := parseManifestDocuments("t.yaml", []byte(`kind: Template
+metadata:
+  name: python
+spec:
+  description: new
+  tags: [ml]
+---
+kind: Template
+metadata:
+  name: missing
+spec:
+  description: fresh
+`))
+	if err != nil {
+		t.Fatalf("parseManifestDocuments() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	for _, document := range documents {
+		object, err := buildManifestObject(document)
+		if err != nil {
+			t.Fatalf("buildManifestObject() error = %v", err)
+		}
+		plan, err := planManifestObject(context.Background(), client, object)
+		if err != nil {
+			t.Fatalf("planManifestObject(%s) error = %v", object.Ref(), err)
+		}
+		writeManifestPlan(&out, plan)
+	}
+
+	want := `template/python: update
+  ~ spec.description: "old" -> "new"
+template/missing: create
+  + spec.description: "fresh"
+`
+	if out.String() != want {
+		t.Fatalf("plan output =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,253 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// pageCollector writes the pages of an --all listing. Streaming formats
+// receive each page as soon as it is fetched; other formats receive a single
+// merged result once every page has been read.
+type pageCollector struct {
+	w         io.Writer
+	formatter output.Formatter
+	streaming bool
+	pages     int
+	items     int
+}
+
+func newPageCollector(w io.Writer) *pageCollector {
+	return &pageCollector{
+		w:         w,
+		formatter: getFormatter(),
+		streaming: output.ParseFormat(cfgFormat).IsStreaming(),
+	}
+}
+
+// count records one page with n items.
+func (c *pageCollector) count(n int) {
+	c.pages++
+	c.items += n
+}
+
+// add records one page with n items and writes it when streaming.
+func (c *pageCollector) add(page any, n int) error {
+	c.count(n)
+	if !c.streaming {
+		return nil
+	}
+	return c.formatter.Format(c.w, page)
+}
+
+// finish writes the merged result for non-streaming formats.
+func (c *pageCollector) finish(merged any) error {
+	if c.streaming {
+		return nil
+	}
+	return c.formatter.Format(c.w, merged)
+}
+
+// summary describes the totals of the listing for stderr.
+func (c *pageCollector) summary(noun string) string {
+	pages := "pages"
+	if c.pages == 1 {
+		pages = "page"
+	}
+	return fmt.Sprintf("Total: %d %s (%d %s)", c.items, noun, c.pages, pages)
+}
+
+// forEachSandboxPage lists sandboxes page by page, advancing the offset until
+// the server reports no more results.
+func forEachSandboxPage(ctx context.Context, client *sandbox0.Client, opts sandbox0.ListSandboxesOptions, fn func(*sandbox0.ListSandboxesResponse) error) error {
+	offset := 0
+	if opts.Offset != nil {
+		offset = *opts.Offset
+	}
+	for {
+		pageOffset := offset
+		opts.Offset = &pageOffset
+		page, err := client.ListSandboxes(ctx, &opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if !page.HasMore || len(page.Sandboxes) == 0 {
+			return nil
+		}
+		offset += len(page.Sandboxes)
+	}
+}
+
+// forEachSessionEventPage reads the retained session journal page by page,
+// continuing after the last sequence until the latest retained event.
+func forEachSessionEventPage(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string, opts sandbox0.SessionEventOptions, fn func(*apispec.ExecutionSessionEventPage) error) error {
+	for {
+		page, err := sandbox.ListSessionEvents(ctx, sessionID, &opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if len(page.Events) == 0 {
+			return nil
+		}
+		last := page.Events[len(page.Events)-1].Seq
+		if last >= page.Cursor.Latest || last <= opts.After {
+			return nil
+		}
+		opts.After = last
+	}
+}
+
+// forEachObservabilityLogPage queries sandbox logs page by page, following
+// next_cursor until it is exhausted.
+func forEachObservabilityLogPage(ctx context.Context, sandbox *sandbox0.Sandbox, opts sandbox0.SandboxObservabilityLogOptions, fn func(*apispec.SandboxObservabilityLogsResponse) error) error {
+	for {
+		page, err := sandbox.ListLogs(ctx, &opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		next, ok := nextObservabilityCursor(page.NextCursor, opts.Cursor, len(page.Logs))
+		if !ok {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// forEachObservabilityEventPage queries sandbox events page by page,
+// following next_cursor until it is exhausted.
+func forEachObservabilityEventPage(ctx context.Context, sandbox *sandbox0.Sandbox, opts sandbox0.SandboxObservabilityEventOptions, fn func(*apispec.SandboxObservabilityEventsResponse) error) error {
+	for {
+		page, err := sandbox.ListObservabilityEvents(ctx, &opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		next, ok := nextObservabilityCursor(page.NextCursor, opts.Cursor, len(page.Events))
+		if !ok {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+// nextObservabilityCursor returns the cursor for the following page, or false
+// when the page was empty or the cursor did not advance.
+func nextObservabilityCursor(next apispec.OptString, current string, n int) (string, bool) {
+	cursor, ok := next.Get()
+	if !ok || cursor == "" || cursor == current || n == 0 {
+		return "", false
+	}
+	return cursor, true
+}
+
+// listAllSandboxes writes every sandbox matching opts and reports the total
+// on stderr.
+func listAllSandboxes(ctx context.Context, client *sandbox0.Client, opts sandbox0.ListSandboxesOptions) error {
+	pages := newPageCollector(os.Stdout)
+	merged := &sandbox0.ListSandboxesResponse{}
+	err := forEachSandboxPage(ctx, client, opts, func(page *sandbox0.ListSandboxesResponse) error {
+		if !pages.streaming {
+			merged.Sandboxes = append(merged.Sandboxes, page.Sandboxes...)
+		}
+		return pages.add(page, len(page.Sandboxes))
+	})
+	if err != nil {
+		return err
+	}
+	merged.Count = len(merged.Sandboxes)
+	if err := pages.finish(merged); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, pages.summary("sandboxes"))
+	return nil
+}
+
+// listAllSessionEvents writes every retained event of a session and reports
+// the total on stderr.
+func listAllSessionEvents(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string, opts sandbox0.SessionEventOptions) error {
+	pages := newPageCollector(os.Stdout)
+	merged := &apispec.ExecutionSessionEventPage{}
+	err := forEachSessionEventPage(ctx, sandbox, sessionID, opts, func(page *apispec.ExecutionSessionEventPage) error {
+		if !pages.streaming {
+			merged.Events = append(merged.Events, page.Events...)
+			merged.Cursor = page.Cursor
+		}
+		return pages.add(page, len(page.Events))
+	})
+	if err != nil {
+		return err
+	}
+	if err := pages.finish(merged); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, pages.summary("events"))
+	return nil
+}
+
+// listAllObservabilityLogs writes every log entry matching opts. Plain output
+// is written page by page like the single-page query.
+func listAllObservabilityLogs(ctx context.Context, sandbox *sandbox0.Sandbox, opts sandbox0.SandboxObservabilityLogOptions) error {
+	pages := newPageCollector(os.Stdout)
+	plain := !isStructuredOutput()
+	merged := &apispec.SandboxObservabilityLogsResponse{}
+	err := forEachObservabilityLogPage(ctx, sandbox, opts, func(page *apispec.SandboxObservabilityLogsResponse) error {
+		if plain {
+			pages.count(len(page.Logs))
+			writeObservabilityLogs(os.Stdout, page.Logs)
+			return nil
+		}
+		if !pages.streaming {
+			merged.Logs = append(merged.Logs, page.Logs...)
+			merged.Watermark = page.Watermark
+		}
+		return pages.add(page, len(page.Logs))
+	})
+	if err != nil {
+		return err
+	}
+	if !plain {
+		if err := pages.finish(merged); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(os.Stderr, pages.summary("log entries"))
+	return nil
+}
+
+// listAllObservabilityEvents writes every event matching opts and reports the
+// total on stderr.
+func listAllObservabilityEvents(ctx context.Context, sandbox *sandbox0.Sandbox, opts sandbox0.SandboxObservabilityEventOptions) error {
+	pages := newPageCollector(os.Stdout)
+	merged := &apispec.SandboxObservabilityEventsResponse{}
+	err := forEachObservabilityEventPage(ctx, sandbox, opts, func(page *apispec.SandboxObservabilityEventsResponse) error {
+		if !pages.streaming {
+			merged.Events = append(merged.Events, page.Events...)
+			merged.Watermark = page.Watermark
+		}
+		return pages.add(page, len(page.Events))
+	})
+	if err != nil {
+		return err
+	}
+	if err := pages.finish(merged); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, pages.summary("events"))
+	return nil
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func newTestSDKClient(t *testing.T, handler http.HandlerFunc) *sandbox0.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := newSDKClientForBaseURL(server.URL, "token-1")
+	if err != nil {
+		t.Fatalf("newSDKClientForBaseURL() error = %v", err)
+	}
+	return client
+}
+
+func TestForEachSandboxPageWalksOffsets(t *testing.T) {
+	var offsets []string
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		offsets = append(offsets, offset)
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit = %q, want 2", got)
+		}
+		ids := map[string][]string{"0": {"sb_1", "sb_2"}, "2": {"sb_3", "sb_4"}, "4": {"sb_5"}}[offset]
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			items[i] = fmt.Sprintf(`{"id":%q,"template_id":"python","status":"running","paused":false,"runtime_generation":1,"created_at":"2026-01-01T00:00:00Z","expires_at":"2026-01-01T00:00:00Z","hard_expires_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}`, id)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{"sandboxes":[%s],"count":%d,"has_more":%t}}`, strings.Join(items, ","), len(ids), offset != "4")
+	})
+
+	limit := 2
+	var ids []string
+	err := forEachSandboxPage(context.Background(), client, sandbox0.ListSandboxesOptions{Limit: &limit}, func(page *sandbox0.ListSandboxesResponse) error {
+		for _, sandbox := range page.Sandboxes {
+			ids = append(ids, sandbox.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachSandboxPage() error = %v", err)
+	}
+	if strings.Join(offsets, ",") != "0,2,4" {
+		t.Fatalf("offsets = %v, want [0 2 4]", offsets)
+	}
+	if strings.Join(ids, ",") != "sb_1,sb_2,sb_3,sb_4,sb_5" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestForEachSessionEventPageFollowsSequence(t *testing.T) {
+	var afters []string
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+		seqs := map[string][]int{"": {1, 2}, "2": {3}}[after]
+		events := make([]string, len(seqs))
+		for i, seq := range seqs {
+			events[i] = fmt.Sprintf(`{"seq":%d,"session_id":"ses_1","runtime_generation":1,"type":"output","occurred_at":"2026-01-01T00:00:00Z"}`, seq)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{"events":[%s],"cursor":{"earliest":1,"latest":3}}}`, strings.Join(events, ","))
+	})
+
+	var seqs []int64
+	err := forEachSessionEventPage(context.Background(), client.Sandbox("sb_1"), "ses_1", sandbox0.SessionEventOptions{Limit: 2}, func(page *apispec.ExecutionSessionEventPage) error {
+		for _, event := range page.Events {
+			seqs = append(seqs, event.Seq)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachSessionEventPage() error = %v", err)
+	}
+	if len(afters) != 2 || afters[1] != "2" {
+		t.Fatalf("after values = %q, want [\"\" \"2\"]", afters)
+	}
+	if fmt.Sprint(seqs) != "[1 2 3]" {
+		t.Fatalf("seqs = %v, want [1 2 3]", seqs)
+	}
+}
+
+func TestForEachObservabilityLogPageFollowsNextCursor(t *testing.T) {
+	var cursors []string
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		next := map[string]string{"": `"next_cursor":"c2",`, "c2": ""}[cursor]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{%s"logs":[{"team_id":"t","sandbox_id":"sb_1","region_id":"r","cluster_id":"c","occurred_at":"2026-01-01T00:00:00Z","ingested_at":"2026-01-01T00:00:00Z","message":"line %s","cursor":"x"}]}}`, next, cursor)
+	})
+
+	var messages []string
+	opts := sandbox0.SandboxObservabilityLogOptions{SandboxObservabilityQueryOptions: sandbox0.SandboxObservabilityQueryOptions{Limit: 1}}
+	err := forEachObservabilityLogPage(context.Background(), client.Sandbox("sb_1"), opts, func(page *apispec.SandboxObservabilityLogsResponse) error {
+		for _, entry := range page.Logs {
+			messages = append(messages, entry.Message)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEachObservabilityLogPage() error = %v", err)
+	}
+	if strings.Join(cursors, ",") != ",c2" {
+		t.Fatalf("cursors = %q, want [\"\" \"c2\"]", cursors)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("messages = %v, want 2 entries", messages)
+	}
+}
+
+func TestNextObservabilityCursorStopsWhenCursorDoesNotAdvance(t *testing.T) {
+	if _, ok := nextObservabilityCursor(apispec.NewOptString("c1"), "c1", 5); ok {
+		t.Fatal("repeated cursor should stop paging")
+	}
+	if _, ok := nextObservabilityCursor(apispec.NewOptString("c2"), "c1", 0); ok {
+		t.Fatal("empty page should stop paging")
+	}
+	if next, ok := nextObservabilityCursor(apispec.NewOptString("c2"), "c1", 5); !ok || next != "c2" {
+		t.Fatalf("nextObservabilityCursor() = %q, %v; want c2, true", next, ok)
+	}
+}
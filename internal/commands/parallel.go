@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"context"
+	"sync"
+)
+
+// runBounded calls fn for every item with at most limit calls in flight and
+// returns the results in input order. Once ctx is done no further calls are
+// started, and the results of the items left out keep the zero value.
+func runBounded[T, R any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) R) []R {
+	results := make([]R, len(items))
+	sem := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+	for i, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fn(ctx, item)
+		}()
+	}
+	wg.Wait()
+	return results
+}
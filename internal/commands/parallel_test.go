@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBoundedKeepsInputOrderAndLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	items := []int{1, 2, 3, 4, 5}
+	results := runBounded(context.Background(), items, 2, func(_ context.Context, item int) int {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		// Earlier items finish last so out-of-order completion is exercised.
+		time.Sleep(time.Duration(len(items)-item) * 2 * time.Millisecond)
+		return item * 10
+	})
+
+	if peak.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+	for i, result := range results {
+		if result != items[i]*10 {
+			t.Fatalf("results = %v, want input order", results)
+		}
+	}
+	if got := runBounded(context.Background(), []int{1}, 0, func(_ context.Context, item int) int { return item }); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("runBounded with limit 0 = %v, want [1]", got)
+	}
+}
+
+func TestRunBoundedStopsStartingOnceCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := runBounded(ctx, []string{"a", "b", "c"}, 1, func(_ context.Context, item string) string {
+		if item == "a" {
+			cancel()
+		}
+		return item
+	})
+	if results[0] != "a" || results[1] != "" || results[2] != "" {
+		t.Fatalf("results = %q, want only the first item run", results)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/sandbox0-ai/s0/internal/client"
 	"github.com/sandbox0-ai/s0/internal/config"
@@ -22,6 +23,7 @@ var ErrNoToken = errors.New("no API token configured. Set SANDBOX0_TOKEN environ
 var (
 	cfgVersion string
 	cfgFormat  string
+	noHeaders  bool
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -32,6 +34,13 @@ var rootCmd = &cobra.Command{
 
 It provides comprehensive management of sandboxes, templates, volumes,
 snapshots, and container images.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		resolveOutputFormat(cmd)
+		if err := resolveAliasArgs(cmd, args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 // Execute runs the root command.
@@ -45,20 +54,53 @@ func Execute(version string) {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(config.GetConfigFile(), "config", "c", "", "config file (default is ~/.s0/config.yaml)")
-	rootCmd.PersistentFlags().StringVarP(&cfgFormat, "output", "o", "table", "output format (table, json, yaml)")
+	rootCmd.PersistentFlags().StringVarP(&cfgFormat, "output", "o", config.DefaultFormat, "output format: table|wide|json|yaml|name|jsonpath=<template>|go-template=<template>|custom-columns=<spec>|ndjson|csv|tsv; defaults to the configured output format")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "omit header rows from table, custom-columns, csv, and tsv output")
 	rootCmd.PersistentFlags().StringVarP(config.GetProfileVar(), "profile", "p", "", "profile name (default is \"default\")")
 	rootCmd.PersistentFlags().StringVar(config.GetAPIURLVar(), "api-url", "", "override API URL")
 	rootCmd.PersistentFlags().StringVar(config.GetTokenVar(), "token", "", "override API token")
 }
 
+// resolveOutputFormat applies the configured default format for cmd unless
+// --output was set explicitly.
+func resolveOutputFormat(cmd *cobra.Command) {
+	if flag := cmd.Flag("output"); flag == nil || flag.Changed {
+		return
+	}
+	cfg, err := getConfig()
+	if err != nil {
+		return
+	}
+	cfgFormat = cfg.GetOutputFormat(cfg.GetActiveProfile(), commandPathWithoutRoot(cmd))
+}
+
+// commandPathWithoutRoot returns the command path without the program name,
+// e.g. "sandbox logs".
+func commandPathWithoutRoot(cmd *cobra.Command) string {
+	var names []string
+	for current := cmd; current != nil && current.HasParent(); current = current.Parent() {
+		names = append([]string{current.Name()}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
 // getFormatter returns the output formatter based on the format flag.
 func getFormatter() output.Formatter {
-	return output.NewFormatter(output.ParseFormat(cfgFormat))
+	return getFormatterWithOptions(output.Options{})
 }
 
 // getFormatterWithOptions returns formatter with custom options.
 func getFormatterWithOptions(opts output.Options) output.Formatter {
-	return output.NewFormatterWithOptions(output.ParseFormat(cfgFormat), opts)
+	format, template := output.ParseFormatSpec(cfgFormat)
+	opts.Template = template
+	opts.NoHeaders = noHeaders
+	return output.NewFormatterWithOptions(format, opts)
+}
+
+// isStructuredOutput reports whether the selected format is meant for
+// programs rather than a human-readable table.
+func isStructuredOutput() bool {
+	return !output.ParseFormat(cfgFormat).IsTable()
 }
 
 // getConfig loads and returns the configuration.
@@ -87,9 +129,16 @@ func getClient(cmd *cobra.Command) (*client.Client, error) {
 
 // getClientRaw creates a raw SDK client for operations that don't need the wrapper.
 func getClientRaw(cmd *cobra.Command) (*sandbox0.Client, error) {
+	c, _, err := getClientRawWithTarget(cmd)
+	return c, err
+}
+
+// getClientRawWithTarget creates a raw SDK client and returns the target it
+// was routed to.
+func getClientRawWithTarget(cmd *cobra.Command) (*sandbox0.Client, *client.ResolvedTarget, error) {
 	resolved, userAgent, currentTeamID, scope, err := resolveClientTarget(cmd)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	opts := []sandbox0.Option{
@@ -101,7 +150,11 @@ func getClientRaw(cmd *cobra.Command) (*sandbox0.Client, error) {
 	}
 	appendCurrentTeamHeader(&opts, currentTeamID, scope)
 
-	return sandbox0.NewClient(opts...)
+	c, err := sandbox0.NewClient(opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, resolved, nil
 }
 
 func resolveClientTarget(cmd *cobra.Command) (*client.ResolvedTarget, string, string, client.RouteScope, error) {
@@ -165,6 +218,11 @@ func buildUserAgent() string {
 func commandRouteScope(cmd *cobra.Command) client.RouteScope {
 	for current := cmd; current != nil; current = current.Parent() {
 		switch current.Name() {
+		case "apply", "diff", "delete":
+			// Top-level manifest commands manage home-region resources.
+			if current.HasParent() && !current.Parent().HasParent() {
+				return client.RouteScopeHomeRegion
+			}
 		case "sandbox", "template", "volume", "credential", "apikey", "image", "ssh-key", "quota":
 			return client.RouteScopeHomeRegion
 		case "auth", "team", "user":
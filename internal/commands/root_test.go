@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"os"
 	"testing"
 
 	"github.com/sandbox0-ai/s0/internal/client"
+	"github.com/sandbox0-ai/s0/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -28,3 +30,63 @@ func TestCommandRouteScopeKeepsUserProfileOnEntrypoint(t *testing.T) {
 		t.Fatalf("commandRouteScope(user get) = %q, want %q", got, client.RouteScopeEntrypoint)
 	}
 }
+
+func TestCommandRouteScopeRoutesTopLevelManifestCommandsToHomeRegion(t *testing.T) {
+	root := &cobra.Command{Use: "s0"}
+	apply := &cobra.Command{Use: "apply"}
+	team := &cobra.Command{Use: "team"}
+	teamDelete := &cobra.Command{Use: "delete"}
+	root.AddCommand(apply, team)
+	team.AddCommand(teamDelete)
+
+	if got := commandRouteScope(apply); got != client.RouteScopeHomeRegion {
+		t.Fatalf("commandRouteScope(apply) = %q, want %q", got, client.RouteScopeHomeRegion)
+	}
+	if got := commandRouteScope(teamDelete); got != client.RouteScopeEntrypoint {
+		t.Fatalf("commandRouteScope(team delete) = %q, want %q", got, client.RouteScopeEntrypoint)
+	}
+}
+
+func TestResolveOutputFormatUsesConfigUnlessFlagSet(t *testing.T) {
+	configPath := t.TempDir() + "/config.yaml"
+	if err := os.WriteFile(configPath, []byte("output:\n  format: yaml\n  commands:\n    sandbox logs: json\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	previousConfig := *config.GetConfigFile()
+	previousFormat := cfgFormat
+	t.Cleanup(func() {
+		config.SetConfigFile(previousConfig)
+		cfgFormat = previousFormat
+	})
+	config.SetConfigFile(configPath)
+
+	newTree := func() (*cobra.Command, *cobra.Command, *cobra.Command) {
+		root := &cobra.Command{Use: "s0"}
+		root.PersistentFlags().StringVarP(&cfgFormat, "output", "o", config.DefaultFormat, "")
+		sandbox := &cobra.Command{Use: "sandbox"}
+		logs := &cobra.Command{Use: "logs"}
+		list := &cobra.Command{Use: "list"}
+		root.AddCommand(sandbox)
+		sandbox.AddCommand(logs, list)
+		return root, logs, list
+	}
+
+	_, logs, list := newTree()
+	resolveOutputFormat(logs)
+	if cfgFormat != "json" {
+		t.Fatalf("format for sandbox logs = %q, want json", cfgFormat)
+	}
+	resolveOutputFormat(list)
+	if cfgFormat != "yaml" {
+		t.Fatalf("format for sandbox list = %q, want yaml", cfgFormat)
+	}
+
+	root, logs, _ := newTree()
+	if err := root.PersistentFlags().Set("output", "table"); err != nil {
+		t.Fatalf("Set(output) error = %v", err)
+	}
+	resolveOutputFormat(logs)
+	if cfgFormat != "table" {
+		t.Fatalf("format with explicit --output = %q, want table", cfgFormat)
+	}
+}
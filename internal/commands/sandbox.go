@@ -10,6 +10,7 @@ import (
 
 	"github.com/ghodss/yaml"
 	"github.com/google/uuid"
+	"github.com/sandbox0-ai/s0/internal/config"
 	"github.com/sandbox0-ai/s0/internal/output"
 	sandbox0 "github.com/sandbox0-ai/sdk-go"
 	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
@@ -17,22 +18,26 @@ import (
 )
 
 var (
-	sandboxTemplate   string
-	sandboxTTL        int32
-	sandboxHardTTL    int32
-	sandboxMemory     string
-	sandboxConfigFile string
-	sandboxMounts     []string
-	sandboxSnapshotID string
+	sandboxTemplate     string
+	sandboxTTL          int32
+	sandboxHardTTL      int32
+	sandboxMemory       string
+	sandboxConfigFile   string
+	sandboxMounts       []string
+	sandboxSnapshotID   string
+	sandboxAliasName    string
+	sandboxDevcontainer string
 	// list flags
 	sandboxListStatus     string
 	sandboxListTemplateID string
 	sandboxListPaused     string
 	sandboxListLimit      int
 	sandboxListOffset     int
+	sandboxListAll        bool
 	// observability flags
 	sandboxObsLimit        int
 	sandboxObsCursor       string
+	sandboxObsAll          bool
 	sandboxObsStartTime    string
 	sandboxObsEndTime      string
 	sandboxObsSince        string
@@ -73,7 +78,7 @@ type sandboxCreateOutput struct {
 }
 
 func sandboxCreateOutputValue(sandbox *sandbox0.Sandbox) any {
-	if cfgFormat != "json" && cfgFormat != "yaml" {
+	if !isStructuredOutput() {
 		return sandbox
 	}
 	return sandboxCreateOutput{
@@ -97,7 +102,14 @@ var sandboxCmd = &cobra.Command{
 var sandboxCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create (claim) a new sandbox",
-	Long:  `Create a new sandbox from a template.`,
+	Long: `Create a new sandbox from a template.
+
+With --devcontainer, the template is created or updated from devcontainer.json:
+image is used directly and build.dockerfile is built locally and pushed to the
+Sandbox0 registry. containerEnv becomes sandbox environment variables,
+forwardPorts become sandbox services, volume mounts of sandbox volumes become
+bootstrap mounts, and postCreateCommand runs once the sandbox is running.
+Keys that cannot be mapped onto a sandbox are reported as errors.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, err := getClientRaw(cmd)
 		if err != nil {
@@ -105,18 +117,70 @@ var sandboxCreateCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		request, err := buildSandboxCreateRequest()
+		var devcontainer *devcontainerConfig
+		if sandboxDevcontainer != "" {
+			devcontainer, err = loadDevcontainer(sandboxDevcontainer, sandboxTemplate)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading devcontainer: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		request, err := buildSandboxCreateRequest(devcontainer)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error building sandbox create request: %v\n", err)
 			os.Exit(1)
 		}
+		if sandboxAliasName != "" {
+			if err := config.ValidateAliasName(sandboxAliasName); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
-		sandbox, err := client.ClaimSandboxRequest(cmd.Context(), request)
+		if devcontainer != nil {
+			if err := prepareDevcontainerTemplate(cmd, client, devcontainer); err != nil {
+				fmt.Fprintf(os.Stderr, "Error preparing devcontainer template: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		var sandbox *sandbox0.Sandbox
+		err = retryClaimStartThrottled(cmd.Context(), sandboxRetryThrottled, "Sandbox claim", func() error {
+			var err error
+			sandbox, err = client.ClaimSandboxRequest(cmd.Context(), request)
+			return err
+		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating sandbox: %s\n", formatSandboxCreateError(err))
 			os.Exit(1)
 		}
 
+		if sandboxAliasName != "" {
+			if err := setAlias(sandboxAliasName, sandbox.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: sandbox %s created but alias @%s was not saved: %v\n", sandbox.ID, sandboxAliasName, err)
+			}
+		}
+
+		if sandboxWait || (devcontainer != nil && len(devcontainer.PostCreate) > 0) {
+			if err := checkSandboxBootstrapMounts(sandbox); err != nil {
+				exitSandboxWaitError(err)
+			}
+			status, err := waitForSandboxStatus(cmd.Context(), client, sandbox.ID, sandboxWaitTimeout, string(apispec.SandboxLifecycleStatusRunning))
+			if err != nil {
+				exitSandboxWaitError(err)
+			}
+			if current, ok := status.Status.Get(); ok {
+				sandbox.Status = string(current)
+			}
+		}
+		if devcontainer != nil {
+			if err := runDevcontainerPostCreate(cmd.Context(), sandbox, devcontainer); err != nil {
+				fmt.Fprintf(os.Stderr, "Error running postCreateCommand in sandbox %s: %v\n", sandbox.ID, err)
+				os.Exit(1)
+			}
+		}
+
 		if err := getFormatter().Format(os.Stdout, sandboxCreateOutputValue(sandbox)); err != nil {
 			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
 			os.Exit(1)
@@ -154,11 +218,15 @@ var sandboxGetCmd = &cobra.Command{
 
 // sandboxDeleteCmd deletes a sandbox.
 var sandboxDeleteCmd = &cobra.Command{
-	Use:   "delete <sandbox-id>",
+	Use:   "delete [sandbox-id]",
 	Short: "Delete a sandbox",
-	Long:  `Delete (terminate) a sandbox by its ID.`,
-	Args:  cobra.ExactArgs(1),
+	Long:  `Delete (terminate) a sandbox by its ID, or every sandbox matching --all or the selector flags.`,
+	Args:  sandboxTargetArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		if sandboxBulkRequested(cmd) {
+			runSandboxBulkOperation(cmd, sandboxBulkDelete)
+			return
+		}
 		sandboxID := args[0]
 
 		client, err := getClientRaw(cmd)
@@ -179,11 +247,15 @@ var sandboxDeleteCmd = &cobra.Command{
 
 // sandboxPauseCmd pauses a sandbox.
 var sandboxPauseCmd = &cobra.Command{
-	Use:   "pause <sandbox-id>",
+	Use:   "pause [sandbox-id]",
 	Short: "Pause a sandbox",
-	Long:  `Pause (suspend) a sandbox by its ID.`,
-	Args:  cobra.ExactArgs(1),
+	Long:  `Pause (suspend) a sandbox by its ID, or every sandbox matching --all or the selector flags.`,
+	Args:  sandboxTargetArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		if sandboxBulkRequested(cmd) {
+			runSandboxBulkOperation(cmd, sandboxBulkPause)
+			return
+		}
 		sandboxID := args[0]
 
 		client, err := getClientRaw(cmd)
@@ -204,11 +276,15 @@ var sandboxPauseCmd = &cobra.Command{
 
 // sandboxResumeCmd resumes a sandbox.
 var sandboxResumeCmd = &cobra.Command{
-	Use:   "resume <sandbox-id>",
+	Use:   "resume [sandbox-id]",
 	Short: "Resume a sandbox",
-	Long:  `Resume a paused sandbox by its ID.`,
-	Args:  cobra.ExactArgs(1),
+	Long:  `Resume a paused sandbox by its ID, or every sandbox matching --all or the selector flags.`,
+	Args:  sandboxTargetArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		if sandboxBulkRequested(cmd) {
+			runSandboxBulkOperation(cmd, sandboxBulkResume)
+			return
+		}
 		sandboxID := args[0]
 
 		client, err := getClientRaw(cmd)
@@ -217,12 +293,23 @@ var sandboxResumeCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		_, err = client.ResumeSandbox(cmd.Context(), sandboxID)
+		err = retryClaimStartThrottled(cmd.Context(), sandboxRetryThrottled, "Sandbox resume", func() error {
+			_, err := client.ResumeSandbox(cmd.Context(), sandboxID)
+			return err
+		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error resuming sandbox: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error resuming sandbox: %s\n", formatSandboxCreateError(err))
 			os.Exit(1)
 		}
 
+		if sandboxWait {
+			if _, err := waitForSandboxStatus(cmd.Context(), client, sandboxID, sandboxWaitTimeout, string(apispec.SandboxLifecycleStatusRunning)); err != nil {
+				exitSandboxWaitError(err)
+			}
+			fmt.Printf("Sandbox %s is running\n", sandboxID)
+			return
+		}
+
 		fmt.Printf("Resume requested for sandbox %s\n", sandboxID)
 	},
 }
@@ -356,6 +443,14 @@ var sandboxListCmd = &cobra.Command{
 			opts.Offset = &sandboxListOffset
 		}
 
+		if sandboxListAll {
+			if err := listAllSandboxes(cmd.Context(), client, *opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error listing sandboxes: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		resp, err := client.ListSandboxes(cmd.Context(), opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error listing sandboxes: %v\n", err)
@@ -405,12 +500,20 @@ var sandboxLogsCmd = &cobra.Command{
 			return
 		}
 
+		if sandboxObsAll {
+			if err := listAllObservabilityLogs(cmd.Context(), sandbox, *options); err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting sandbox logs: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		logs, err := sandbox.ListLogs(cmd.Context(), options)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting sandbox logs: %v\n", err)
 			os.Exit(1)
 		}
-		if cfgFormat == "json" || cfgFormat == "yaml" {
+		if isStructuredOutput() {
 			if err := getFormatter().Format(os.Stdout, logs); err != nil {
 				fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
 				os.Exit(1)
@@ -453,6 +556,13 @@ var sandboxEventsCmd = &cobra.Command{
 			}
 			return
 		}
+		if sandboxObsAll {
+			if err := listAllObservabilityEvents(cmd.Context(), sandbox, *options); err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting sandbox events: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 		events, err := sandbox.ListObservabilityEvents(cmd.Context(), options)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting sandbox events: %v\n", err)
@@ -500,13 +610,22 @@ func init() {
 	rootCmd.AddCommand(sandboxCmd)
 
 	// Create command flags
-	sandboxCreateCmd.Flags().StringVarP(&sandboxTemplate, "template", "t", "", "template ID (required unless config file includes template)")
+	sandboxCreateCmd.Flags().StringVarP(&sandboxTemplate, "template", "t", "", "template ID (required unless config file includes template); with --devcontainer, the template to create or update")
 	sandboxCreateCmd.Flags().StringVarP(&sandboxConfigFile, "config-file", "f", "", "path to sandbox config or claim request YAML/JSON file, or - for stdin")
 	sandboxCreateCmd.Flags().Int32Var(&sandboxTTL, "ttl", 0, "soft TTL in seconds")
 	sandboxCreateCmd.Flags().Int32Var(&sandboxHardTTL, "hard-ttl", 0, "hard TTL in seconds")
 	sandboxCreateCmd.Flags().StringVar(&sandboxMemory, "memory", "", "sandbox memory limit, for example 512Mi or 2Gi")
 	sandboxCreateCmd.Flags().StringArrayVar(&sandboxMounts, "mount", nil, "bootstrap mount in the form <sandboxvolume-id>:/absolute/path (repeatable)")
 	sandboxCreateCmd.Flags().StringVar(&sandboxSnapshotID, "snapshot-id", "", "rootfs snapshot ID used to initialize the new sandbox")
+	sandboxCreateCmd.Flags().StringVar(&sandboxDevcontainer, "devcontainer", "", "devcontainer.json file, or a directory containing one, to build the template and claim from")
+	sandboxCreateCmd.Flags().StringVar(&sandboxAliasName, "name", "", "record a local alias for the new sandbox, usable as @name")
+	addSandboxWaitFlags(sandboxCreateCmd, "running")
+	addSandboxWaitFlags(sandboxResumeCmd, "running")
+	addSandboxRetryThrottledFlag(sandboxCreateCmd)
+	addSandboxRetryThrottledFlag(sandboxResumeCmd)
+	addSandboxBulkFlags(sandboxDeleteCmd)
+	addSandboxBulkFlags(sandboxPauseCmd)
+	addSandboxBulkFlags(sandboxResumeCmd)
 
 	sandboxCmd.AddCommand(sandboxCreateCmd)
 	sandboxCmd.AddCommand(sandboxGetCmd)
@@ -533,6 +652,7 @@ func init() {
 	sandboxListCmd.Flags().StringVar(&sandboxListPaused, "paused", "", "filter by paused state (true/false)")
 	sandboxListCmd.Flags().IntVar(&sandboxListLimit, "limit", 50, "maximum number of results")
 	sandboxListCmd.Flags().IntVar(&sandboxListOffset, "offset", 0, "pagination offset")
+	sandboxListCmd.Flags().BoolVar(&sandboxListAll, "all", false, "fetch every page, using --limit as the page size")
 	sandboxCmd.AddCommand(sandboxListCmd)
 
 	addSandboxObservabilityFlags(sandboxLogsCmd)
@@ -557,7 +677,7 @@ func init() {
 	sandboxMetricsCmd.Flags().IntVar(&sandboxMetricPoints, "max-points", 240, "maximum points per returned series")
 }
 
-func buildSandboxCreateRequest() (apispec.ClaimRequest, error) {
+func buildSandboxCreateRequest(devcontainer *devcontainerConfig) (apispec.ClaimRequest, error) {
 	request := apispec.ClaimRequest{}
 	if sandboxConfigFile != "" {
 		var err error
@@ -572,6 +692,9 @@ func buildSandboxCreateRequest() (apispec.ClaimRequest, error) {
 	if sandboxSnapshotID != "" {
 		request.SnapshotID = apispec.NewOptString(sandboxSnapshotID)
 	}
+	if devcontainer != nil {
+		applyDevcontainerClaim(&request, devcontainer)
+	}
 
 	configOverrides, hasConfigOverrides, err := buildSandboxCreateConfigOverrides()
 	if err != nil {
@@ -612,6 +735,7 @@ func addSandboxObservabilityFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&sandboxObsEndTime, "end-time", "", "exclusive end time (RFC3339, not valid with --watch)")
 	cmd.Flags().StringVar(&sandboxObsSince, "since", "", "relative start time, for example 10m or 1h")
 	cmd.Flags().BoolVar(&sandboxObsWatch, "watch", false, "watch realtime records as they are ingested")
+	cmd.Flags().BoolVar(&sandboxObsAll, "all", false, "follow next_cursor and fetch every page, using --limit as the page size")
 }
 
 func addSandboxEventFilterFlags(cmd *cobra.Command) {
@@ -635,6 +759,9 @@ func buildSandboxLogObservabilityOptions(cmd *cobra.Command) (*sandbox0.SandboxO
 		if query.EndTime != nil {
 			return nil, false, fmt.Errorf("--end-time cannot be used with --follow")
 		}
+		if sandboxObsAll {
+			return nil, false, fmt.Errorf("--all cannot be used with --follow")
+		}
 		watch = true
 	}
 	if cmd.Flags().Changed("tail") {
@@ -726,7 +853,7 @@ func validateExactAuditEventLookup(cmd *cobra.Command) error {
 		return nil
 	}
 	for _, name := range []string{
-		"cursor", "start-time", "end-time", "since", "watch",
+		"cursor", "start-time", "end-time", "since", "watch", "all",
 		"source", "event-type", "outcome", "actor-kind", "actor-id",
 		"action", "resource-type", "operation-id",
 	} {
@@ -826,6 +953,9 @@ func buildSandboxObservabilityQueryOptions(cmd *cobra.Command) (sandbox0.Sandbox
 		}
 		options.EndTime = &end
 	}
+	if sandboxObsAll && sandboxObsWatch {
+		return options, false, fmt.Errorf("--all cannot be used with --watch")
+	}
 	return options, sandboxObsWatch, nil
 }
 
@@ -858,6 +988,9 @@ func writeObservabilityWatchLine(line *sandbox0.SandboxObservabilityWatchLine) e
 	if cfgFormat == "json" || cfgFormat == "yaml" {
 		return json.NewEncoder(os.Stdout).Encode(line)
 	}
+	if isStructuredOutput() {
+		return getFormatter().Format(os.Stdout, line)
+	}
 	switch line.Type {
 	case "heartbeat", "watermark":
 		return nil
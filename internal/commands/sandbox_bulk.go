@@ -0,0 +1,266 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sandboxBulkAll         bool
+	sandboxBulkStatus      string
+	sandboxBulkTemplateID  string
+	sandboxBulkPaused      string
+	sandboxBulkOlderThan   time.Duration
+	sandboxBulkDryRun      bool
+	sandboxBulkYes         bool
+	sandboxBulkConcurrency int
+)
+
+// sandboxSelectorFlags select sandboxes for a bulk operation instead of a
+// single ID argument.
+var sandboxSelectorFlags = []string{"all", "status", "template-id", "paused", "older-than"}
+
+// sandboxBulkOperation is a lifecycle operation that can run on many
+// sandboxes at once.
+type sandboxBulkOperation struct {
+	// verb and done describe the operation, for example "delete" and "deleted".
+	verb string
+	done string
+	run  func(ctx context.Context, client *sandbox0.Client, sandboxID string) error
+}
+
+var sandboxBulkDelete = sandboxBulkOperation{
+	verb: "delete",
+	done: "deleted",
+	run: func(ctx context.Context, client *sandbox0.Client, sandboxID string) error {
+		_, err := client.DeleteSandbox(ctx, sandboxID)
+		return err
+	},
+}
+
+var sandboxBulkPause = sandboxBulkOperation{
+	verb: "pause",
+	done: "paused",
+	run: func(ctx context.Context, client *sandbox0.Client, sandboxID string) error {
+		_, err := client.PauseSandbox(ctx, sandboxID)
+		return err
+	},
+}
+
+var sandboxBulkResume = sandboxBulkOperation{
+	verb: "resume",
+	done: "resumed",
+	run: func(ctx context.Context, client *sandbox0.Client, sandboxID string) error {
+		err := retryClaimStartThrottled(ctx, sandboxRetryThrottled, "Sandbox resume "+sandboxID, func() error {
+			_, err := client.ResumeSandbox(ctx, sandboxID)
+			return err
+		})
+		if err != nil || !sandboxWait {
+			return err
+		}
+		_, err = waitForSandboxStatus(ctx, client, sandboxID, sandboxWaitTimeout, string(apispec.SandboxLifecycleStatusRunning))
+		return err
+	},
+}
+
+// addSandboxBulkFlags registers the selector and bulk execution flags.
+func addSandboxBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&sandboxBulkAll, "all", false, "select every sandbox of the team")
+	cmd.Flags().StringVar(&sandboxBulkStatus, "status", "", "select sandboxes by status (starting, running, paused, failed)")
+	cmd.Flags().StringVar(&sandboxBulkTemplateID, "template-id", "", "select sandboxes by template ID")
+	cmd.Flags().StringVar(&sandboxBulkPaused, "paused", "", "select sandboxes by paused state (true/false)")
+	cmd.Flags().DurationVar(&sandboxBulkOlderThan, "older-than", 0, "select sandboxes created longer ago than this, for example 24h")
+	cmd.Flags().BoolVar(&sandboxBulkDryRun, "dry-run", false, "print the selected sandboxes without changing them")
+	cmd.Flags().BoolVarP(&sandboxBulkYes, "yes", "y", false, "skip the confirmation prompt")
+	cmd.Flags().IntVar(&sandboxBulkConcurrency, "concurrency", 4, "number of sandboxes to process in parallel")
+}
+
+// sandboxTargetArgs accepts one sandbox ID, or no arguments when selector
+// flags choose the sandboxes.
+func sandboxTargetArgs(cmd *cobra.Command, args []string) error {
+	if !sandboxBulkRequested(cmd) {
+		for _, name := range []string{"dry-run", "yes", "concurrency"} {
+			if cmd.Flags().Changed(name) {
+				return fmt.Errorf("--%s requires --all or a selector flag", name)
+			}
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("a sandbox ID cannot be combined with --all or selector flags")
+	}
+	return nil
+}
+
+func sandboxBulkRequested(cmd *cobra.Command) bool {
+	for _, name := range sandboxSelectorFlags {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSandboxSelector converts the selector flags to list options.
+func buildSandboxSelector() (sandbox0.ListSandboxesOptions, error) {
+	opts := sandbox0.ListSandboxesOptions{Status: sandboxBulkStatus, TemplateID: sandboxBulkTemplateID}
+	switch sandboxBulkPaused {
+	case "":
+	case "true", "false":
+		paused := sandboxBulkPaused == "true"
+		opts.Paused = &paused
+	default:
+		return opts, fmt.Errorf("invalid --paused %q, expected true or false", sandboxBulkPaused)
+	}
+	if sandboxBulkOlderThan < 0 {
+		return opts, fmt.Errorf("--older-than cannot be negative")
+	}
+	if sandboxBulkConcurrency < 1 {
+		return opts, fmt.Errorf("--concurrency must be greater than 0")
+	}
+	limit := 100
+	opts.Limit = &limit
+	return opts, nil
+}
+
+// selectSandboxes lists every sandbox matching opts that was created before
+// createdBefore. A zero createdBefore matches any creation time.
+func selectSandboxes(ctx context.Context, client *sandbox0.Client, opts sandbox0.ListSandboxesOptions, createdBefore time.Time) ([]apispec.SandboxSummary, error) {
+	var selected []apispec.SandboxSummary
+	err := forEachSandboxPage(ctx, client, opts, func(page *sandbox0.ListSandboxesResponse) error {
+		for _, sandbox := range page.Sandboxes {
+			if createdBefore.IsZero() || sandbox.CreatedAt.Before(createdBefore) {
+				selected = append(selected, sandbox)
+			}
+		}
+		return nil
+	})
+	return selected, err
+}
+
+// runSandboxBulkOperation selects sandboxes with the selector flags, confirms
+// the operation, runs it with bounded parallelism, and prints a summary.
+func runSandboxBulkOperation(cmd *cobra.Command, op sandboxBulkOperation) {
+	opts, err := buildSandboxSelector()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	client, err := getClientRaw(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	var createdBefore time.Time
+	if sandboxBulkOlderThan > 0 {
+		createdBefore = time.Now().Add(-sandboxBulkOlderThan)
+	}
+	selected, err := selectSandboxes(cmd.Context(), client, opts, createdBefore)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing sandboxes: %v\n", err)
+		os.Exit(1)
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(os.Stderr, "No sandboxes matched the selector.")
+		return
+	}
+	preview := &sandbox0.ListSandboxesResponse{Sandboxes: selected, Count: len(selected)}
+
+	if sandboxBulkDryRun {
+		if err := getFormatter().Format(os.Stdout, preview); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "%d %s would be %s (dry run)\n", len(selected), pluralizeSandbox(len(selected)), op.done)
+		return
+	}
+
+	if !sandboxBulkYes {
+		if !isTerminalFile(os.Stdin) {
+			fmt.Fprintf(os.Stderr, "Error: refusing to %s %d %s without --yes when stdin is not a terminal\n", op.verb, len(selected), pluralizeSandbox(len(selected)))
+			os.Exit(1)
+		}
+		if err := output.NewFormatter(output.FormatTable).Format(os.Stderr, preview); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		question := fmt.Sprintf("%s %d %s?", capitalize(op.verb), len(selected), pluralizeSandbox(len(selected)))
+		if !confirm(os.Stdin, os.Stderr, question) {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			os.Exit(1)
+		}
+	}
+
+	ids := make([]string, len(selected))
+	for i, sandbox := range selected {
+		ids[i] = sandbox.ID
+	}
+	results := runSandboxOperations(cmd.Context(), ids, sandboxBulkConcurrency, op, func(ctx context.Context, sandboxID string) error {
+		return op.run(ctx, client, sandboxID)
+	})
+
+	if err := getFormatter().Format(os.Stdout, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s %d of %d %s, %d failed\n", capitalize(op.done), len(results)-failed, len(results), pluralizeSandbox(len(results)), failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runSandboxOperations runs fn for every ID, at most concurrency at a time,
+// and records each outcome as op.done or failed.
+func runSandboxOperations(ctx context.Context, ids []string, concurrency int, op sandboxBulkOperation, fn func(ctx context.Context, sandboxID string) error) output.SandboxOperationResults {
+	return runBounded(ctx, ids, concurrency, func(ctx context.Context, id string) output.SandboxOperationResult {
+		result := output.SandboxOperationResult{ID: id, Result: op.done}
+		if err := fn(ctx, id); err != nil {
+			result.Result = "failed"
+			result.Error = err.Error()
+		}
+		return result
+	})
+}
+
+// confirm asks a yes/no question and reports whether the answer was yes.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+func pluralizeSandbox(n int) string {
+	if n == 1 {
+		return "sandbox"
+	}
+	return "sandboxes"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
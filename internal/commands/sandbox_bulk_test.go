@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/spf13/cobra"
+)
+
+func newSandboxBulkTestCommand() *cobra.Command {
+	cmd := &cobra.Command{Use: "delete"}
+	addSandboxBulkFlags(cmd)
+	return cmd
+}
+
+func TestSandboxTargetArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		args    []string
+		wantErr bool
+	}{
+		{name: "single ID", args: []string{"sb_1"}},
+		{name: "missing ID", wantErr: true},
+		{name: "selector", flags: map[string]string{"status": "failed"}},
+		{name: "all", flags: map[string]string{"all": "true"}},
+		{name: "selector with ID", flags: map[string]string{"all": "true"}, args: []string{"sb_1"}, wantErr: true},
+		{name: "dry run without selector", flags: map[string]string{"dry-run": "true"}, args: []string{"sb_1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newSandboxBulkTestCommand()
+			for name, value := range tt.flags {
+				if err := cmd.Flags().Set(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sandboxTargetArgs(cmd, tt.args); (err != nil) != tt.wantErr {
+				t.Fatalf("sandboxTargetArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectSandboxesFiltersByCreationTime(t *testing.T) {
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != "failed" {
+			t.Errorf("status = %q, want failed", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		var items []string
+		for id, created := range map[string]string{"sb_old": "2026-01-01T00:00:00Z", "sb_new": "2026-03-01T00:00:00Z"} {
+			items = append(items, fmt.Sprintf(`{"id":%q,"template_id":"python","status":"failed","paused":false,"runtime_generation":1,"created_at":%q,"expires_at":%q,"hard_expires_at":%q,"updated_at":%q}`, id, created, created, created, created))
+		}
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{"sandboxes":[%s],"count":2,"has_more":false}}`, strings.Join(items, ","))
+	})
+
+	selected, err := selectSandboxes(context.Background(), client, sandbox0.ListSandboxesOptions{Status: "failed"}, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("selectSandboxes() error = %v", err)
+	}
+	if len(selected) != 1 || selected[0].ID != "sb_old" {
+		t.Fatalf("selected = %+v, want only sb_old", selected)
+	}
+}
+
+func TestRunSandboxOperationsBoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ids := []string{"sb_1", "sb_2", "sb_3", "sb_4", "sb_5"}
+	results := runSandboxOperations(context.Background(), ids, 2, sandboxBulkDelete, func(_ context.Context, id string) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if id == "sb_3" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if peak.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+	for i, result := range results {
+		if result.ID != ids[i] {
+			t.Fatalf("results[%d].ID = %q, want %q", i, result.ID, ids[i])
+		}
+		wantResult := "deleted"
+		if result.ID == "sb_3" {
+			wantResult = "failed"
+		}
+		if result.Result != wantResult {
+			t.Fatalf("results[%d] = %+v, want %s", i, result, wantResult)
+		}
+	}
+	if results[2].Error != "boom" {
+		t.Fatalf("failed result error = %q, want boom", results[2].Error)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		var out bytes.Buffer
+		if got := confirm(strings.NewReader(answer), &out, "Delete 2 sandboxes?"); got != want {
+			t.Fatalf("confirm(%q) = %v, want %v", answer, got, want)
+		}
+		if out.String() != "Delete 2 sandboxes? [y/N]: " {
+			t.Fatalf("prompt = %q", out.String())
+		}
+	}
+}
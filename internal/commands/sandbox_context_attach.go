@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextAttachInteractive bool
+	contextAttachTTY         bool
+)
+
+// sandboxContextAttachCmd streams the output of an existing context.
+var sandboxContextAttachCmd = &cobra.Command{
+	Use:   "attach <context-id>",
+	Short: "Attach to a running context",
+	Long: `Attach to a running CMD or REPL context, such as one started with
+'sandbox exec --no-wait' or 'sandbox context create', and stream its output.
+
+With -i, stdin, interrupt, and terminate signals are forwarded to the context;
+-t also puts the terminal in raw mode and forwards size changes. Without -i,
+interrupting the CLI leaves the context running. The command exits with the
+context's exit code once it finishes, and reports it right away for a context
+that has already exited.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		contextID := args[0]
+
+		if contextAttachTTY && !contextAttachInteractive {
+			fmt.Fprintln(os.Stderr, "Error: --tty requires --interactive")
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		err = attachContext(cmd.Context(), client.Sandbox(contextSandboxID), contextID, os.Stdin, os.Stdout, os.Stderr, contextStreamOptions{
+			tty:     contextAttachTTY,
+			input:   contextAttachInteractive,
+			signals: contextAttachInteractive,
+		})
+		if err != nil {
+			var exitErr *execExitCodeError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.code)
+			}
+			fmt.Fprintf(os.Stderr, "Error attaching to context: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// attachContext streams the output of the context until it finishes. A
+// context that has already exited has its captured output written instead.
+// A non-zero exit code is returned as *execExitCodeError.
+func attachContext(ctx context.Context, sandbox *sandbox0.Sandbox, contextID string, in io.Reader, stdout, stderr io.Writer, opts contextStreamOptions) error {
+	contextResp, err := sandbox.GetContext(ctx, contextID)
+	if err != nil {
+		return err
+	}
+	if !contextResp.Running {
+		if _, exited := contextResp.ExitCode.Get(); !exited {
+			return fmt.Errorf("context %s is not running", contextID)
+		}
+		return writeExitedContext(contextResp, stdout, stderr)
+	}
+
+	conn, _, err := sandbox.ConnectWSContext(ctx, contextID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if err := streamContextWS(ctx, conn, in, stdout, stderr, opts); err != nil || ctx.Err() != nil {
+		return err
+	}
+	// The connection may close without a done message once the process
+	// exits; the context then reports the exit code.
+	contextResp, err = sandbox.GetContext(ctx, contextID)
+	if err != nil {
+		return fmt.Errorf("get context exit code: %w", err)
+	}
+	if code, ok := contextResp.ExitCode.Get(); ok && code != 0 {
+		return &execExitCodeError{code: int(code)}
+	}
+	return nil
+}
+
+// writeExitedContext writes the output captured for an exited context and
+// returns its exit code.
+func writeExitedContext(contextResp *apispec.ContextResponse, stdout, stderr io.Writer) error {
+	if raw, ok := contextResp.OutputRaw.Get(); ok {
+		if _, err := io.WriteString(stdout, raw); err != nil {
+			return err
+		}
+	} else {
+		if _, err := io.WriteString(stdout, contextResp.Stdout.Or("")); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(stderr, contextResp.Stderr.Or("")); err != nil {
+			return err
+		}
+	}
+	if code := contextResp.ExitCode.Or(0); code != 0 {
+		return &execExitCodeError{code: int(code)}
+	}
+	return nil
+}
+
+func init() {
+	sandboxContextCmd.AddCommand(sandboxContextAttachCmd)
+	sandboxContextAttachCmd.Flags().BoolVarP(&contextAttachInteractive, "interactive", "i", false, "forward stdin and interrupt/terminate signals to the context")
+	sandboxContextAttachCmd.Flags().BoolVarP(&contextAttachTTY, "tty", "t", false, "put the terminal in raw mode and forward size changes (requires -i)")
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// newContextAttachServer serves ctx_1 of sb_1. Once the WebSocket has been
+// opened, the context reports exited with exitCode; the socket sends
+// messages and then closes normally.
+func newContextAttachServer(t *testing.T, running bool, exitCode int32, messages []execWSMessage) (*atomic.Bool, http.HandlerFunc) {
+	var connected atomic.Bool
+	upgrader := websocket.Upgrader{}
+	return &connected, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sandboxes/sb_1/contexts/ctx_1":
+			resp := &apispec.ContextResponse{ID: "ctx_1", Type: apispec.ProcessTypeCmd, Running: running && !connected.Load()}
+			if !resp.Running {
+				resp.ExitCode = apispec.NewOptInt32(exitCode)
+				resp.Stdout = apispec.NewOptString("captured\n")
+			}
+			writeTestSuccess(t, w, resp)
+		case "/api/v1/sandboxes/sb_1/contexts/ctx_1/ws":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("upgrade: %v", err)
+				return
+			}
+			defer conn.Close()
+			connected.Store(true)
+			for _, msg := range messages {
+				if err := conn.WriteJSON(msg); err != nil {
+					t.Errorf("write message: %v", err)
+					return
+				}
+			}
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			_, _, _ = conn.ReadMessage()
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestAttachContextStreamsUntilDone(t *testing.T) {
+	code := 3
+	_, handler := newContextAttachServer(t, true, 3, []execWSMessage{
+		{Type: "output", Source: "stdout", Data: "hello\n"},
+		{Type: "output", Source: "stderr", Data: "warn\n"},
+		{Type: "done", ExitCode: &code},
+	})
+	client := newTestSDKClient(t, handler)
+
+	var stdout, stderr bytes.Buffer
+	err := attachContext(context.Background(), client.Sandbox("sb_1"), "ctx_1", strings.NewReader(""), &stdout, &stderr, contextStreamOptions{})
+	var exitErr *execExitCodeError
+	if !errors.As(err, &exitErr) || exitErr.code != 3 {
+		t.Fatalf("attachContext() error = %v, want exit code 3", err)
+	}
+	if stdout.String() != "hello\n" || stderr.String() != "warn\n" {
+		t.Fatalf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestAttachContextReadsExitCodeAfterClose(t *testing.T) {
+	_, handler := newContextAttachServer(t, true, 4, []execWSMessage{{Type: "output", Data: "working\n"}})
+	client := newTestSDKClient(t, handler)
+
+	var stdout bytes.Buffer
+	err := attachContext(context.Background(), client.Sandbox("sb_1"), "ctx_1", strings.NewReader(""), &stdout, io.Discard, contextStreamOptions{})
+	var exitErr *execExitCodeError
+	if !errors.As(err, &exitErr) || exitErr.code != 4 {
+		t.Fatalf("attachContext() error = %v, want exit code 4", err)
+	}
+	if stdout.String() != "working\n" {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+}
+
+func TestAttachContextReportsLostExitCode(t *testing.T) {
+	connected, attachHandler := newContextAttachServer(t, true, 0, []execWSMessage{{Type: "output", Data: "working\n"}})
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/sandboxes/sb_1/contexts/ctx_1" && connected.Load() {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success":false,"error":{"code":"internal","message":"procd unavailable"}}`))
+			return
+		}
+		attachHandler(w, r)
+	})
+
+	err := attachContext(context.Background(), client.Sandbox("sb_1"), "ctx_1", strings.NewReader(""), io.Discard, io.Discard, contextStreamOptions{})
+	if err == nil || !strings.Contains(err.Error(), "get context exit code") {
+		t.Fatalf("attachContext() error = %v, want the lost exit code reported", err)
+	}
+}
+
+func TestAttachContextAlreadyExited(t *testing.T) {
+	connected, handler := newContextAttachServer(t, false, 2, nil)
+	client := newTestSDKClient(t, handler)
+
+	var stdout bytes.Buffer
+	err := attachContext(context.Background(), client.Sandbox("sb_1"), "ctx_1", strings.NewReader(""), &stdout, io.Discard, contextStreamOptions{})
+	var exitErr *execExitCodeError
+	if !errors.As(err, &exitErr) || exitErr.code != 2 {
+		t.Fatalf("attachContext() error = %v, want exit code 2", err)
+	}
+	if stdout.String() != "captured\n" {
+		t.Fatalf("stdout = %q, want the captured output", stdout.String())
+	}
+	if connected.Load() {
+		t.Fatal("attachContext() opened a WebSocket for an exited context")
+	}
+}
@@ -0,0 +1,615 @@
+package commands
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/client"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+type copyEndpointKind string
+
+const (
+	copyEndpointLocal   copyEndpointKind = "local"
+	copyEndpointSandbox copyEndpointKind = "sandbox"
+	copyEndpointVolume  copyEndpointKind = "volume"
+	copyEndpointStream  copyEndpointKind = "stream"
+)
+
+// copyEndpoint is one side of sandbox cp: a local path, a sandbox or volume
+// path addressed as <id>:<path>, or - for a tar stream on stdin/stdout.
+type copyEndpoint struct {
+	Kind copyEndpointKind
+	ID   string
+	Path string
+}
+
+func (e copyEndpoint) String() string {
+	switch e.Kind {
+	case copyEndpointSandbox, copyEndpointVolume:
+		return e.ID + ":" + e.Path
+	case copyEndpointStream:
+		return "-"
+	default:
+		return e.Path
+	}
+}
+
+func (e copyEndpoint) remote() bool {
+	return e.Kind == copyEndpointSandbox || e.Kind == copyEndpointVolume
+}
+
+// base returns the final path element, or "" when the endpoint addresses the
+// contents of a directory (for example /app/. or a bare /).
+func (e copyEndpoint) base() string {
+	var base string
+	if e.Kind == copyEndpointLocal {
+		base = filepath.Base(e.Path)
+	} else {
+		base = path.Base(e.Path)
+	}
+	if base == "." || base == "/" || base == string(filepath.Separator) {
+		return ""
+	}
+	return base
+}
+
+func (e copyEndpoint) dir() string {
+	if e.Kind == copyEndpointLocal {
+		return filepath.Dir(filepath.Clean(e.Path))
+	}
+	return path.Dir(path.Clean(e.Path))
+}
+
+func parseCopyEndpoint(arg string) (copyEndpoint, error) {
+	if arg == "-" {
+		return copyEndpoint{Kind: copyEndpointStream}, nil
+	}
+	if id, remotePath, ok := strings.Cut(arg, ":"); ok && !strings.ContainsAny(id, `/\`) {
+		var kind copyEndpointKind
+		switch {
+		case strings.HasPrefix(id, "sb_"):
+			kind = copyEndpointSandbox
+		case strings.HasPrefix(id, "vol_"):
+			kind = copyEndpointVolume
+		}
+		if kind != "" {
+			if !strings.HasPrefix(remotePath, "/") {
+				return copyEndpoint{}, fmt.Errorf("invalid path %q: %s paths must be absolute", arg, kind)
+			}
+			return copyEndpoint{Kind: kind, ID: id, Path: remotePath}, nil
+		}
+	}
+	if arg == "" {
+		return copyEndpoint{}, fmt.Errorf("path cannot be empty")
+	}
+	return copyEndpoint{Kind: copyEndpointLocal, Path: arg}, nil
+}
+
+// volumeFileSystem adapts the volume file API to remoteFileSystem.
+type volumeFileSystem struct {
+	client   *sandbox0.Client
+	volumeID string
+}
+
+func (v volumeFileSystem) StatFile(ctx context.Context, path string) (*apispec.FileInfo, error) {
+	return v.client.StatVolumeFile(ctx, v.volumeID, path)
+}
+
+func (v volumeFileSystem) ListFiles(ctx context.Context, path string) ([]apispec.FileInfo, error) {
+	return v.client.ListVolumeFiles(ctx, v.volumeID, path)
+}
+
+func (v volumeFileSystem) OpenFile(ctx context.Context, path string, offset int64) (*client.FileDownload, error) {
+	return client.DownloadVolumeFile(ctx, v.client, v.volumeID, path, offset)
+}
+
+func (v volumeFileSystem) UploadFile(ctx context.Context, path string, body io.Reader, size int64) error {
+	return client.UploadVolumeFile(ctx, v.client, v.volumeID, path, body, size)
+}
+
+func (v volumeFileSystem) Mkdir(ctx context.Context, path string, recursive bool) (*apispec.SuccessCreatedResponse, error) {
+	return v.client.MkdirVolumeFile(ctx, v.volumeID, path, recursive)
+}
+
+func (v volumeFileSystem) DeleteFile(ctx context.Context, path string) (*apispec.SuccessDeletedResponse, error) {
+	return v.client.DeleteVolumeFile(ctx, v.volumeID, path)
+}
+
+func copyEndpointFileSystem(c *sandbox0.Client, endpoint copyEndpoint) remoteFileSystem {
+	if endpoint.Kind == copyEndpointVolume {
+		return volumeFileSystem{client: c, volumeID: endpoint.ID}
+	}
+	return newSandboxFileSystem(c, endpoint.ID)
+}
+
+// copyPathInfo describes whether a copy endpoint exists and is a directory,
+// with the size, modification time, and permission bits reported for it.
+// Mode is zero when the permissions are unknown.
+type copyPathInfo struct {
+	Exists  bool
+	Dir     bool
+	Size    int64
+	ModTime time.Time
+	Mode    os.FileMode
+}
+
+func statCopyEndpoint(ctx context.Context, client *sandbox0.Client, endpoint copyEndpoint) (copyPathInfo, error) {
+	switch endpoint.Kind {
+	case copyEndpointLocal:
+		info, err := os.Stat(endpoint.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			return copyPathInfo{}, nil
+		}
+		if err != nil {
+			return copyPathInfo{}, err
+		}
+		return copyPathInfo{Exists: true, Dir: info.IsDir(), Size: info.Size(), ModTime: info.ModTime(), Mode: info.Mode().Perm()}, nil
+	case copyEndpointSandbox, copyEndpointVolume:
+		info, err := copyEndpointFileSystem(client, endpoint).StatFile(ctx, endpoint.Path)
+		if isNotFoundError(err) {
+			return copyPathInfo{}, nil
+		}
+		if err != nil {
+			return copyPathInfo{}, err
+		}
+		return copyPathInfo{
+			Exists:  true,
+			Dir:     info.Type.Or("") == apispec.FileInfoTypeDir,
+			Size:    info.Size.Or(-1),
+			ModTime: info.ModTime.Or(time.Time{}),
+			Mode:    parseRemoteFileMode(info.Mode.Or("")),
+		}, nil
+	default:
+		return copyPathInfo{}, nil
+	}
+}
+
+// copyPlan is where archive entries land: entries are named under Prefix and
+// extracted into DestDir.
+type copyPlan struct {
+	Prefix  string
+	DestDir string
+}
+
+// resolveCopyPlan applies docker cp semantics. Copying into an existing
+// directory keeps the source name; copying to a new or existing file path
+// renames the source; a source ending in /. or a tar stream copies contents.
+func resolveCopyPlan(src copyEndpoint, srcInfo copyPathInfo, dst copyEndpoint, dstInfo copyPathInfo) (copyPlan, error) {
+	if src.Kind != copyEndpointStream && !srcInfo.Exists {
+		return copyPlan{}, fmt.Errorf("source %s does not exist", src)
+	}
+	srcBase := src.base()
+	if dst.Kind == copyEndpointStream {
+		return copyPlan{Prefix: srcBase}, nil
+	}
+	if src.Kind == copyEndpointStream || srcBase == "" {
+		if dstInfo.Exists && !dstInfo.Dir {
+			return copyPlan{}, fmt.Errorf("destination %s must be a directory", dst)
+		}
+		return copyPlan{DestDir: dst.Path}, nil
+	}
+	if dstInfo.Exists && dstInfo.Dir {
+		return copyPlan{Prefix: srcBase, DestDir: dst.Path}, nil
+	}
+	if dstInfo.Exists && srcInfo.Dir {
+		return copyPlan{}, fmt.Errorf("cannot copy directory %s to file %s", src, dst)
+	}
+	if !dstInfo.Exists && !srcInfo.Dir && strings.HasSuffix(dst.Path, "/") {
+		return copyPlan{}, fmt.Errorf("destination directory %s does not exist", dst)
+	}
+	if dst.base() == "" {
+		return copyPlan{Prefix: srcBase, DestDir: dst.Path}, nil
+	}
+	return copyPlan{Prefix: dst.base(), DestDir: dst.dir()}, nil
+}
+
+func joinCopyArchiveName(prefix, rel string) string {
+	switch {
+	case prefix == "":
+		return rel
+	case rel == "":
+		return prefix
+	default:
+		return prefix + "/" + rel
+	}
+}
+
+// cleanCopyArchiveName normalizes a tar entry name and rejects entries that
+// would escape the destination directory. It returns "" for the archive root.
+func cleanCopyArchiveName(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimLeft(filepath.ToSlash(name), "/"))
+	if cleaned == "." {
+		return "", nil
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("archive entry %q escapes the destination", name)
+	}
+	return cleaned, nil
+}
+
+// writeLocalCopyArchive writes localPath as a tar archive with entries named under prefix.
+func writeLocalCopyArchive(w io.Writer, localPath, prefix string) error {
+	root := filepath.Clean(localPath)
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(root, func(current string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(root, current)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			rel = ""
+		}
+		name := joinCopyArchiveName(prefix, filepath.ToSlash(rel))
+		if name == "" {
+			return nil
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(current); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(current)
+		if err != nil {
+			return err
+		}
+		_, copyErr := io.Copy(tw, file)
+		closeErr := file.Close()
+		if copyErr != nil {
+			return copyErr
+		}
+		return closeErr
+	})
+	if err != nil {
+		_ = tw.Close()
+		return err
+	}
+	return tw.Close()
+}
+
+// writeRemoteCopyArchive writes a sandbox or volume path, described by info,
+// as a tar archive with entries named under prefix.
+func writeRemoteCopyArchive(ctx context.Context, w io.Writer, fs remoteFileSystem, remotePath, prefix string, info copyPathInfo) error {
+	tw := tar.NewWriter(w)
+	writeFile := func(name, filePath string, size int64, modTime time.Time, mode os.FileMode) error {
+		download, err := fs.OpenFile(ctx, filePath, 0)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filePath, err)
+		}
+		defer download.Body.Close()
+		if download.Size >= 0 {
+			size = download.Size
+		}
+		if size < 0 {
+			return fmt.Errorf("read %s: file size unknown", filePath)
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     int64(archiveMode(mode, 0644)),
+			Size:     size,
+			ModTime:  modTime,
+		}); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(tw, download.Body, size); err != nil {
+			return fmt.Errorf("read %s: %w", filePath, err)
+		}
+		return nil
+	}
+
+	if !info.Dir {
+		if err := writeFile(prefix, remotePath, info.Size, info.ModTime, info.Mode); err != nil {
+			_ = tw.Close()
+			return err
+		}
+		return tw.Close()
+	}
+
+	entries, err := scanRemoteTree(ctx, fs, remotePath)
+	if err != nil {
+		_ = tw.Close()
+		return err
+	}
+	if prefix != "" {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: prefix + "/", Mode: int64(archiveMode(info.Mode, 0755)), ModTime: info.ModTime}); err != nil {
+			_ = tw.Close()
+			return err
+		}
+	}
+	paths := make([]string, 0, len(entries))
+	for rel := range entries {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	for _, rel := range paths {
+		entry := entries[rel]
+		name := joinCopyArchiveName(prefix, rel)
+		if entry.Dir {
+			err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: int64(archiveMode(entry.Mode, 0755)), ModTime: entry.ModTime})
+		} else {
+			err = writeFile(name, path.Join(remotePath, rel), entry.Size, entry.ModTime, entry.Mode)
+		}
+		if err != nil {
+			_ = tw.Close()
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// archiveMode returns mode, or fallback when the remote mode is unknown.
+func archiveMode(mode, fallback os.FileMode) os.FileMode {
+	if mode == 0 {
+		return fallback
+	}
+	return mode
+}
+
+// extractLocalCopyArchive extracts regular files and directories from archive into destDir.
+func extractLocalCopyArchive(archive io.Reader, destDir string) (sandboxTransferSummary, error) {
+	summary := sandboxTransferSummary{}
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+		name, err := cleanCopyArchiveName(header.Name)
+		if err != nil {
+			return summary, err
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return summary, err
+			}
+			if name != "" {
+				summary.Directories++
+			}
+		case tar.TypeReg:
+			if name == "" {
+				return summary, fmt.Errorf("archive file entry %q has no name", header.Name)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return summary, err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return summary, err
+			}
+			written, copyErr := io.Copy(file, tr)
+			closeErr := file.Close()
+			if copyErr != nil {
+				return summary, copyErr
+			}
+			if closeErr != nil {
+				return summary, closeErr
+			}
+			// OpenFile only applies the mode to new files, and through the umask.
+			if err := os.Chmod(target, header.FileInfo().Mode().Perm()); err != nil {
+				return summary, err
+			}
+			_ = os.Chtimes(target, header.ModTime, header.ModTime)
+			summary.Files++
+			summary.Bytes += written
+		default:
+			summary.Skipped++
+		}
+	}
+}
+
+// extractRemoteCopyArchive extracts regular files and directories from archive
+// into destDir on a sandbox or volume.
+func extractRemoteCopyArchive(ctx context.Context, archive io.Reader, fs remoteFileSystem, destDir string) (sandboxTransferSummary, error) {
+	summary := sandboxTransferSummary{}
+	created := map[string]bool{}
+	ensureDir := func(dir string) error {
+		if created[dir] {
+			return nil
+		}
+		if _, err := fs.Mkdir(ctx, dir, true); err != nil && !isSandboxFileExists(err) {
+			return fmt.Errorf("create %s: %w", dir, err)
+		}
+		created[dir] = true
+		return nil
+	}
+
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+		name, err := cleanCopyArchiveName(header.Name)
+		if err != nil {
+			return summary, err
+		}
+		target := path.Join(destDir, name)
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := ensureDir(target); err != nil {
+				return summary, err
+			}
+			if name != "" {
+				summary.Directories++
+			}
+		case tar.TypeReg:
+			if name == "" {
+				return summary, fmt.Errorf("archive file entry %q has no name", header.Name)
+			}
+			if err := ensureDir(path.Dir(target)); err != nil {
+				return summary, err
+			}
+			if err := fs.UploadFile(ctx, target, tr, header.Size); err != nil {
+				return summary, fmt.Errorf("write %s: %w", target, err)
+			}
+			summary.Files++
+			summary.Bytes += header.Size
+		default:
+			summary.Skipped++
+		}
+	}
+}
+
+// openCopySource returns a tar stream of src with entries named under prefix.
+func openCopySource(ctx context.Context, client *sandbox0.Client, src copyEndpoint, srcInfo copyPathInfo, prefix string, stdin io.Reader) io.ReadCloser {
+	if src.Kind == copyEndpointStream {
+		return io.NopCloser(stdin)
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		var err error
+		if src.Kind == copyEndpointLocal {
+			err = writeLocalCopyArchive(pw, src.Path, prefix)
+		} else {
+			err = writeRemoteCopyArchive(ctx, pw, copyEndpointFileSystem(client, src), src.Path, prefix, srcInfo)
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// runSandboxCopy streams src to dst as a tar archive without staging it on local disk.
+func runSandboxCopy(ctx context.Context, client *sandbox0.Client, src, dst copyEndpoint, stdin io.Reader, stdout io.Writer) (sandboxTransferSummary, error) {
+	if !src.remote() && !dst.remote() {
+		return sandboxTransferSummary{}, fmt.Errorf("at least one of SRC or DST must be a sandbox or volume path")
+	}
+
+	srcInfo, err := statCopyEndpoint(ctx, client, src)
+	if err != nil {
+		return sandboxTransferSummary{}, fmt.Errorf("stat %s: %w", src, err)
+	}
+	dstInfo, err := statCopyEndpoint(ctx, client, dst)
+	if err != nil {
+		return sandboxTransferSummary{}, fmt.Errorf("stat %s: %w", dst, err)
+	}
+	plan, err := resolveCopyPlan(src, srcInfo, dst, dstInfo)
+	if err != nil {
+		return sandboxTransferSummary{}, err
+	}
+
+	archive := openCopySource(ctx, client, src, srcInfo, plan.Prefix, stdin)
+	defer archive.Close()
+
+	switch dst.Kind {
+	case copyEndpointStream:
+		written, err := io.Copy(stdout, archive)
+		return sandboxTransferSummary{Bytes: written}, err
+	case copyEndpointLocal:
+		return extractLocalCopyArchive(archive, plan.DestDir)
+	case copyEndpointVolume:
+		result, err := client.ImportVolumeArchive(ctx, dst.ID, plan.DestDir, archive)
+		if err != nil {
+			return sandboxTransferSummary{}, err
+		}
+		return sandboxTransferSummary{
+			Files:       int(result.Files),
+			Directories: int(result.Directories),
+			Bytes:       result.Bytes,
+		}, nil
+	default:
+		return extractRemoteCopyArchive(ctx, archive, copyEndpointFileSystem(client, dst), plan.DestDir)
+	}
+}
+
+// sandboxCpCmd copies files between local paths, sandboxes, and volumes.
+var sandboxCpCmd = &cobra.Command{
+	Use:   "cp <src> <dst>",
+	Short: "Copy files between local paths, sandboxes, and volumes",
+	Long: `Copy files and directories between the local filesystem, sandboxes, and volumes.
+
+Either side may be a local path, <sandbox-id>:/path, <volume-id>:/path, or - to
+read or write a tar archive on stdin/stdout. Remote-to-remote copies stream
+through the CLI without staging data on local disk.
+
+Copying into an existing directory keeps the source name; otherwise the
+destination names the copy. Use a source ending in /. to copy directory
+contents.
+
+Examples:
+  s0 sandbox cp ./src sb_abc123:/app
+  s0 sandbox cp sb_abc123:/app/out ./out
+  s0 sandbox cp vol_abc123:/datasets sb_abc123:/workspace
+  s0 sandbox cp sb_abc123:/app - | tar t
+  tar c . | s0 sandbox cp - sb_abc123:/app`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		src, err := parseCopyEndpoint(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		dst, err := parseCopyEndpoint(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := runSandboxCopy(cmd.Context(), client, src, dst, os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error copying files: %v\n", err)
+			os.Exit(1)
+		}
+		if dst.Kind == copyEndpointStream {
+			return
+		}
+		fmt.Printf(
+			"Copied %s to %s (files=%d directories=%d skipped=%d bytes=%d)\n",
+			src,
+			dst,
+			result.Files,
+			result.Directories,
+			result.Skipped,
+			result.Bytes,
+		)
+	},
+}
+
+func init() {
+	sandboxCmd.AddCommand(sandboxCpCmd)
+}
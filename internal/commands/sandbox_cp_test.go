@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func TestParseCopyEndpoint(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want copyEndpoint
+	}{
+		{arg: "-", want: copyEndpoint{Kind: copyEndpointStream}},
+		{arg: "sb_abc:/app", want: copyEndpoint{Kind: copyEndpointSandbox, ID: "sb_abc", Path: "/app"}},
+		{arg: "vol_abc:/data/x.txt", want: copyEndpoint{Kind: copyEndpointVolume, ID: "vol_abc", Path: "/data/x.txt"}},
+		{arg: "./out", want: copyEndpoint{Kind: copyEndpointLocal, Path: "./out"}},
+		{arg: "dir/with:colon", want: copyEndpoint{Kind: copyEndpointLocal, Path: "dir/with:colon"}},
+		{arg: "C:\\data", want: copyEndpoint{Kind: copyEndpointLocal, Path: "C:\\data"}},
+	}
+	for _, tt := range tests {
+		got, err := parseCopyEndpoint(tt.arg)
+		if err != nil {
+			t.Fatalf("parseCopyEndpoint(%q) error = %v", tt.arg, err)
+		}
+		if got != tt.want {
+			t.Fatalf("parseCopyEndpoint(%q) = %+v, want %+v", tt.arg, got, tt.want)
+		}
+	}
+
+	if _, err := parseCopyEndpoint("sb_abc:relative"); err == nil {
+		t.Fatal("parseCopyEndpoint() error = nil, want relative remote path error")
+	}
+}
+
+func TestResolveCopyPlan(t *testing.T) {
+	mustParse := func(arg string) copyEndpoint {
+		t.Helper()
+		endpoint, err := parseCopyEndpoint(arg)
+		if err != nil {
+			t.Fatalf("parseCopyEndpoint(%q) error = %v", arg, err)
+		}
+		return endpoint
+	}
+	file := copyPathInfo{Exists: true}
+	dir := copyPathInfo{Exists: true, Dir: true}
+	missing := copyPathInfo{}
+
+	tests := []struct {
+		name    string
+		src     string
+		srcInfo copyPathInfo
+		dst     string
+		dstInfo copyPathInfo
+		want    copyPlan
+		wantErr bool
+	}{
+		{name: "file into directory", src: "sb_a:/etc/hosts", srcInfo: file, dst: "./out", dstInfo: dir, want: copyPlan{Prefix: "hosts", DestDir: "./out"}},
+		{name: "file to new name", src: "sb_a:/etc/hosts", srcInfo: file, dst: "./hosts.bak", dstInfo: missing, want: copyPlan{Prefix: "hosts.bak", DestDir: "."}},
+		{name: "directory to new path", src: "./src", srcInfo: dir, dst: "sb_a:/app/src2", dstInfo: missing, want: copyPlan{Prefix: "src2", DestDir: "/app"}},
+		{name: "directory contents", src: "./src/.", srcInfo: dir, dst: "sb_a:/app", dstInfo: dir, want: copyPlan{DestDir: "/app"}},
+		{name: "stream into directory", src: "-", dst: "vol_a:/data", dstInfo: missing, want: copyPlan{DestDir: "/data"}},
+		{name: "to stream", src: "sb_a:/app", srcInfo: dir, dst: "-", want: copyPlan{Prefix: "app"}},
+		{name: "missing source", src: "sb_a:/nope", srcInfo: missing, dst: "./out", dstInfo: dir, wantErr: true},
+		{name: "directory onto file", src: "./src", srcInfo: dir, dst: "sb_a:/app/file", dstInfo: file, wantErr: true},
+		{name: "file into missing directory", src: "./a.txt", srcInfo: file, dst: "sb_a:/missing/", dstInfo: missing, wantErr: true},
+		{name: "stream onto file", src: "-", dst: "sb_a:/app/file", dstInfo: file, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveCopyPlan(mustParse(tt.src), tt.srcInfo, mustParse(tt.dst), tt.dstInfo)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveCopyPlan() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveCopyPlan() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("resolveCopyPlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalCopyArchiveRoundTrip(t *testing.T) {
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "nested"), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "nested", "a.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var archive bytes.Buffer
+	if err := writeLocalCopyArchive(&archive, src, "project"); err != nil {
+		t.Fatalf("writeLocalCopyArchive() error = %v", err)
+	}
+
+	dst := t.TempDir()
+	summary, err := extractLocalCopyArchive(&archive, dst)
+	if err != nil {
+		t.Fatalf("extractLocalCopyArchive() error = %v", err)
+	}
+	if summary.Files != 1 || summary.Directories != 2 || summary.Bytes != 5 {
+		t.Fatalf("summary = %+v, want 1 file, 2 directories, 5 bytes", summary)
+	}
+	data, err := os.ReadFile(filepath.Join(dst, "project", "nested", "a.txt"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("content = %q, want hello", string(data))
+	}
+}
+
+func TestExtractLocalCopyArchiveRejectsEscapes(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "../evil", Mode: 0644, Size: 1}); err != nil {
+		t.Fatalf("WriteHeader() error = %v", err)
+	}
+	if _, err := tw.Write([]byte("x")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := extractLocalCopyArchive(&archive, t.TempDir()); err == nil {
+		t.Fatal("extractLocalCopyArchive() error = nil, want escape error")
+	}
+}
+
+func TestRemoteCopyArchiveKeepsModTime(t *testing.T) {
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sandboxvolumes/vol_1/files" || r.URL.Query().Get("path") != "/data/a.txt" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	var archive bytes.Buffer
+	fs := volumeFileSystem{client: client, volumeID: "vol_1"}
+	info := copyPathInfo{Exists: true, Size: 5, ModTime: modTime}
+	if err := writeRemoteCopyArchive(context.Background(), &archive, fs, "/data/a.txt", "a.txt", info); err != nil {
+		t.Fatalf("writeRemoteCopyArchive() error = %v", err)
+	}
+
+	dst := t.TempDir()
+	if _, err := extractLocalCopyArchive(&archive, dst); err != nil {
+		t.Fatalf("extractLocalCopyArchive() error = %v", err)
+	}
+	stat, err := os.Stat(filepath.Join(dst, "a.txt"))
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if !stat.ModTime().Equal(modTime) || stat.Size() != 5 {
+		t.Fatalf("mtime = %v size = %d, want %v and 5", stat.ModTime(), stat.Size(), modTime)
+	}
+}
+
+func TestRemoteCopyArchiveKeepsExecutableBit(t *testing.T) {
+	modTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/sandboxvolumes/vol_1/files/list" && r.URL.Query().Get("path") == "/data":
+			writeTestSuccess(t, w, &apispec.SuccessFileListResponseData{Entries: []apispec.FileInfo{
+				{Name: apispec.NewOptString("run.sh"), Type: apispec.NewOptFileInfoType(apispec.FileInfoTypeFile), Size: apispec.NewOptInt64(2), Mode: apispec.NewOptString("-rwxr-xr-x"), ModTime: apispec.NewOptDateTime(modTime)},
+				{Name: apispec.NewOptString("notes.txt"), Type: apispec.NewOptFileInfoType(apispec.FileInfoTypeFile), Size: apispec.NewOptInt64(2), Mode: apispec.NewOptString("0600"), ModTime: apispec.NewOptDateTime(modTime)},
+			}})
+		case r.URL.Path == "/api/v1/sandboxvolumes/vol_1/files":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("hi"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var archive bytes.Buffer
+	fs := volumeFileSystem{client: client, volumeID: "vol_1"}
+	info := copyPathInfo{Exists: true, Dir: true, ModTime: modTime, Mode: 0750}
+	if err := writeRemoteCopyArchive(context.Background(), &archive, fs, "/data", "data", info); err != nil {
+		t.Fatalf("writeRemoteCopyArchive() error = %v", err)
+	}
+
+	dst := t.TempDir()
+	if _, err := extractLocalCopyArchive(&archive, dst); err != nil {
+		t.Fatalf("extractLocalCopyArchive() error = %v", err)
+	}
+	for name, want := range map[string]os.FileMode{"run.sh": 0755, "notes.txt": 0600} {
+		stat, err := os.Stat(filepath.Join(dst, "data", name))
+		if err != nil {
+			t.Fatalf("Stat(%s) error = %v", name, err)
+		}
+		if stat.Mode().Perm() != want {
+			t.Fatalf("%s mode = %v, want %v", name, stat.Mode().Perm(), want)
+		}
+	}
+}
+
+func TestParseRemoteFileMode(t *testing.T) {
+	for mode, want := range map[string]os.FileMode{
+		"0755":       0755,
+		"644":        0644,
+		"-rwxr-x---": 0750,
+		"drwxr-xr-x": 0755,
+		"-rwsr-xr-T": 0754,
+		"":           0,
+		"rw":         0,
+		"-rwzr-xr-x": 0,
+	} {
+		if got := parseRemoteFileMode(mode); got != want {
+			t.Fatalf("parseRemoteFileMode(%q) = %v, want %v", mode, got, want)
+		}
+	}
+}
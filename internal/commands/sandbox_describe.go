@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sandboxDescribeEvents int
+	sandboxDescribeSince  time.Duration
+)
+
+// sandboxDescribeEventPageSize bounds the events fetched to find the most
+// recent ones, since the events API does not sort newest first.
+const sandboxDescribeEventPageSize = 1000
+
+// sandboxDescribeAuditEventTypes are the event types shown as audit events.
+// The events API filters on one type per query, so each is fetched in turn.
+var sandboxDescribeAuditEventTypes = []apispec.SandboxObservabilityEventType{
+	apispec.SandboxObservabilityEventTypeAPIAccess,
+	apispec.SandboxObservabilityEventTypeNetworkAudit,
+}
+
+// sandboxDescribeCmd prints an aggregated view of a sandbox.
+var sandboxDescribeCmd = &cobra.Command{
+	Use:   "describe <sandbox-id>",
+	Short: "Show an aggregated view of a sandbox",
+	Long: `Show a sandbox's configuration, claim and expiry times, mounts, network
+policy, services, running contexts and sessions, rootfs snapshots, recent
+audit events (API access and network audit), and latest CPU and memory
+metrics in one report.
+
+The sections are fetched concurrently. A section that cannot be fetched is
+reported in place without failing the command. With -o json or -o yaml the
+report is one document, and section errors are listed under "errors".`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		description, err := describeSandbox(cmd.Context(), client, sandboxID, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting sandbox: %v\n", err)
+			os.Exit(1)
+		}
+		if err := getFormatter().Format(os.Stdout, description); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	sandboxDescribeCmd.Flags().IntVar(&sandboxDescribeEvents, "events", 10, "number of recent audit events to show")
+	sandboxDescribeCmd.Flags().DurationVar(&sandboxDescribeSince, "since", time.Hour, "window for recent events and metrics")
+	sandboxCmd.AddCommand(sandboxDescribeCmd)
+}
+
+// describeSandbox fetches every section of the sandbox description
+// concurrently. Only a failure to get the sandbox itself is returned as an
+// error; other failures are recorded in the description.
+func describeSandbox(ctx context.Context, client *sandbox0.Client, sandboxID string, now time.Time) (*output.SandboxDescription, error) {
+	sandbox := client.Sandbox(sandboxID)
+	start := now.Add(-sandboxDescribeSince)
+	d := &output.SandboxDescription{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	fetch := func(section string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if d.Errors == nil {
+					d.Errors = map[string]string{}
+				}
+				d.Errors[section] = err.Error()
+			}
+		}()
+	}
+
+	fetch("sandbox", func() (err error) {
+		d.Sandbox, err = client.GetSandbox(ctx, sandboxID)
+		return err
+	})
+	fetch("status", func() (err error) {
+		d.Status, err = client.StatusSandbox(ctx, sandboxID)
+		return err
+	})
+	fetch("network", func() (err error) {
+		d.Network, err = sandbox.GetNetworkPolicy(ctx)
+		return err
+	})
+	fetch("services", func() (err error) {
+		d.Services, err = sandbox.GetServices(ctx)
+		return err
+	})
+	fetch("contexts", func() error {
+		contexts, err := sandbox.ListContext(ctx)
+		d.Contexts = runningContexts(contexts)
+		return err
+	})
+	fetch("sessions", func() error {
+		sessions, err := sandbox.ListSessions(ctx)
+		d.Sessions = activeSessions(sessions)
+		return err
+	})
+	fetch("snapshots", func() error {
+		snapshots, err := client.ListSandboxRootFSSnapshots(ctx, sandboxID)
+		if err != nil {
+			return err
+		}
+		d.Snapshots = snapshots.Snapshots
+		return nil
+	})
+	fetch("events", func() error {
+		var events []apispec.SandboxObservabilityEvent
+		for _, eventType := range sandboxDescribeAuditEventTypes {
+			page, err := sandbox.ListObservabilityEvents(ctx, &sandbox0.SandboxObservabilityEventOptions{
+				SandboxObservabilityQueryOptions: sandbox0.SandboxObservabilityQueryOptions{StartTime: &start, EndTime: &now, Limit: sandboxDescribeEventPageSize},
+				EventType:                        eventType,
+			})
+			if err != nil {
+				return err
+			}
+			events = append(events, page.Events...)
+		}
+		d.Events = latestEvents(events, sandboxDescribeEvents)
+		return nil
+	})
+	fetch("metrics", func() (err error) {
+		d.Metrics, err = sandbox.ListMetrics(ctx, &sandbox0.SandboxObservabilityMetricOptions{
+			StartTime: &start,
+			EndTime:   &now,
+			Metrics:   []apispec.SandboxRuntimeMetricName{apispec.SandboxRuntimeMetricNameSandboxCPUUtilization, apispec.SandboxRuntimeMetricNameSandboxMemoryUsage},
+		})
+		return err
+	})
+	wg.Wait()
+
+	if msg, failed := d.Errors["sandbox"]; failed || d.Sandbox == nil {
+		return nil, fmt.Errorf("%s", msg)
+	}
+	d.Mounts = d.Sandbox.Mounts
+	if d.Mounts == nil {
+		d.Mounts = []apispec.ClaimMountRequest{}
+	}
+	return d, nil
+}
+
+func runningContexts(contexts []apispec.ContextResponse) []apispec.ContextResponse {
+	running := []apispec.ContextResponse{}
+	for _, ctx := range contexts {
+		if ctx.Running {
+			running = append(running, ctx)
+		}
+	}
+	return running
+}
+
+func activeSessions(sessions []apispec.ExecutionSession) []apispec.ExecutionSession {
+	active := []apispec.ExecutionSession{}
+	for _, session := range sessions {
+		if !slices.Contains(workspaceSessionEndedPhases, session.Phase) {
+			active = append(active, session)
+		}
+	}
+	return active
+}
+
+// latestEvents returns the n most recent events, oldest first.
+func latestEvents(events []apispec.SandboxObservabilityEvent, n int) []apispec.SandboxObservabilityEvent {
+	sorted := slices.Clone(events)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OccurredAt.Before(sorted[j].OccurredAt) })
+	if n >= 0 && len(sorted) > n {
+		sorted = sorted[len(sorted)-n:]
+	}
+	if sorted == nil {
+		sorted = []apispec.SandboxObservabilityEvent{}
+	}
+	return sorted
+}
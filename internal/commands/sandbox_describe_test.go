@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func writeTestSuccess(t *testing.T, w http.ResponseWriter, data any) {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"success":true,"data":` + string(raw) + `}`))
+}
+
+func TestDescribeSandbox(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	var eventTypes []string
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sandboxes/sb_1":
+			writeTestSuccess(t, w, &apispec.Sandbox{
+				ID: "sb_1", TemplateID: "python", TeamID: "team_1", Status: apispec.SandboxLifecycleStatusRunning,
+				Mounts:    []apispec.ClaimMountRequest{{SandboxvolumeID: "vol_1", MountPoint: "/data"}},
+				ClaimedAt: now, CreatedAt: now, UpdatedAt: now,
+			})
+		case "/api/v1/sandboxes/sb_1/status":
+			writeTestSuccess(t, w, &apispec.SandboxStatus{
+				Status:        apispec.NewOptSandboxLifecycleStatus(apispec.SandboxLifecycleStatusRunning),
+				ClaimedAt:     apispec.NewOptString("2026-01-02T11:00:00Z"),
+				ExpiresAt:     apispec.NewOptString("2026-01-02T13:00:00Z"),
+				HardExpiresAt: apispec.NewOptString("2026-01-03T11:00:00Z"),
+			})
+		case "/api/v1/sandboxes/sb_1/network":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"success":false,"error":{"code":"internal","message":"netd unavailable"}}`))
+		case "/api/v1/sandboxes/sb_1/services":
+			writeTestSuccess(t, w, map[string]any{"sandbox_id": "sb_1", "services": []any{}})
+		case "/api/v1/sandboxes/sb_1/contexts":
+			writeTestSuccess(t, w, map[string]any{"contexts": []map[string]any{
+				{"id": "ctx_run", "type": "cmd", "running": true, "paused": false, "created_at": "2026-01-02T11:00:00Z"},
+				{"id": "ctx_done", "type": "cmd", "running": false, "paused": false, "created_at": "2026-01-02T10:00:00Z"},
+			}})
+		case "/api/v1/sandboxes/sb_1/sessions":
+			writeTestSuccess(t, w, map[string]any{"sessions": []*apispec.ExecutionSession{
+				{ID: "ses_run", Spec: testSessionSpec("web", "serve"), Phase: apispec.ExecutionSessionPhaseRunning, CreatedAt: now, UpdatedAt: now},
+				{ID: "ses_done", Spec: testSessionSpec("job", "run"), Phase: apispec.ExecutionSessionPhaseExited, CreatedAt: now, UpdatedAt: now},
+			}})
+		case "/api/v1/sandboxes/sb_1/snapshots":
+			writeTestSuccess(t, w, map[string]any{"snapshots": []any{}, "count": 0})
+		case "/api/v1/sandboxes/sb_1/observability/events":
+			mu.Lock()
+			eventTypes = append(eventTypes, r.URL.Query().Get("event_type"))
+			mu.Unlock()
+			writeTestSuccess(t, w, map[string]any{"events": []any{}})
+		case "/api/v1/sandboxes/sb_1/metrics":
+			writeTestSuccess(t, w, &apispec.SandboxRuntimeMetricsResponse{StartTime: now.Add(-time.Hour), EndTime: now, StepSeconds: 60,
+				Freshness: apispec.SandboxRuntimeMetricFreshness{Status: apispec.SandboxRuntimeMetricFreshnessStatusFresh},
+				Series: []apispec.SandboxRuntimeMetricSeries{
+					{
+						Metric: apispec.SandboxRuntimeMetricNameSandboxMemoryUsage, Kind: apispec.SandboxRuntimeMetricKindGauge,
+						Unit: apispec.SandboxRuntimeMetricUnitBytes, Statistic: apispec.SandboxRuntimeMetricStatisticAverage,
+						Segments: []apispec.SandboxRuntimeMetricSegment{{Points: []apispec.SandboxRuntimeMetricPoint{
+							{Time: now.Add(-2 * time.Minute), Value: 1 << 20},
+							{Time: now.Add(-time.Minute), Value: 3 << 20},
+						}}},
+					},
+				}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	d, err := describeSandbox(context.Background(), client, "sb_1", now)
+	if err != nil {
+		t.Fatalf("describeSandbox() error = %v", err)
+	}
+	if d.Sandbox.ID != "sb_1" || len(d.Mounts) != 1 || d.Mounts[0].MountPoint != "/data" {
+		t.Fatalf("sandbox = %+v, mounts = %+v", d.Sandbox, d.Mounts)
+	}
+	if d.Status == nil || d.Status.ExpiresAt.Value != "2026-01-02T13:00:00Z" {
+		t.Fatalf("status = %+v, want expiry times", d.Status)
+	}
+	if strings.Join(eventTypes, ",") != "api_access,network_audit" {
+		t.Fatalf("event types = %v, want only api_access and network_audit", eventTypes)
+	}
+	if len(d.Contexts) != 1 || d.Contexts[0].ID != "ctx_run" || len(d.Sessions) != 1 || d.Sessions[0].ID != "ses_run" {
+		t.Fatalf("contexts = %+v, sessions = %+v, errors = %v", d.Contexts, d.Sessions, d.Errors)
+	}
+	if d.Network != nil || !strings.Contains(d.Errors["network"], "netd unavailable") || len(d.Errors) != 1 {
+		t.Fatalf("network = %+v, errors = %v", d.Network, d.Errors)
+	}
+
+	var table bytes.Buffer
+	if err := output.NewFormatter(output.FormatTable).Format(&table, d); err != nil {
+		t.Fatalf("Format(table) error = %v", err)
+	}
+	for _, want := range []string{"Sandbox:", "Claimed At:", "Hard Expires At:", "Mounts:", "vol_1", "Network Policy:\nError:", "netd unavailable", "ctx_run", "ses_run", "Latest Metrics:", "3.0 MiB"} {
+		if !strings.Contains(table.String(), want) {
+			t.Fatalf("table output missing %q:\n%s", want, table.String())
+		}
+	}
+	if strings.Contains(table.String(), "STATE") {
+		t.Fatalf("table output should list mounts without a state column:\n%s", table.String())
+	}
+	if strings.Contains(table.String(), "ctx_done") || strings.Contains(table.String(), "1.0 MiB") {
+		t.Fatalf("table output should only show running contexts and latest metrics:\n%s", table.String())
+	}
+
+	var document bytes.Buffer
+	if err := output.NewFormatter(output.FormatJSON).Format(&document, d); err != nil {
+		t.Fatalf("Format(json) error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(document.Bytes(), &decoded); err != nil {
+		t.Fatalf("json output is not one document: %v", err)
+	}
+	for _, key := range []string{"sandbox", "status", "mounts", "services", "contexts", "sessions", "snapshots", "events", "metrics", "errors"} {
+		if _, ok := decoded[key]; !ok {
+			t.Fatalf("json output missing %q: %s", key, document.String())
+		}
+	}
+}
+
+func TestLatestEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []apispec.SandboxObservabilityEvent{
+		{Action: "c", OccurredAt: base.Add(3 * time.Minute)},
+		{Action: "a", OccurredAt: base.Add(time.Minute)},
+		{Action: "b", OccurredAt: base.Add(2 * time.Minute)},
+	}
+	got := latestEvents(events, 2)
+	if len(got) != 2 || got[0].Action != "b" || got[1].Action != "c" {
+		t.Fatalf("latestEvents() = %+v, want b, c", got)
+	}
+	if got := latestEvents(nil, 5); got == nil || len(got) != 0 {
+		t.Fatalf("latestEvents(nil) = %#v, want empty slice", got)
+	}
+}
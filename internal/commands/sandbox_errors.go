@@ -1,10 +1,33 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"time"
 
 	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/spf13/cobra"
+)
+
+// defaultRetryThrottledDuration is the retry budget of a bare --retry-throttled.
+const defaultRetryThrottledDuration = 10 * time.Minute
+
+var (
+	sandboxRetryThrottled time.Duration
+
+	// throttleRetrySleep waits between throttled claim attempts.
+	throttleRetrySleep = func(ctx context.Context, d time.Duration) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	}
 )
 
 func formatSandboxCreateError(err error) string {
@@ -29,3 +52,52 @@ func pluralizeSecond(seconds int) string {
 	}
 	return "seconds"
 }
+
+// isNotFoundError reports whether err is an API 404 response.
+func isNotFoundError(err error) bool {
+	var apiErr *sandbox0.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// addSandboxRetryThrottledFlag registers --retry-throttled[=max-duration].
+func addSandboxRetryThrottledFlag(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&sandboxRetryThrottled, "retry-throttled", 0, "retry throttled sandbox claim/start requests for up to this long")
+	cmd.Flags().Lookup("retry-throttled").NoOptDefVal = defaultRetryThrottledDuration.String()
+}
+
+// retryClaimStartThrottled runs fn and retries it while it fails with a
+// claim/start throttled error, sleeping for the server-advised Retry-After
+// interval plus jitter. Retries stop once maxDuration has elapsed; a zero
+// maxDuration disables retries.
+func retryClaimStartThrottled(ctx context.Context, maxDuration time.Duration, what string, fn func() error) error {
+	deadline := time.Now().Add(maxDuration)
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || maxDuration <= 0 || !sandbox0.IsClaimStartThrottled(err) {
+			return err
+		}
+
+		delay := throttleRetryDelay(err, attempt)
+		remaining := time.Until(deadline)
+		if delay > remaining {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "%s throttled; retrying in %s (attempt %d, %s left)\n",
+			what, delay.Round(100*time.Millisecond), attempt, remaining.Round(time.Second))
+		if err := throttleRetrySleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// throttleRetryDelay returns the Retry-After interval of err, or an
+// exponential backoff when the server did not advise one, plus up to 20%
+// jitter so concurrent clients do not retry in lockstep.
+func throttleRetryDelay(err error, attempt int) time.Duration {
+	delay := min(time.Second<<min(attempt-1, 5), 30*time.Second)
+	var apiErr *sandbox0.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfterSeconds > 0 {
+		delay = time.Duration(apiErr.RetryAfterSeconds) * time.Second
+	}
+	return delay + rand.N(delay/5+1)
+}
@@ -7,7 +7,6 @@ import (
 	"io"
 	"net"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strings"
@@ -27,6 +26,7 @@ var (
 	execStream      bool
 	execInteractive bool
 	execTTY         bool
+	execNoTTY       bool
 )
 
 type execWSMessage struct {
@@ -57,12 +57,25 @@ var sandboxExecCmd = &cobra.Command{
 
 The command must be preceded by '--' to separate it from flags.
 
+With --selector, or '-' in place of the sandbox ID to read sandbox IDs from
+stdin, the command runs on many sandboxes, --parallel at a time. Each
+sandbox's output is streamed line by line as it arrives, prefixed by the
+sandbox ID, followed by a table of results, exit codes, and durations.
+
 Examples:
   s0 sandbox exec sb_abc123 -- echo "Hello"
   s0 sandbox exec sb_abc123 --cwd /app -- python script.py
-  s0 sandbox exec sb_abc123 -it -- bash`,
-	Args: cobra.MinimumNArgs(2),
+  s0 sandbox exec sb_abc123 -it -- bash
+  tar c . | s0 sandbox exec sb_abc123 -i --no-tty -- tar x -C /app
+  s0 sandbox exec --selector template-id=python,status=running --parallel 16 -- uptime
+  s0 sandbox list -o name | s0 sandbox exec - -- df -h /`,
+	Args: execTargetArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		if isFleetExec(args) {
+			runFleetExecCommand(cmd, args)
+			return
+		}
+
 		sandboxID := args[0]
 		command := extractExecCommand(args)
 		if len(command) == 0 {
@@ -87,8 +100,17 @@ Examples:
 			os.Exit(1)
 		}
 
+		if execNoTTY && (execTTY || !execInteractive) {
+			fmt.Fprintln(os.Stderr, "Error: --no-tty requires -i and cannot be used with -t")
+			os.Exit(1)
+		}
+
 		if shouldUseStreamingExec(command) {
-			if err := runStreamingExec(cmd.Context(), client, sandboxID, command, envMap); err != nil {
+			run := runStreamingExec
+			if execNoTTY {
+				run = runPipedExec
+			}
+			if err := run(cmd.Context(), client, sandboxID, command, envMap); err != nil {
 				var exitErr *execExitCodeError
 				if errors.As(err, &exitErr) {
 					os.Exit(exitErr.code)
@@ -212,9 +234,12 @@ func isInteractiveCommand(command []string) bool {
 }
 
 func runStreamingExec(ctx context.Context, client *sandbox0.Client, sandboxID string, command []string, envMap map[string]string) error {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	return runStreamingExecWithInput(ctx, client, sandboxID, command, envMap, os.Stdin)
+}
 
+// runStreamingExecWithInput is runStreamingExec reading interactive input from
+// in instead of stdin, for callers that already own the stdin reader.
+func runStreamingExecWithInput(ctx context.Context, client *sandbox0.Client, sandboxID string, command []string, envMap map[string]string, in io.Reader) error {
 	allocateTTY := execTTY || execInteractive || shouldAutoInteractiveExec(command)
 
 	req := apispec.CreateContextRequest{
@@ -256,7 +281,32 @@ func runStreamingExec(ctx context.Context, client *sandbox0.Client, sandboxID st
 		_ = conn.Close()
 	}()
 
-	restoreTerminal, err := prepareTerminalForStreaming(allocateTTY)
+	return streamContextWS(ctx, conn, in, os.Stdout, os.Stderr, contextStreamOptions{
+		tty:     allocateTTY,
+		input:   execInteractive || shouldAutoInteractiveExec(command),
+		signals: true,
+	})
+}
+
+// contextStreamOptions chooses what streamContextWS forwards from the local
+// terminal to the context process.
+type contextStreamOptions struct {
+	// tty puts stdin in raw mode and forwards terminal size changes.
+	tty bool
+	// input forwards stdin; EOF closes the connection.
+	input bool
+	// signals forwards interrupt and terminate signals.
+	signals bool
+}
+
+// streamContextWS copies the output of a context's WebSocket to stdout and
+// stderr until its terminal done message or the connection closes. A
+// non-zero exit code is returned as *execExitCodeError.
+func streamContextWS(ctx context.Context, conn *websocket.Conn, in io.Reader, stdout, stderr io.Writer, opts contextStreamOptions) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	restoreTerminal, err := prepareTerminalForStreaming(opts.tty)
 	if err != nil {
 		return err
 	}
@@ -267,12 +317,13 @@ func runStreamingExec(ctx context.Context, client *sandbox0.Client, sandboxID st
 		return conn.WriteControl(messageType, data, time.Now().Add(time.Second))
 	}
 
-	if allocateTTY && isTerminalFile(os.Stdin) {
+	if opts.tty && isTerminalFile(os.Stdin) {
 		go forwardResizeEvents(ctx, writeJSON)
 	}
-	go forwardSignals(ctx, writeJSON)
-	streamInput := execInteractive || shouldAutoInteractiveExec(command)
-	go forwardInput(ctx, cancel, writeJSON, writeControl, streamInput)
+	if opts.signals {
+		go forwardSignals(ctx, writeJSON)
+	}
+	go forwardInput(ctx, cancel, in, writeJSON, writeControl, opts.input)
 
 	for {
 		var msg execWSMessage
@@ -294,11 +345,11 @@ func runStreamingExec(ctx context.Context, client *sandbox0.Client, sandboxID st
 		}
 		switch msg.Source {
 		case "stderr":
-			if _, err := io.WriteString(os.Stderr, msg.Data); err != nil {
+			if _, err := io.WriteString(stderr, msg.Data); err != nil {
 				return err
 			}
 		default:
-			if _, err := io.WriteString(os.Stdout, msg.Data); err != nil {
+			if _, err := io.WriteString(stdout, msg.Data); err != nil {
 				return err
 			}
 		}
@@ -312,43 +363,14 @@ func isTerminalDoneExecMessage(msg execWSMessage) bool {
 	return msg.RequestID == "" || msg.ExitCode != nil || msg.State != ""
 }
 
-func prepareTerminalForStreaming(allocateTTY bool) (func(), error) {
-	if !allocateTTY || !isTerminalFile(os.Stdin) {
-		return func() {}, nil
-	}
-
-	stateCmd := exec.Command("stty", "-g")
-	stateCmd.Stdin = os.Stdin
-	state, err := stateCmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("capture terminal state: %w", err)
-	}
-
-	rawCmd := exec.Command("stty", "raw", "-echo")
-	rawCmd.Stdin = os.Stdin
-	if err := rawCmd.Run(); err != nil {
-		return nil, fmt.Errorf("enable raw terminal mode: %w", err)
-	}
-
-	restore := strings.TrimSpace(string(state))
-	return func() {
-		if restore == "" {
-			return
-		}
-		restoreCmd := exec.Command("stty", restore)
-		restoreCmd.Stdin = os.Stdin
-		_ = restoreCmd.Run()
-	}, nil
-}
-
-func forwardInput(ctx context.Context, cancel context.CancelFunc, writeJSON func(execWSMessage) error, writeControl func(int, []byte) error, streamInput bool) {
+func forwardInput(ctx context.Context, cancel context.CancelFunc, in io.Reader, writeJSON func(execWSMessage) error, writeControl func(int, []byte) error, streamInput bool) {
 	if !streamInput {
 		return
 	}
 
 	buf := make([]byte, 4096)
 	for {
-		n, err := os.Stdin.Read(buf)
+		n, err := in.Read(buf)
 		if n > 0 {
 			if writeErr := writeJSON(execWSMessage{
 				Type: "input",
@@ -425,26 +447,6 @@ func forwardSignals(ctx context.Context, writeJSON func(execWSMessage) error) {
 	}
 }
 
-func currentTerminalSize() (int32, int32) {
-	if !isTerminalFile(os.Stdin) {
-		return 24, 80
-	}
-	sizeCmd := exec.Command("stty", "size")
-	sizeCmd.Stdin = os.Stdin
-	out, err := sizeCmd.Output()
-	if err != nil {
-		return 24, 80
-	}
-	var rows, cols int32
-	if _, err := fmt.Sscanf(strings.TrimSpace(string(out)), "%d %d", &rows, &cols); err != nil {
-		return 24, 80
-	}
-	if rows <= 0 || cols <= 0 {
-		return 24, 80
-	}
-	return rows, cols
-}
-
 func isTerminalFile(file *os.File) bool {
 	if file == nil {
 		return false
@@ -486,8 +488,9 @@ func init() {
 	sandboxExecCmd.Flags().BoolVar(&execNoWait, "no-wait", false, "don't wait for command completion")
 	sandboxExecCmd.Flags().Int32Var(&execTTL, "ttl", 0, "context TTL in seconds")
 	sandboxExecCmd.Flags().BoolVar(&execStream, "stream", false, "stream stdout/stderr without allocating a TTY")
-	sandboxExecCmd.Flags().BoolVarP(&execInteractive, "interactive", "i", false, "keep stdin attached and stream exec I/O (implies TTY)")
+	sandboxExecCmd.Flags().BoolVarP(&execInteractive, "interactive", "i", false, "keep stdin attached and stream exec I/O (implies TTY unless --no-tty)")
 	sandboxExecCmd.Flags().BoolVarP(&execTTY, "tty", "t", false, "allocate a TTY and stream exec I/O")
+	sandboxExecCmd.Flags().BoolVar(&execNoTTY, "no-tty", false, "with -i, pipe stdin to the command without a TTY (binary-safe, EOF is forwarded)")
 
 	sandboxCmd.AddCommand(sandboxExecCmd)
 }
@@ -0,0 +1,392 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execSelector string
+	execParallel int
+	execFailFast bool
+)
+
+// fleetExecStdinTarget is the sandbox ID argument that reads the target IDs
+// from stdin.
+const fleetExecStdinTarget = "-"
+
+// isFleetExec reports whether exec targets many sandboxes.
+func isFleetExec(args []string) bool {
+	return execSelector != "" || (len(args) > 0 && args[0] == fleetExecStdinTarget)
+}
+
+// execTargetArgs accepts a sandbox ID (or "-") followed by the command, or
+// only the command when --selector chooses the sandboxes.
+func execTargetArgs(cmd *cobra.Command, args []string) error {
+	if execSelector == "" {
+		for _, name := range []string{"parallel", "fail-fast"} {
+			if cmd.Flags().Changed(name) && (len(args) == 0 || args[0] != fleetExecStdinTarget) {
+				return fmt.Errorf("--%s requires --selector or '-' to read sandbox IDs from stdin", name)
+			}
+		}
+		return cobra.MinimumNArgs(2)(cmd, args)
+	}
+	if cmd.ArgsLenAtDash() > 0 {
+		return fmt.Errorf("a sandbox ID cannot be combined with --selector")
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
+// runFleetExecCommand runs the exec command on every sandbox chosen by
+// --selector or listed on stdin, and exits non-zero unless it succeeded
+// everywhere.
+func runFleetExecCommand(cmd *cobra.Command, args []string) {
+	if execStream || execInteractive || execTTY || execNoTTY || execNoWait {
+		fmt.Fprintln(os.Stderr, "Error: running on many sandboxes cannot be combined with --stream, -i, -t, --no-tty, or --no-wait")
+		os.Exit(1)
+	}
+	if execParallel < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --parallel must be greater than 0")
+		os.Exit(1)
+	}
+	command := args
+	if execSelector == "" {
+		command = extractExecCommand(args)
+	}
+	if len(command) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: command is required (use '--' followed by command)")
+		os.Exit(1)
+	}
+	envMap, err := parseExecEnvVars(execEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	client, err := getClientRaw(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		os.Exit(1)
+	}
+
+	var ids []string
+	if execSelector != "" {
+		opts, err := parseSandboxSelector(execSelector)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		selected, err := selectSandboxes(cmd.Context(), client, opts, time.Time{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing sandboxes: %v\n", err)
+			os.Exit(1)
+		}
+		for _, sandbox := range selected {
+			ids = append(ids, sandbox.ID)
+		}
+	} else {
+		ids, err = readSandboxIDs(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading sandbox IDs: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(os.Stderr, "No sandboxes matched the selector.")
+		return
+	}
+
+	opts := []sandbox0.CmdOption{sandbox0.WithCommand(command)}
+	if execCwd != "" {
+		opts = append(opts, sandbox0.WithCmdCWD(execCwd))
+	}
+	if len(envMap) > 0 {
+		opts = append(opts, sandbox0.WithCmdEnvVars(envMap))
+	}
+	if execTTL > 0 {
+		opts = append(opts, sandbox0.WithCmdTTL(execTTL))
+	}
+	cmdStr := strings.Join(command, " ")
+	run := func(ctx context.Context, sandboxID string, stdout, stderr io.Writer) (sandbox0.CmdResult, error) {
+		return streamFleetCmd(ctx, client.Sandbox(sandboxID), cmdStr, opts, stdout, stderr)
+	}
+
+	// Structured output prints one aggregate document with each sandbox's
+	// output instead of streaming prefixed lines.
+	var printer *fleetOutputPrinter
+	if !isStructuredOutput() {
+		printer = newFleetOutputPrinter(os.Stdout, os.Stderr)
+	}
+	results := runFleetExec(cmd.Context(), ids, execParallel, execFailFast, run, printer)
+
+	if !isStructuredOutput() {
+		fmt.Fprintln(os.Stdout)
+	}
+	if err := getFormatter().Format(os.Stdout, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+		os.Exit(1)
+	}
+	succeeded := 0
+	for _, result := range results {
+		if result.Result == "succeeded" {
+			succeeded++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Succeeded on %d of %d %s\n", succeeded, len(results), pluralizeSandbox(len(results)))
+	if succeeded < len(results) {
+		os.Exit(1)
+	}
+}
+
+// parseSandboxSelector parses a comma-separated list of key=value filters,
+// such as template-id=python,status=running, into list options.
+func parseSandboxSelector(selector string) (sandbox0.ListSandboxesOptions, error) {
+	limit := 100
+	opts := sandbox0.ListSandboxesOptions{Limit: &limit}
+	for _, term := range strings.Split(selector, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(term), "=")
+		if !ok || value == "" {
+			return opts, fmt.Errorf("invalid selector %q, expected key=value", term)
+		}
+		switch key {
+		case "template-id":
+			opts.TemplateID = value
+		case "status":
+			opts.Status = value
+		case "paused":
+			if value != "true" && value != "false" {
+				return opts, fmt.Errorf("invalid selector paused=%q, expected true or false", value)
+			}
+			paused := value == "true"
+			opts.Paused = &paused
+		default:
+			return opts, fmt.Errorf("unknown selector key %q, expected template-id, status, or paused", key)
+		}
+	}
+	return opts, nil
+}
+
+// readSandboxIDs reads whitespace-separated sandbox IDs, dropping
+// duplicates.
+func readSandboxIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		if id := scanner.Text(); !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// fleetCmdFunc runs the command on one sandbox, writing its output to
+// stdout and stderr as it arrives.
+type fleetCmdFunc func(ctx context.Context, sandboxID string, stdout, stderr io.Writer) (sandbox0.CmdResult, error)
+
+// runFleetExec runs the command on every sandbox with at most parallel runs
+// in flight and returns the results in input order. printer, when set,
+// receives each sandbox's output while it runs. With failFast, the first
+// failure cancels the runs in flight and skips the sandboxes not started yet.
+func runFleetExec(ctx context.Context, ids []string, parallel int, failFast bool, run fleetCmdFunc, printer *fleetOutputPrinter) output.SandboxExecResults {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := runBounded(ctx, ids, parallel, func(ctx context.Context, id string) output.SandboxExecResult {
+		var out *fleetSandboxOutput
+		var stdout, stderr io.Writer = io.Discard, io.Discard
+		if printer != nil {
+			out = printer.sandbox(id)
+			stdout, stderr = out.stdout, out.stderr
+		}
+		start := time.Now()
+		cmdResult, err := run(ctx, id, stdout, stderr)
+		result := fleetExecResult(id, cmdResult, err, time.Since(start))
+		if out != nil {
+			out.finish(result)
+		}
+		if result.Result != "succeeded" && failFast {
+			cancel()
+		}
+		return result
+	})
+	for i, result := range results {
+		if result.ID == "" {
+			results[i] = output.SandboxExecResult{ID: ids[i], Result: "skipped"}
+		}
+	}
+	return results
+}
+
+// streamFleetCmd runs the command in a CMD context and copies its output to
+// stdout and stderr as it arrives. The returned result also carries the
+// collected output for structured formats.
+func streamFleetCmd(ctx context.Context, sandbox *sandbox0.Sandbox, cmd string, opts []sandbox0.CmdOption, stdout, stderr io.Writer) (sandbox0.CmdResult, error) {
+	stream, err := sandbox.CmdStream(ctx, cmd, opts...)
+	if err != nil {
+		return sandbox0.CmdResult{}, err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+	// Closing the connection unblocks Recv when the run is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = stream.Close() })
+	defer stop()
+
+	result := sandbox0.CmdResult{SandboxID: stream.SandboxID, ContextID: stream.ContextID}
+	var collectedStdout, collectedStderr strings.Builder
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			return result, err
+		}
+		w, collected := stdout, &collectedStdout
+		if msg.Source == "stderr" {
+			w, collected = stderr, &collectedStderr
+		}
+		collected.WriteString(msg.Data)
+		if _, err := io.WriteString(w, msg.Data); err != nil {
+			return result, err
+		}
+	}
+	result.Stdout, result.Stderr = collectedStdout.String(), collectedStderr.String()
+	if done, ok := stream.Result(); ok {
+		result.ExitCode, result.State = done.ExitCode, done.State
+		return result, nil
+	}
+	// The connection may close without a done message once the process
+	// exits; the context then reports the exit code.
+	contextResp, err := sandbox.GetContext(ctx, stream.ContextID)
+	if err != nil {
+		return result, err
+	}
+	if code, ok := contextResp.ExitCode.Get(); ok {
+		exitCode := int(code)
+		result.ExitCode = &exitCode
+	}
+	return result, nil
+}
+
+func fleetExecResult(id string, cmdResult sandbox0.CmdResult, err error, elapsed time.Duration) output.SandboxExecResult {
+	result := output.SandboxExecResult{ID: id, Result: "succeeded", DurationMS: elapsed.Milliseconds()}
+	if err != nil {
+		result.Result = "error"
+		if errors.Is(err, context.Canceled) {
+			result.Result = "cancelled"
+		}
+		result.Error = err.Error()
+		return result
+	}
+	result.ExitCode = cmdResult.ExitCode
+	result.Stdout, result.Stderr = cmdResult.Stdout, cmdResult.Stderr
+	if result.Stdout == "" && result.Stderr == "" {
+		result.Stdout = cmdResult.OutputRaw
+	}
+	if code, failed := remoteExecFailureCode(cmdResult.ExitCode); failed {
+		result.Result = "failed"
+		result.Error = fmt.Sprintf("exited with code %d", code)
+	}
+	return result
+}
+
+// fleetOutputPrinter writes the output of many sandboxes to one stdout and
+// stderr, prefixing every line with the sandbox ID. Whole lines are written
+// at a time so output from different sandboxes never interleaves mid-line.
+type fleetOutputPrinter struct {
+	mu     sync.Mutex
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func newFleetOutputPrinter(stdout, stderr io.Writer) *fleetOutputPrinter {
+	return &fleetOutputPrinter{stdout: stdout, stderr: stderr}
+}
+
+// sandbox returns the writers for one sandbox's output.
+func (p *fleetOutputPrinter) sandbox(id string) *fleetSandboxOutput {
+	prefix := "[" + id + "] "
+	return &fleetSandboxOutput{
+		stdout: &prefixedLineWriter{mu: &p.mu, w: p.stdout, prefix: prefix},
+		stderr: &prefixedLineWriter{mu: &p.mu, w: p.stderr, prefix: prefix},
+	}
+}
+
+// fleetSandboxOutput is the prefixed stdout and stderr of one sandbox.
+type fleetSandboxOutput struct {
+	stdout *prefixedLineWriter
+	stderr *prefixedLineWriter
+}
+
+// finish writes any unterminated last lines and, when the command could not
+// run, the error.
+func (o *fleetSandboxOutput) finish(result output.SandboxExecResult) {
+	o.stdout.Flush()
+	o.stderr.Flush()
+	if result.Error != "" && result.ExitCode == nil {
+		_, _ = io.WriteString(o.stderr, "error: "+result.Error+"\n")
+	}
+}
+
+// prefixedLineWriter buffers partial lines and writes each complete line to
+// w with prefix, holding mu while it writes.
+type prefixedLineWriter struct {
+	mu      *sync.Mutex
+	w       io.Writer
+	prefix  string
+	partial []byte
+}
+
+func (w *prefixedLineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.partial[:i]); err != nil {
+			return len(p), err
+		}
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes a last line that did not end in a newline.
+func (w *prefixedLineWriter) Flush() {
+	if len(w.partial) > 0 {
+		_ = w.writeLine(w.partial)
+		w.partial = nil
+	}
+}
+
+func (w *prefixedLineWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.w, "%s%s\n", w.prefix, line)
+	return err
+}
+
+func init() {
+	sandboxExecCmd.Flags().StringVar(&execSelector, "selector", "", "run on every sandbox matching key=value filters (template-id, status, paused), comma-separated")
+	sandboxExecCmd.Flags().IntVar(&execParallel, "parallel", 8, "number of sandboxes to run on in parallel with --selector or '-'")
+	sandboxExecCmd.Flags().BoolVar(&execFailFast, "fail-fast", false, "stop starting new runs and cancel running ones after the first failure")
+}
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+)
+
+func TestParseSandboxSelector(t *testing.T) {
+	opts, err := parseSandboxSelector("template-id=python, status=running,paused=false")
+	if err != nil {
+		t.Fatalf("parseSandboxSelector() error = %v", err)
+	}
+	if opts.TemplateID != "python" || opts.Status != "running" || opts.Paused == nil || *opts.Paused {
+		t.Fatalf("opts = %+v", opts)
+	}
+	for _, selector := range []string{"template-id", "owner=me", "paused=maybe", "status="} {
+		if _, err := parseSandboxSelector(selector); err == nil {
+			t.Fatalf("parseSandboxSelector(%q) error = nil", selector)
+		}
+	}
+}
+
+func TestReadSandboxIDs(t *testing.T) {
+	ids, err := readSandboxIDs(strings.NewReader("sb_1\nsb_2 sb_1\n\n  sb_3\n"))
+	if err != nil {
+		t.Fatalf("readSandboxIDs() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "sb_1,sb_2,sb_3" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestRunFleetExec(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	run := func(ctx context.Context, sandboxID string, stdout, stderr io.Writer) (sandbox0.CmdResult, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		switch sandboxID {
+		case "sb_fail":
+			code := 2
+			_, _ = io.WriteString(stderr, "boom\n")
+			return sandbox0.CmdResult{Stderr: "boom\n", ExitCode: &code}, nil
+		case "sb_err":
+			return sandbox0.CmdResult{}, errors.New("sandbox not found")
+		}
+		code := 0
+		_, _ = io.WriteString(stdout, "up "+sandboxID+"\n")
+		return sandbox0.CmdResult{Stdout: "up " + sandboxID + "\n", ExitCode: &code}, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	ids := []string{"sb_1", "sb_fail", "sb_2", "sb_err", "sb_3"}
+	results := runFleetExec(context.Background(), ids, 2, false, run, newFleetOutputPrinter(&stdout, &stderr))
+
+	if peak.Load() > 2 {
+		t.Fatalf("peak parallel runs = %d, want at most 2", peak.Load())
+	}
+	var got []string
+	for _, result := range results {
+		got = append(got, result.ID+"="+result.Result)
+	}
+	if strings.Join(got, " ") != "sb_1=succeeded sb_fail=failed sb_2=succeeded sb_err=error sb_3=succeeded" {
+		t.Fatalf("results = %v", got)
+	}
+	if results[1].ExitCode == nil || *results[1].ExitCode != 2 {
+		t.Fatalf("sb_fail exit code = %v, want 2", results[1].ExitCode)
+	}
+	if !strings.Contains(stdout.String(), "[sb_2] up sb_2\n") || !strings.Contains(stderr.String(), "[sb_fail] boom\n") ||
+		!strings.Contains(stderr.String(), "[sb_err] error: sandbox not found\n") {
+		t.Fatalf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	var table bytes.Buffer
+	if err := output.NewFormatter(output.FormatTable).Format(&table, results); err != nil {
+		t.Fatalf("Format(table) error = %v", err)
+	}
+	for _, want := range []string{"EXIT CODE", "DURATION", "sb_fail", "exited with code 2"} {
+		if !strings.Contains(table.String(), want) {
+			t.Fatalf("table missing %q:\n%s", want, table.String())
+		}
+	}
+}
+
+func TestRunFleetExecFailFast(t *testing.T) {
+	run := func(ctx context.Context, sandboxID string, _, _ io.Writer) (sandbox0.CmdResult, error) {
+		if sandboxID == "sb_fail" {
+			code := 1
+			return sandbox0.CmdResult{ExitCode: &code}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return sandbox0.CmdResult{}, ctx.Err()
+		case <-time.After(5 * time.Second):
+			code := 0
+			return sandbox0.CmdResult{ExitCode: &code}, nil
+		}
+	}
+
+	results := runFleetExec(context.Background(), []string{"sb_slow", "sb_fail", "sb_later"}, 2, true, run, nil)
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Result)
+	}
+	if strings.Join(got, ",") != "cancelled,failed,skipped" {
+		t.Fatalf("results = %v, want cancelled,failed,skipped", got)
+	}
+}
+
+func TestRunFleetExecStreamsPrefixedLines(t *testing.T) {
+	var stdout syncBuffer
+	firstLine := make(chan struct{})
+	release := make(chan struct{})
+	run := func(_ context.Context, _ string, w, _ io.Writer) (sandbox0.CmdResult, error) {
+		_, _ = io.WriteString(w, "first\nsec")
+		close(firstLine)
+		<-release
+		_, _ = io.WriteString(w, "ond")
+		code := 0
+		return sandbox0.CmdResult{ExitCode: &code}, nil
+	}
+
+	done := make(chan output.SandboxExecResults)
+	go func() {
+		done <- runFleetExec(context.Background(), []string{"sb_1"}, 1, false, run, newFleetOutputPrinter(&stdout, io.Discard))
+	}()
+	<-firstLine
+	if got := stdout.String(); got != "[sb_1] first\n" {
+		t.Fatalf("stdout while running = %q, want only the complete first line", got)
+	}
+	close(release)
+	<-done
+	if got := stdout.String(); got != "[sb_1] first\n[sb_1] second\n" {
+		t.Fatalf("stdout = %q, want the unterminated last line flushed", got)
+	}
+}
+
+func TestStreamFleetCmd(t *testing.T) {
+	code := 2
+	upgrader := websocket.Upgrader{}
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sandboxes/sb_1/contexts":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":"ctx_1","type":"cmd","running":true,"paused":false,"created_at":"2026-01-01T00:00:00Z"}}`))
+		case "/api/v1/sandboxes/sb_1/contexts/ctx_1/ws":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("upgrade: %v", err)
+				return
+			}
+			defer conn.Close()
+			for _, msg := range []execWSMessage{
+				{Type: "output", Source: "stdout", Data: "hello\n"},
+				{Type: "output", Source: "stderr", Data: "warn\n"},
+				{Type: "done", ExitCode: &code},
+			} {
+				_ = conn.WriteJSON(msg)
+			}
+			_, _, _ = conn.ReadMessage()
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var stdout, stderr bytes.Buffer
+	result, err := streamFleetCmd(context.Background(), client.Sandbox("sb_1"), "make test", []sandbox0.CmdOption{sandbox0.WithCommand([]string{"make", "test"})}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("streamFleetCmd() error = %v", err)
+	}
+	if stdout.String() != "hello\n" || stderr.String() != "warn\n" {
+		t.Fatalf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if result.Stdout != "hello\n" || result.Stderr != "warn\n" || result.ExitCode == nil || *result.ExitCode != 2 {
+		t.Fatalf("result = %+v, want collected output and exit code 2", result)
+	}
+}
+
+// syncBuffer is a bytes.Buffer that can be read while another goroutine
+// writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// execPipeChunkSize is the largest stdin chunk sent in one input message.
+const execPipeChunkSize = 32 << 10
+
+// execPipeWindow is the number of input chunks that may be awaiting an
+// acknowledgement. Reading stdin pauses while the window is full, so a slow
+// remote reader slows down the local writer instead of buffering without
+// bound.
+const execPipeWindow = 8
+
+// sessionPipeConn is the part of a session WebSocket attachment used to pipe
+// stdin and output.
+type sessionPipeConn interface {
+	Send(sandbox0.SessionWebSocketRequest) error
+	Recv() (*sandbox0.SessionWebSocketMessage, error)
+}
+
+// runPipedExec runs command as a short-lived execution session without a
+// TTY, piping stdin to it with binary-safe framing and an explicit EOF. The
+// context WebSocket used by streaming exec carries input as JSON strings and
+// has no EOF, so it cannot carry tar streams or database dumps.
+func runPipedExec(ctx context.Context, client *sandbox0.Client, sandboxID string, command []string, envMap map[string]string) error {
+	sandbox := client.Sandbox(sandboxID)
+	session, err := sandbox.CreateSession(ctx, execPipeSessionSpec(command, envMap), nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, _ = sandbox.DeleteSession(cleanupCtx, session.ID)
+	}()
+
+	// Replay from the start of the journal so output written before the
+	// attachment is established is not lost.
+	conn, _, err := sandbox.ConnectSession(ctx, session.ID, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go forwardSignals(ctx, func(msg execWSMessage) error {
+		return conn.Send(sandbox0.SessionWebSocketRequest{Type: "signal", Signal: msg.Signal})
+	})
+
+	code, err := pipeSessionExec(ctx, conn, os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if code != 0 {
+		return &execExitCodeError{code: code}
+	}
+	return nil
+}
+
+func execPipeSessionSpec(command []string, envMap map[string]string) apispec.ExecutionSessionSpec {
+	lifecycle := apispec.ExecutionSessionLifecycleSpec{
+		Restart: apispec.NewOptExecutionSessionRestartSpec(apispec.ExecutionSessionRestartSpec{
+			Policy: apispec.NewOptExecutionSessionRestartPolicy(apispec.ExecutionSessionRestartPolicyNever),
+		}),
+		RuntimeRecovery: apispec.NewOptExecutionSessionRuntimeRecoveryPolicy(apispec.ExecutionSessionRuntimeRecoveryPolicyStop),
+	}
+	if execTTL > 0 {
+		lifecycle.MaxLifetimeSeconds = apispec.NewOptInt64(int64(execTTL))
+	}
+	spec := apispec.ExecutionSessionSpec{
+		Command:   append([]string(nil), command...),
+		Lifecycle: apispec.NewOptExecutionSessionLifecycleSpec(lifecycle),
+		Io: apispec.NewOptExecutionSessionIOSpec(apispec.ExecutionSessionIOSpec{
+			Mode: apispec.NewOptExecutionSessionIOMode(apispec.ExecutionSessionIOModePipes),
+		}),
+	}
+	if execCwd != "" {
+		spec.Cwd = apispec.NewOptString(execCwd)
+	}
+	if len(envMap) > 0 {
+		spec.Env = apispec.NewOptExecutionSessionSpecEnv(apispec.ExecutionSessionSpecEnv(envMap))
+	}
+	return spec
+}
+
+// pipeSessionExec copies in to the session's stdin and its output to stdout
+// and stderr until the process exits, and returns its exit code.
+func pipeSessionExec(ctx context.Context, conn sessionPipeConn, in io.Reader, stdout, stderr io.Writer) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	pending := map[string]bool{}
+	window := make(chan struct{}, execPipeWindow)
+	go func() {
+		// Input errors after the process stopped reading, such as a closed
+		// stdin, are reported by the process itself through its exit code.
+		_ = writeSessionPipeInput(ctx, conn, in, window, func(requestID string) {
+			mu.Lock()
+			defer mu.Unlock()
+			pending[requestID] = true
+		})
+	}()
+
+	for {
+		msg, err := conn.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			return 0, fmt.Errorf("session stream closed before the command exited: %w", err)
+		}
+		switch msg.Type {
+		case "ack", "error":
+			mu.Lock()
+			input := pending[msg.RequestID]
+			delete(pending, msg.RequestID)
+			mu.Unlock()
+			if !input {
+				continue
+			}
+			if msg.Type == "error" {
+				return 0, fmt.Errorf("write input: %s", msg.Error)
+			}
+			<-window
+		case "event":
+			if msg.Event == nil {
+				continue
+			}
+			code, exited, err := handleSessionPipeEvent(msg.Event, stdout, stderr)
+			if err != nil || exited {
+				return code, err
+			}
+		}
+	}
+}
+
+// writeSessionPipeInput sends in as base64 input chunks followed by EOF.
+// Each chunk takes a slot in window until it is acknowledged.
+func writeSessionPipeInput(ctx context.Context, conn sessionPipeConn, in io.Reader, window chan struct{}, track func(requestID string)) error {
+	buf := make([]byte, execPipeChunkSize)
+	send := func(data []byte, eof bool) error {
+		select {
+		case window <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		request := sandbox0.SessionWebSocketRequest{
+			Type:      "input",
+			RequestID: uuid.NewString(),
+			InputID:   uuid.NewString(),
+			EOF:       eof,
+		}
+		if len(data) > 0 {
+			request.DataBase64 = base64.StdEncoding.EncodeToString(data)
+		}
+		track(request.RequestID)
+		return conn.Send(request)
+	}
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			if sendErr := send(buf[:n], false); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return send(nil, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleSessionPipeEvent writes output events and reports whether the event
+// ends the attempt.
+func handleSessionPipeEvent(event *apispec.ExecutionSessionEvent, stdout, stderr io.Writer) (int, bool, error) {
+	if encoded, ok := event.DataBase64.Get(); ok && event.Type == "output" {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return 0, false, fmt.Errorf("decode output: %w", err)
+		}
+		w := stdout
+		if stream, ok := event.Stream.Get(); ok && stream == apispec.ExecutionSessionEventStreamStderr {
+			w = stderr
+		}
+		if _, err := w.Write(data); err != nil {
+			return 0, false, err
+		}
+		return 0, false, nil
+	}
+	if code, ok := event.ExitCode.Get(); ok {
+		return int(code), true, nil
+	}
+	if strings.Contains(event.Type, "fail") {
+		reason := event.Reason.Or(event.Type)
+		return 0, true, fmt.Errorf("command failed: %s", reason)
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// fakeSessionPipe acknowledges input like a session attachment. When ack is
+// set it acknowledges every input and, on EOF, echoes the received bytes to
+// stdout and exits with exitCode.
+type fakeSessionPipe struct {
+	ack      bool
+	exitCode int32
+
+	mu       sync.Mutex
+	requests []sandbox0.SessionWebSocketRequest
+	received bytes.Buffer
+	messages chan *sandbox0.SessionWebSocketMessage
+}
+
+func newFakeSessionPipe(ack bool, exitCode int32) *fakeSessionPipe {
+	return &fakeSessionPipe{ack: ack, exitCode: exitCode, messages: make(chan *sandbox0.SessionWebSocketMessage, 64)}
+}
+
+func (f *fakeSessionPipe) Send(request sandbox0.SessionWebSocketRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, request)
+	data, err := base64.StdEncoding.DecodeString(request.DataBase64)
+	if err != nil {
+		return err
+	}
+	f.received.Write(data)
+	if !f.ack {
+		return nil
+	}
+	f.messages <- &sandbox0.SessionWebSocketMessage{Type: "ack", RequestID: request.RequestID}
+	if request.EOF {
+		f.messages <- &sandbox0.SessionWebSocketMessage{Type: "event", Event: &apispec.ExecutionSessionEvent{
+			Type:       "output",
+			Stream:     apispec.NewOptExecutionSessionEventStream(apispec.ExecutionSessionEventStreamStdout),
+			DataBase64: apispec.NewOptString(base64.StdEncoding.EncodeToString(f.received.Bytes())),
+		}}
+		f.messages <- &sandbox0.SessionWebSocketMessage{Type: "event", Event: &apispec.ExecutionSessionEvent{
+			Type:       "output",
+			Stream:     apispec.NewOptExecutionSessionEventStream(apispec.ExecutionSessionEventStreamStderr),
+			DataBase64: apispec.NewOptString(base64.StdEncoding.EncodeToString([]byte("done\n"))),
+		}}
+		f.messages <- &sandbox0.SessionWebSocketMessage{Type: "event", Event: &apispec.ExecutionSessionEvent{
+			Type:     "exited",
+			ExitCode: apispec.NewOptInt32(f.exitCode),
+		}}
+	}
+	return nil
+}
+
+func (f *fakeSessionPipe) Recv() (*sandbox0.SessionWebSocketMessage, error) {
+	msg, ok := <-f.messages
+	if !ok {
+		return nil, io.EOF
+	}
+	return msg, nil
+}
+
+func (f *fakeSessionPipe) sent() []sandbox0.SessionWebSocketRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]sandbox0.SessionWebSocketRequest(nil), f.requests...)
+}
+
+func TestPipeSessionExecIsBinarySafe(t *testing.T) {
+	input := make([]byte, 3*execPipeChunkSize+17)
+	for i := range input {
+		input[i] = byte(i * 7)
+	}
+	input[0], input[1] = 0xff, 0xfe
+
+	conn := newFakeSessionPipe(true, 3)
+	var stdout, stderr bytes.Buffer
+	code, err := pipeSessionExec(context.Background(), conn, bytes.NewReader(input), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("pipeSessionExec() error = %v", err)
+	}
+	if code != 3 {
+		t.Fatalf("exit code = %d, want 3", code)
+	}
+	if !bytes.Equal(stdout.Bytes(), input) {
+		t.Fatalf("stdout differs from input: got %d bytes, want %d", stdout.Len(), len(input))
+	}
+	if stderr.String() != "done\n" {
+		t.Fatalf("stderr = %q, want done", stderr.String())
+	}
+
+	requests := conn.sent()
+	last := requests[len(requests)-1]
+	if !last.EOF || last.DataBase64 != "" {
+		t.Fatalf("last request = %+v, want a bare EOF", last)
+	}
+	for _, request := range requests {
+		if request.Type != "input" || request.InputID == "" || request.RequestID == "" {
+			t.Fatalf("request = %+v, want input with input and request IDs", request)
+		}
+	}
+}
+
+func TestPipeSessionExecAppliesBackpressure(t *testing.T) {
+	conn := newFakeSessionPipe(false, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = pipeSessionExec(ctx, conn, zeroReader{}, io.Discard, io.Discard)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(conn.sent()) < execPipeWindow && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := len(conn.sent()); got != execPipeWindow {
+		t.Fatalf("sent %d unacknowledged chunks, want %d", got, execPipeWindow)
+	}
+
+	cancel()
+	close(conn.messages)
+	<-done
+}
+
+func TestHandleSessionPipeEventReportsFailure(t *testing.T) {
+	_, exited, err := handleSessionPipeEvent(&apispec.ExecutionSessionEvent{
+		Type:   "start_failed",
+		Reason: apispec.NewOptString("executable not found"),
+	}, io.Discard, io.Discard)
+	if !exited || err == nil || err.Error() != "command failed: executable not found" {
+		t.Fatalf("exited = %v, err = %v", exited, err)
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
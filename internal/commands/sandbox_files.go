@@ -4,16 +4,24 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path"
 
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
 	"github.com/spf13/cobra"
 )
 
 var (
-	filesSandboxID string
-	filesRecursive bool
-	filesParents   bool
-	filesStdin     bool
-	filesData      string
+	filesSandboxID         string
+	filesRecursive         bool
+	filesParents           bool
+	filesStdin             bool
+	filesData              string
+	filesUploadRecursive   bool
+	filesDownloadRecursive bool
+	filesSyncDelete        bool
+	filesSyncDryRun        bool
+	filesUploadTransfer    fileTransferFlags
+	filesDownloadTransfer  fileTransferFlags
 )
 
 // sandboxFilesCmd represents the sandbox files command group.
@@ -186,12 +194,17 @@ var sandboxFilesMvCmd = &cobra.Command{
 	},
 }
 
-// sandboxFilesUploadCmd uploads a local file.
+// sandboxFilesUploadCmd uploads a local file or directory.
 var sandboxFilesUploadCmd = &cobra.Command{
 	Use:   "upload <local-path> <remote-path>",
-	Short: "Upload a local file",
-	Long:  `Upload a local file to the sandbox.`,
-	Args:  cobra.ExactArgs(2),
+	Short: "Upload a local file or directory",
+	Long: `Upload a local file to the sandbox. Use --recursive to upload a local directory.
+
+Files are streamed with bounded memory and retried after network failures.
+Use --resume to skip files whose remote copy already has the same size and is
+not older, so rerunning an interrupted upload only sends what is missing. Use
+--verify to compare sha256 checksums once a single-file upload completes.`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		localPath := args[0]
 		remotePath := args[1]
@@ -202,28 +215,67 @@ var sandboxFilesUploadCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		data, err := os.ReadFile(localPath)
+		info, err := os.Stat(localPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading local file: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error reading local path: %v\n", err)
 			os.Exit(1)
 		}
-
-		_, err = client.Sandbox(filesSandboxID).WriteFile(cmd.Context(), remotePath, data)
+		opts, err := filesUploadTransfer.options(cmd)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fs := newSandboxFileSystem(client, filesSandboxID)
+		if info.IsDir() {
+			if !filesUploadRecursive {
+				fmt.Fprintf(os.Stderr, "Error uploading file: local path is a directory; use --recursive\n")
+				os.Exit(1)
+			}
+			opts.Progress = false
+			result, err := uploadSandboxDirectory(cmd.Context(), fs, localPath, remotePath, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error uploading directory: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf(
+				"Directory uploaded to %s (files=%d directories=%d skipped=%d bytes=%d)\n",
+				remotePath,
+				result.Files,
+				result.Directories,
+				result.Skipped,
+				result.Bytes,
+			)
+			return
+		}
+
+		if _, err := uploadFile(cmd.Context(), fs, localPath, remotePath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error uploading file: %v\n", err)
 			os.Exit(1)
 		}
+		if opts.Verify {
+			sum, err := verifyFileTransfer(cmd.Context(), fs, localPath, remotePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error verifying upload: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Verified sha256 %s\n", sum)
+		}
 
 		fmt.Printf("File uploaded to %s\n", remotePath)
 	},
 }
 
-// sandboxFilesDownloadCmd downloads a file to local.
+// sandboxFilesDownloadCmd downloads a file or directory to local.
 var sandboxFilesDownloadCmd = &cobra.Command{
 	Use:   "download <remote-path> <local-path>",
-	Short: "Download a file",
-	Long:  `Download a file from the sandbox to local filesystem.`,
-	Args:  cobra.ExactArgs(2),
+	Short: "Download a file or directory",
+	Long: `Download a file from the sandbox to local filesystem. Use --recursive to download a directory.
+
+Files are streamed to a .s0part file that is renamed into place once complete.
+Use --resume to continue an interrupted download, skipping files of a directory
+that are already up to date, and --verify to compare sha256 checksums once a
+single-file download completes.`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		remotePath := args[0]
 		localPath := args[1]
@@ -234,19 +286,129 @@ var sandboxFilesDownloadCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		data, err := client.Sandbox(filesSandboxID).ReadFile(cmd.Context(), remotePath)
+		opts, err := filesDownloadTransfer.options(cmd)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fs := newSandboxFileSystem(client, filesSandboxID)
+		if filesDownloadRecursive {
+			info, err := fs.StatFile(cmd.Context(), remotePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting file info: %v\n", err)
+				os.Exit(1)
+			}
+			if info.Type.Or("") == apispec.FileInfoTypeDir {
+				opts.Progress = false
+				result, err := downloadSandboxDirectory(cmd.Context(), fs, remotePath, localPath, opts)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error downloading directory: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf(
+					"Directory downloaded to %s (files=%d directories=%d skipped=%d bytes=%d)\n",
+					localPath,
+					result.Files,
+					result.Directories,
+					result.Skipped,
+					result.Bytes,
+				)
+				return
+			}
+		}
+
+		if _, err := downloadFileStream(cmd.Context(), fs, remotePath, localPath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error downloading file: %v\n", err)
 			os.Exit(1)
 		}
+		if opts.Verify {
+			sum, err := verifyFileTransfer(cmd.Context(), fs, localPath, remotePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error verifying download: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Verified sha256 %s\n", sum)
+		}
 
-		err = os.WriteFile(localPath, data, 0644)
+		fmt.Printf("File downloaded to %s\n", localPath)
+	},
+}
+
+// sandboxFilesSyncCmd mirrors a local directory into the sandbox.
+var sandboxFilesSyncCmd = &cobra.Command{
+	Use:   "sync <local-dir> <remote-dir>",
+	Short: "Sync a local directory into the sandbox",
+	Long: `Sync a local directory into a sandbox directory, transferring only files that
+are missing remotely, differ in size, or are newer locally.
+
+Patterns listed in a .s0ignore file at the root of the local directory are
+skipped, using gitignore-style comments, ! negation, trailing / for directories,
+and glob patterns. Use --delete to remove remote files that no longer exist
+locally; ignored remote paths are never deleted.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		localPath := args[0]
+		remotePath := args[1]
+
+		info, err := os.Stat(localPath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing local file: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error reading local path: %v\n", err)
+			os.Exit(1)
+		}
+		if !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "Error: local path %s is not a directory\n", localPath)
 			os.Exit(1)
 		}
 
-		fmt.Printf("File downloaded to %s\n", localPath)
+		ignore, err := loadSandboxIgnore(localPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		local, _, err := scanLocalTree(localPath, ignore)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading local directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		fs := newSandboxFileSystem(client, filesSandboxID)
+		remote, err := scanRemoteTree(cmd.Context(), fs, remotePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing remote directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		actions, unchanged := planSandboxSync(local, remote, filesSyncDelete, ignore)
+		if filesSyncDryRun {
+			for _, action := range actions {
+				fmt.Printf("%s %s\n", action.Kind, path.Join(remotePath, action.Path))
+			}
+			fmt.Printf("Dry run: %d changes, %d unchanged\n", len(actions), unchanged)
+			return
+		}
+
+		result, err := applySandboxSync(cmd.Context(), fs, localPath, remotePath, actions, fileTransferOptions{Retries: defaultTransferRetries})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing directory: %v\n", err)
+			os.Exit(1)
+		}
+		result.Unchanged = unchanged
+		fmt.Printf(
+			"Synced %s to %s (uploaded=%d deleted=%d directories=%d unchanged=%d bytes=%d)\n",
+			localPath,
+			remotePath,
+			result.Uploaded,
+			result.Deleted,
+			result.Directories,
+			result.Unchanged,
+			result.Bytes,
+		)
 	},
 }
 
@@ -344,6 +506,7 @@ func init() {
 	sandboxFilesCmd.AddCommand(sandboxFilesDownloadCmd)
 	sandboxFilesCmd.AddCommand(sandboxFilesWriteCmd)
 	sandboxFilesCmd.AddCommand(sandboxFilesWatchCmd)
+	sandboxFilesCmd.AddCommand(sandboxFilesSyncCmd)
 
 	// Sandbox ID flag (required for all subcommands)
 	sandboxFilesCmd.PersistentFlags().StringVarP(&filesSandboxID, "sandbox-id", "s", "", "sandbox ID (required)")
@@ -352,6 +515,16 @@ func init() {
 	// Mkdir flags
 	sandboxFilesMkdirCmd.Flags().BoolVar(&filesParents, "parents", false, "create parent directories as needed")
 
+	// Transfer flags
+	sandboxFilesUploadCmd.Flags().BoolVarP(&filesUploadRecursive, "recursive", "r", false, "upload a directory recursively")
+	sandboxFilesDownloadCmd.Flags().BoolVarP(&filesDownloadRecursive, "recursive", "r", false, "download a directory recursively")
+	addFileTransferFlags(sandboxFilesUploadCmd, &filesUploadTransfer)
+	sandboxFilesUploadCmd.Flags().BoolVar(&filesUploadTransfer.resume, "resume", false, "skip files whose remote copy is already up to date")
+	addFileTransferFlags(sandboxFilesDownloadCmd, &filesDownloadTransfer)
+	sandboxFilesDownloadCmd.Flags().BoolVar(&filesDownloadTransfer.resume, "resume", false, "resume a previously interrupted download")
+	sandboxFilesSyncCmd.Flags().BoolVar(&filesSyncDelete, "delete", false, "delete remote files that do not exist locally")
+	sandboxFilesSyncCmd.Flags().BoolVar(&filesSyncDryRun, "dry-run", false, "print planned changes without applying them")
+
 	// Watch flags
 	sandboxFilesWatchCmd.Flags().BoolVarP(&filesRecursive, "recursive", "r", false, "watch recursively")
 
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSandboxFilesCommandRegistration(t *testing.T) {
+	subcommands := map[string]bool{}
+	for _, cmd := range sandboxFilesCmd.Commands() {
+		subcommands[cmd.Name()] = true
+	}
+
+	expected := []string{"ls", "cat", "stat", "mkdir", "rm", "mv", "upload", "download", "write", "watch", "sync"}
+	for _, name := range expected {
+		if !subcommands[name] {
+			t.Fatalf("expected subcommand %q to be registered", name)
+		}
+	}
+}
+
+func TestSandboxFilesTransferFlags(t *testing.T) {
+	if sandboxFilesUploadCmd.Flags().Lookup("recursive") == nil {
+		t.Fatalf("expected upload --recursive flag")
+	}
+	if sandboxFilesDownloadCmd.Flags().Lookup("recursive") == nil {
+		t.Fatalf("expected download --recursive flag")
+	}
+	if sandboxFilesSyncCmd.Flags().Lookup("delete") == nil {
+		t.Fatalf("expected sync --delete flag")
+	}
+	if sandboxFilesSyncCmd.Flags().Lookup("dry-run") == nil {
+		t.Fatalf("expected sync --dry-run flag")
+	}
+}
+
+func TestSandboxIgnoreMatch(t *testing.T) {
+	matcher := parseSandboxIgnore(`
+# dependencies
+node_modules/
+*.log
+!keep.log
+/build
+docs/*.tmp
+`)
+
+	tests := []struct {
+		path string
+		dir  bool
+		want bool
+	}{
+		{path: "node_modules", dir: true, want: true},
+		{path: "node_modules/pkg/index.js", want: true},
+		{path: "src/node_modules", dir: true, want: true},
+		{path: "node_modules", want: false},
+		{path: "debug.log", want: true},
+		{path: "src/trace.log", want: true},
+		{path: "keep.log", want: false},
+		{path: "build", dir: true, want: true},
+		{path: "src/build", dir: true, want: false},
+		{path: "docs/a.tmp", want: true},
+		{path: "src/docs/a.tmp", want: false},
+		{path: "main.go", want: false},
+	}
+	for _, tt := range tests {
+		if got := matcher.Match(tt.path, tt.dir); got != tt.want {
+			t.Fatalf("Match(%q, %v) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestPlanSandboxSync(t *testing.T) {
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	local := map[string]sandboxTreeEntry{
+		"src":           {Dir: true},
+		"src/main.go":   {Size: 10, ModTime: older},
+		"src/util.go":   {Size: 20, ModTime: newer},
+		"src/resize.go": {Size: 5, ModTime: older},
+		"README.md":     {Size: 3, ModTime: older},
+		"assets":        {Dir: true},
+	}
+	remote := map[string]sandboxTreeEntry{
+		"src":           {Dir: true},
+		"src/main.go":   {Size: 10, ModTime: newer},
+		"src/util.go":   {Size: 20, ModTime: older},
+		"src/resize.go": {Size: 6, ModTime: newer},
+		"assets":        {Size: 1, ModTime: older},
+		"old":           {Dir: true},
+		"old/a.txt":     {Size: 1, ModTime: older},
+		"cache":         {Dir: true},
+	}
+	ignore := parseSandboxIgnore("cache/\n")
+
+	t.Run("without delete", func(t *testing.T) {
+		actions, unchanged := planSandboxSync(local, remote, false, ignore)
+		want := []sandboxSyncAction{
+			{Kind: sandboxSyncDelete, Path: "assets"},
+			{Kind: sandboxSyncMkdir, Path: "assets"},
+			{Kind: sandboxSyncUpload, Path: "README.md"},
+			{Kind: sandboxSyncUpload, Path: "src/resize.go"},
+			{Kind: sandboxSyncUpload, Path: "src/util.go"},
+		}
+		if !reflect.DeepEqual(actions, want) {
+			t.Fatalf("planSandboxSync() = %#v, want %#v", actions, want)
+		}
+		if unchanged != 1 {
+			t.Fatalf("unchanged = %d, want 1", unchanged)
+		}
+	})
+
+	t.Run("with delete", func(t *testing.T) {
+		actions, _ := planSandboxSync(local, remote, true, ignore)
+		want := []sandboxSyncAction{
+			{Kind: sandboxSyncDelete, Path: "assets"},
+			{Kind: sandboxSyncDelete, Path: "old"},
+			{Kind: sandboxSyncMkdir, Path: "assets"},
+			{Kind: sandboxSyncUpload, Path: "README.md"},
+			{Kind: sandboxSyncUpload, Path: "src/resize.go"},
+			{Kind: sandboxSyncUpload, Path: "src/util.go"},
+		}
+		if !reflect.DeepEqual(actions, want) {
+			t.Fatalf("planSandboxSync() = %#v, want %#v", actions, want)
+		}
+	})
+}
+
+func TestScanLocalTreeHonorsIgnore(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"src", "node_modules/pkg"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+	}
+	for name, content := range map[string]string{
+		".s0ignore":                 "node_modules/\n*.log\n",
+		"src/main.go":               "package main\n",
+		"src/debug.log":             "noise",
+		"node_modules/pkg/index.js": "module.exports = {}",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	ignore, err := loadSandboxIgnore(root)
+	if err != nil {
+		t.Fatalf("loadSandboxIgnore() error = %v", err)
+	}
+	entries, _, err := scanLocalTree(root, ignore)
+	if err != nil {
+		t.Fatalf("scanLocalTree() error = %v", err)
+	}
+
+	got := make([]string, 0, len(entries))
+	for rel := range entries {
+		got = append(got, rel)
+	}
+	want := []string{".s0ignore", "src", "src/main.go"}
+	if len(got) != len(want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+	for _, rel := range want {
+		if _, ok := entries[rel]; !ok {
+			t.Fatalf("entries = %v, missing %q", got, rel)
+		}
+	}
+	if entries["src/main.go"].Size != int64(len("package main\n")) {
+		t.Fatalf("src/main.go size = %d", entries["src/main.go"].Size)
+	}
+}
@@ -0,0 +1,479 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/client"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// sandboxIgnoreFileName is the per-directory ignore file honored by sandbox files sync.
+const sandboxIgnoreFileName = ".s0ignore"
+
+// remoteFileSystem is the file API shared by sandboxes and volumes, adapted by
+// sandboxFileSystem and volumeFileSystem. File content is always streamed.
+type remoteFileSystem interface {
+	StatFile(ctx context.Context, path string) (*apispec.FileInfo, error)
+	ListFiles(ctx context.Context, path string) ([]apispec.FileInfo, error)
+	Mkdir(ctx context.Context, path string, recursive bool) (*apispec.SuccessCreatedResponse, error)
+	DeleteFile(ctx context.Context, path string) (*apispec.SuccessDeletedResponse, error)
+	// OpenFile streams a file starting at offset.
+	OpenFile(ctx context.Context, path string, offset int64) (*client.FileDownload, error)
+	// UploadFile streams body into path, replacing its content. size is the
+	// body length, or -1 when unknown.
+	UploadFile(ctx context.Context, path string, body io.Reader, size int64) error
+}
+
+// sandboxFileSystem adds streaming file content to the sandbox file API.
+type sandboxFileSystem struct {
+	*sandbox0.Sandbox
+	client *sandbox0.Client
+}
+
+func newSandboxFileSystem(c *sandbox0.Client, sandboxID string) sandboxFileSystem {
+	return sandboxFileSystem{Sandbox: c.Sandbox(sandboxID), client: c}
+}
+
+func (s sandboxFileSystem) OpenFile(ctx context.Context, path string, offset int64) (*client.FileDownload, error) {
+	return client.DownloadSandboxFile(ctx, s.client, s.ID, path, offset)
+}
+
+func (s sandboxFileSystem) UploadFile(ctx context.Context, path string, body io.Reader, size int64) error {
+	return client.UploadSandboxFile(ctx, s.client, s.ID, path, body, size)
+}
+
+type sandboxTransferSummary struct {
+	Files       int
+	Directories int
+	Skipped     int
+	Bytes       int64
+}
+
+type sandboxTreeEntry struct {
+	Dir     bool
+	Size    int64
+	ModTime time.Time
+	// Mode holds the permission bits reported for remote entries, or zero
+	// when they are unknown.
+	Mode os.FileMode
+}
+
+type sandboxSyncActionKind string
+
+const (
+	sandboxSyncMkdir  sandboxSyncActionKind = "mkdir"
+	sandboxSyncUpload sandboxSyncActionKind = "upload"
+	sandboxSyncDelete sandboxSyncActionKind = "delete"
+)
+
+type sandboxSyncAction struct {
+	Kind sandboxSyncActionKind
+	Path string
+}
+
+type sandboxSyncSummary struct {
+	Uploaded    int
+	Deleted     int
+	Directories int
+	Unchanged   int
+	Bytes       int64
+}
+
+// sandboxIgnoreRule is one parsed line of a .s0ignore file.
+type sandboxIgnoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// sandboxIgnoreMatcher matches slash-separated relative paths against .s0ignore rules.
+// It supports a gitignore-like subset: comments, ! negation, trailing / for
+// directories, and path.Match globs that are anchored when they contain a /.
+type sandboxIgnoreMatcher struct {
+	rules []sandboxIgnoreRule
+}
+
+func parseSandboxIgnore(content string) *sandboxIgnoreMatcher {
+	matcher := &sandboxIgnoreMatcher{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := sandboxIgnoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.HasPrefix(line, "/") {
+			rule.anchored = true
+			line = strings.TrimLeft(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		matcher.rules = append(matcher.rules, rule)
+	}
+	return matcher
+}
+
+func loadSandboxIgnore(localRoot string) (*sandboxIgnoreMatcher, error) {
+	data, err := os.ReadFile(filepath.Join(localRoot, sandboxIgnoreFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &sandboxIgnoreMatcher{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", sandboxIgnoreFileName, err)
+	}
+	return parseSandboxIgnore(string(data)), nil
+}
+
+// Match reports whether rel, a slash-separated path relative to the sync root, is
+// ignored either directly or through one of its parent directories.
+func (m *sandboxIgnoreMatcher) Match(rel string, dir bool) bool {
+	if m == nil {
+		return false
+	}
+	for parent := path.Dir(rel); parent != "." && parent != "/"; parent = path.Dir(parent) {
+		if m.matchEntry(parent, true) {
+			return true
+		}
+	}
+	return m.matchEntry(rel, dir)
+}
+
+func (m *sandboxIgnoreMatcher) matchEntry(rel string, dir bool) bool {
+	ignored := false
+	base := path.Base(rel)
+	for _, rule := range m.rules {
+		if rule.dirOnly && !dir {
+			continue
+		}
+		target := base
+		if rule.anchored {
+			target = rel
+		}
+		if ok, _ := path.Match(rule.pattern, target); ok {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// scanLocalTree walks localRoot and returns regular files and directories keyed by
+// slash-separated relative path. Symlinks and other special files are skipped.
+func scanLocalTree(localRoot string, ignore *sandboxIgnoreMatcher) (map[string]sandboxTreeEntry, int, error) {
+	entries := map[string]sandboxTreeEntry{}
+	skipped := 0
+	err := filepath.WalkDir(localRoot, func(current string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if current == localRoot {
+			return nil
+		}
+		rel, err := filepath.Rel(localRoot, current)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if ignore.Match(rel, entry.IsDir()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			entries[rel] = sandboxTreeEntry{Dir: true, ModTime: info.ModTime()}
+		case info.Mode().IsRegular():
+			entries[rel] = sandboxTreeEntry{Size: info.Size(), ModTime: info.ModTime()}
+		default:
+			skipped++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, skipped, nil
+}
+
+// scanRemoteTree lists remoteRoot recursively. A missing root yields an empty tree.
+func scanRemoteTree(ctx context.Context, fs remoteFileSystem, remoteRoot string) (map[string]sandboxTreeEntry, error) {
+	entries := map[string]sandboxTreeEntry{}
+	var walk func(rel string) error
+	walk = func(rel string) error {
+		files, err := fs.ListFiles(ctx, path.Join(remoteRoot, rel))
+		if err != nil {
+			if rel == "" && isNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		for _, file := range files {
+			name := file.Name.Or(path.Base(file.Path.Or("")))
+			if name == "" || name == "." || name == ".." {
+				continue
+			}
+			childRel := path.Join(rel, name)
+			entry := sandboxTreeEntry{Size: file.Size.Or(0), Mode: parseRemoteFileMode(file.Mode.Or(""))}
+			if modTime, ok := file.ModTime.Get(); ok {
+				entry.ModTime = modTime
+			}
+			switch file.Type.Or("") {
+			case apispec.FileInfoTypeDir:
+				entry.Dir = true
+				entries[childRel] = entry
+				if err := walk(childRel); err != nil {
+					return err
+				}
+			case apispec.FileInfoTypeFile:
+				entries[childRel] = entry
+			}
+		}
+		return nil
+	}
+	if err := walk(""); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseRemoteFileMode returns the permission bits of a mode reported by the
+// file API, either octal such as 0755 or symbolic such as -rwxr-xr-x. It
+// returns zero when the mode is missing or not recognized.
+func parseRemoteFileMode(mode string) os.FileMode {
+	if mode == "" {
+		return 0
+	}
+	if perm, err := strconv.ParseUint(mode, 8, 32); err == nil {
+		return os.FileMode(perm).Perm()
+	}
+	if len(mode) < 9 {
+		return 0
+	}
+	var perm os.FileMode
+	for i, c := range mode[len(mode)-9:] {
+		bit := os.FileMode(1) << (8 - i)
+		switch {
+		case c == '-' || c == 'S' || c == 'T':
+		case c == rune("rwx"[i%3]) || (i%3 == 2 && (c == 's' || c == 't')):
+			perm |= bit
+		default:
+			return 0
+		}
+	}
+	return perm
+}
+
+// planSandboxSync compares a local and remote tree and returns the ordered actions
+// needed to make remote match local. Files are transferred when missing, when their
+// size differs, or when the local copy is newer. Remote entries that are ignored are
+// never deleted.
+func planSandboxSync(local, remote map[string]sandboxTreeEntry, deleteExtraneous bool, ignore *sandboxIgnoreMatcher) ([]sandboxSyncAction, int) {
+	var deletes, mkdirs, uploads []sandboxSyncAction
+	unchanged := 0
+
+	for rel, localEntry := range local {
+		remoteEntry, exists := remote[rel]
+		if exists && remoteEntry.Dir != localEntry.Dir {
+			deletes = append(deletes, sandboxSyncAction{Kind: sandboxSyncDelete, Path: rel})
+			exists = false
+		}
+		switch {
+		case localEntry.Dir && !exists:
+			mkdirs = append(mkdirs, sandboxSyncAction{Kind: sandboxSyncMkdir, Path: rel})
+		case localEntry.Dir:
+		case !exists || remoteEntry.Size != localEntry.Size ||
+			localEntry.ModTime.Truncate(time.Second).After(remoteEntry.ModTime.Truncate(time.Second)):
+			uploads = append(uploads, sandboxSyncAction{Kind: sandboxSyncUpload, Path: rel})
+		default:
+			unchanged++
+		}
+	}
+
+	if deleteExtraneous {
+		for rel, remoteEntry := range remote {
+			if _, ok := local[rel]; ok {
+				continue
+			}
+			if ignore.Match(rel, remoteEntry.Dir) {
+				continue
+			}
+			deletes = append(deletes, sandboxSyncAction{Kind: sandboxSyncDelete, Path: rel})
+		}
+	}
+	deletes = collapseSandboxSyncDeletes(deletes)
+
+	sortSandboxSyncActions(mkdirs)
+	sortSandboxSyncActions(uploads)
+	actions := make([]sandboxSyncAction, 0, len(deletes)+len(mkdirs)+len(uploads))
+	actions = append(actions, deletes...)
+	actions = append(actions, mkdirs...)
+	actions = append(actions, uploads...)
+	return actions, unchanged
+}
+
+// collapseSandboxSyncDeletes drops deletions already covered by deleting a parent directory.
+func collapseSandboxSyncDeletes(deletes []sandboxSyncAction) []sandboxSyncAction {
+	sortSandboxSyncActions(deletes)
+	out := make([]sandboxSyncAction, 0, len(deletes))
+	for _, action := range deletes {
+		covered := false
+		for _, kept := range out {
+			if strings.HasPrefix(action.Path, kept.Path+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			out = append(out, action)
+		}
+	}
+	return out
+}
+
+func sortSandboxSyncActions(actions []sandboxSyncAction) {
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].Path < actions[j].Path
+	})
+}
+
+func applySandboxSync(ctx context.Context, fs remoteFileSystem, localRoot, remoteRoot string, actions []sandboxSyncAction, opts fileTransferOptions) (sandboxSyncSummary, error) {
+	summary := sandboxSyncSummary{}
+	if _, err := fs.Mkdir(ctx, remoteRoot, true); err != nil && !isSandboxFileExists(err) {
+		return summary, fmt.Errorf("create %s: %w", remoteRoot, err)
+	}
+	for _, action := range actions {
+		remotePath := path.Join(remoteRoot, action.Path)
+		switch action.Kind {
+		case sandboxSyncDelete:
+			if _, err := fs.DeleteFile(ctx, remotePath); err != nil {
+				return summary, fmt.Errorf("delete %s: %w", remotePath, err)
+			}
+			summary.Deleted++
+		case sandboxSyncMkdir:
+			if _, err := fs.Mkdir(ctx, remotePath, true); err != nil && !isSandboxFileExists(err) {
+				return summary, fmt.Errorf("create %s: %w", remotePath, err)
+			}
+			summary.Directories++
+		case sandboxSyncUpload:
+			written, err := uploadFileStream(ctx, fs, filepath.Join(localRoot, filepath.FromSlash(action.Path)), remotePath, opts)
+			if err != nil {
+				return summary, fmt.Errorf("upload %s: %w", remotePath, err)
+			}
+			summary.Uploaded++
+			summary.Bytes += written
+		}
+	}
+	return summary, nil
+}
+
+func isSandboxFileExists(err error) bool {
+	var apiErr *sandbox0.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// uploadSandboxDirectory copies every regular file below localRoot into
+// remoteRoot. With opts.Resume, files whose remote copy already has the same
+// size and is not older are skipped.
+func uploadSandboxDirectory(ctx context.Context, fs remoteFileSystem, localRoot, remoteRoot string, opts fileTransferOptions) (sandboxTransferSummary, error) {
+	local, skipped, err := scanLocalTree(localRoot, nil)
+	if err != nil {
+		return sandboxTransferSummary{}, err
+	}
+	var remote map[string]sandboxTreeEntry
+	if opts.Resume {
+		if remote, err = scanRemoteTree(ctx, fs, remoteRoot); err != nil {
+			return sandboxTransferSummary{}, err
+		}
+	}
+	actions, unchanged := planSandboxSync(local, remote, false, nil)
+	result, err := applySandboxSync(ctx, fs, localRoot, remoteRoot, actions, opts)
+	return sandboxTransferSummary{
+		Files:       result.Uploaded,
+		Directories: result.Directories,
+		Skipped:     skipped + unchanged,
+		Bytes:       result.Bytes,
+	}, err
+}
+
+// downloadSandboxDirectory copies every regular file below the remoteRoot
+// directory into localRoot. With opts.Resume, files whose local copy already
+// has the same size and is not older are skipped, and partial files left by
+// an interrupted run are continued.
+func downloadSandboxDirectory(ctx context.Context, fs remoteFileSystem, remoteRoot, localRoot string, opts fileTransferOptions) (sandboxTransferSummary, error) {
+	summary := sandboxTransferSummary{}
+	remote, err := scanRemoteTree(ctx, fs, remoteRoot)
+	if err != nil {
+		return summary, err
+	}
+	if err := os.MkdirAll(localRoot, 0755); err != nil {
+		return summary, err
+	}
+
+	paths := make([]string, 0, len(remote))
+	for rel := range remote {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+	for _, rel := range paths {
+		entry := remote[rel]
+		localPath := filepath.Join(localRoot, filepath.FromSlash(rel))
+		if entry.Dir {
+			if err := os.MkdirAll(localPath, 0755); err != nil {
+				return summary, err
+			}
+			summary.Directories++
+			continue
+		}
+		if opts.Resume && localFileUpToDate(localPath, entry) {
+			summary.Skipped++
+			continue
+		}
+		remotePath := path.Join(remoteRoot, rel)
+		written, err := downloadFileStream(ctx, fs, remotePath, localPath, opts)
+		if err != nil {
+			return summary, fmt.Errorf("download %s: %w", remotePath, err)
+		}
+		summary.Files++
+		summary.Bytes += written
+	}
+	return summary, nil
+}
+
+// localFileUpToDate reports whether localPath has the size of remote and is
+// not older than it.
+func localFileUpToDate(localPath string, remote sandboxTreeEntry) bool {
+	info, err := os.Stat(localPath)
+	return err == nil && info.Mode().IsRegular() && info.Size() == remote.Size &&
+		!remote.ModTime.Truncate(time.Second).After(info.ModTime().Truncate(time.Second))
+}
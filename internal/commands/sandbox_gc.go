@@ -0,0 +1,257 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sandboxGCIdleFor          time.Duration
+	sandboxGCCPUThreshold     float64
+	sandboxGCNetworkThreshold float64
+	sandboxGCAction           string
+	sandboxGCDryRun           bool
+	sandboxGCStatus           string
+	sandboxGCTemplateID       string
+	sandboxGCOlderThan        time.Duration
+	sandboxGCConcurrency      int
+)
+
+// sandboxGCActivityEventTypes are the observability events that count as
+// workload activity. API access and lifecycle events are excluded because
+// queries such as sandbox gc itself produce them.
+var sandboxGCActivityEventTypes = []apispec.SandboxObservabilityEventType{
+	apispec.SandboxObservabilityEventTypeProcess,
+	apispec.SandboxObservabilityEventTypeFile,
+}
+
+// sandboxGCCmd pauses or deletes idle sandboxes.
+var sandboxGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Pause or delete idle sandboxes",
+	Long: `Find idle sandboxes and pause or delete them.
+
+A sandbox is idle when, over the last --idle-for, its peak CPU utilization
+and network throughput stay at or below the thresholds and it wrote no logs
+and produced no process or file events. Sandboxes without metrics are kept.
+
+gc only reports by default; pass --dry-run=false to act. The report is
+printed in the selected output format, so -o json or -o ndjson can be
+consumed from cron jobs. The command exits non-zero if any sandbox could not
+be classified or acted on.
+
+Examples:
+  s0 sandbox gc --idle-for 2h
+  s0 sandbox gc --idle-for 24h --action delete --dry-run=false -o ndjson`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if sandboxGCIdleFor <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: --idle-for must be positive")
+			os.Exit(1)
+		}
+		op, err := sandboxGCOperation(sandboxGCAction)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if sandboxGCConcurrency < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --concurrency must be greater than 0")
+			os.Exit(1)
+		}
+		olderThan := sandboxGCIdleFor
+		if cmd.Flags().Changed("older-than") {
+			olderThan = sandboxGCOlderThan
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		limit := 100
+		opts := sandbox0.ListSandboxesOptions{Status: sandboxGCStatus, TemplateID: sandboxGCTemplateID, Limit: &limit}
+		if op.verb == "pause" {
+			paused := false
+			opts.Paused = &paused
+		}
+		now := time.Now()
+		selected, err := selectSandboxes(cmd.Context(), client, opts, now.Add(-olderThan))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing sandboxes: %v\n", err)
+			os.Exit(1)
+		}
+
+		thresholds := sandboxIdleThresholds{Window: sandboxGCIdleFor, CPU: sandboxGCCPUThreshold, Network: sandboxGCNetworkThreshold}
+		report := output.SandboxGCReport(runBounded(cmd.Context(), selected, sandboxGCConcurrency, func(ctx context.Context, sandbox apispec.SandboxSummary) output.SandboxGCResult {
+			result := classifySandboxIdle(ctx, client.Sandbox(sandbox.ID), sandbox, thresholds, now)
+			switch {
+			case result.Error != "" || !result.Idle:
+			case sandboxGCDryRun:
+				result.Result = "would " + op.verb
+			default:
+				result.Result = op.done
+				if err := op.run(ctx, client, sandbox.ID); err != nil {
+					result.Result = "failed"
+					result.Error = err.Error()
+				}
+			}
+			return result
+		}))
+
+		if err := getFormatter().Format(os.Stdout, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+		idle, failed := 0, 0
+		for _, result := range report {
+			if result.Idle {
+				idle++
+			}
+			if result.Error != "" {
+				failed++
+			}
+		}
+		summary := fmt.Sprintf("%d of %d %s idle", idle, len(report), pluralizeSandbox(len(report)))
+		if sandboxGCDryRun && idle > 0 {
+			summary += fmt.Sprintf(", would %s (dry run; pass --dry-run=false to %s)", op.verb, op.verb)
+		}
+		fmt.Fprintf(os.Stderr, "%s, %d failed\n", summary, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	sandboxGCCmd.Flags().DurationVar(&sandboxGCIdleFor, "idle-for", time.Hour, "window without activity after which a sandbox is idle")
+	sandboxGCCmd.Flags().Float64Var(&sandboxGCCPUThreshold, "cpu-threshold", 0.05, "peak CPU utilization, as a fraction of the CPU limit, at or below which a sandbox is idle")
+	sandboxGCCmd.Flags().Float64Var(&sandboxGCNetworkThreshold, "network-threshold", 1024, "peak network throughput in bytes per second at or below which a sandbox is idle")
+	sandboxGCCmd.Flags().StringVar(&sandboxGCAction, "action", "pause", "action for idle sandboxes (pause, delete)")
+	sandboxGCCmd.Flags().BoolVar(&sandboxGCDryRun, "dry-run", true, "report idle sandboxes without changing them")
+	sandboxGCCmd.Flags().StringVar(&sandboxGCStatus, "status", string(apispec.SandboxLifecycleStatusRunning), "select sandboxes by status")
+	sandboxGCCmd.Flags().StringVar(&sandboxGCTemplateID, "template-id", "", "select sandboxes by template ID")
+	sandboxGCCmd.Flags().DurationVar(&sandboxGCOlderThan, "older-than", 0, "select sandboxes created longer ago than this (default --idle-for)")
+	sandboxGCCmd.Flags().IntVar(&sandboxGCConcurrency, "concurrency", 4, "number of sandboxes to process in parallel")
+	sandboxCmd.AddCommand(sandboxGCCmd)
+}
+
+func sandboxGCOperation(action string) (sandboxBulkOperation, error) {
+	switch action {
+	case "pause":
+		return sandboxBulkPause, nil
+	case "delete":
+		return sandboxBulkDelete, nil
+	default:
+		return sandboxBulkOperation{}, fmt.Errorf("invalid --action %q, expected pause or delete", action)
+	}
+}
+
+// sandboxIdleThresholds configure when a sandbox is classified as idle.
+type sandboxIdleThresholds struct {
+	Window  time.Duration
+	CPU     float64
+	Network float64
+}
+
+// sandboxActivityAPI is the subset of a sandbox handle used to classify it.
+type sandboxActivityAPI interface {
+	ListMetrics(ctx context.Context, opts *sandbox0.SandboxObservabilityMetricOptions) (*apispec.SandboxRuntimeMetricsResponse, error)
+	ListLogs(ctx context.Context, opts *sandbox0.SandboxObservabilityLogOptions) (*apispec.SandboxObservabilityLogsResponse, error)
+	ListObservabilityEvents(ctx context.Context, opts *sandbox0.SandboxObservabilityEventOptions) (*apispec.SandboxObservabilityEventsResponse, error)
+}
+
+// classifySandboxIdle reads the sandbox's metrics, logs, and events over the
+// threshold window ending at now and reports whether it is idle. A sandbox
+// whose activity cannot be determined is never idle.
+func classifySandboxIdle(ctx context.Context, api sandboxActivityAPI, sandbox apispec.SandboxSummary, thresholds sandboxIdleThresholds, now time.Time) output.SandboxGCResult {
+	result := output.SandboxGCResult{ID: sandbox.ID, TemplateID: sandbox.TemplateID, CreatedAt: sandbox.CreatedAt, Result: "kept"}
+	start := now.Add(-thresholds.Window)
+	fail := func(what string, err error) output.SandboxGCResult {
+		result.Result = "failed"
+		result.Error = fmt.Sprintf("%s: %v", what, err)
+		return result
+	}
+
+	metrics, err := api.ListMetrics(ctx, &sandbox0.SandboxObservabilityMetricOptions{
+		StartTime: &start,
+		EndTime:   &now,
+		Metrics:   []apispec.SandboxRuntimeMetricName{apispec.SandboxRuntimeMetricNameSandboxCPUUtilization, apispec.SandboxRuntimeMetricNameSandboxNetworkIo},
+		Statistic: apispec.SandboxRuntimeMetricStatisticMaximum,
+	})
+	if err != nil {
+		return fail("metrics", err)
+	}
+	result.CPUMax = peakMetricValue(metrics, apispec.SandboxRuntimeMetricNameSandboxCPUUtilization)
+	result.NetworkMax = peakMetricValue(metrics, apispec.SandboxRuntimeMetricNameSandboxNetworkIo)
+
+	logs, err := api.ListLogs(ctx, &sandbox0.SandboxObservabilityLogOptions{
+		SandboxObservabilityQueryOptions: sandbox0.SandboxObservabilityQueryOptions{StartTime: &start, EndTime: &now, Limit: 1},
+	})
+	if err != nil {
+		return fail("logs", err)
+	}
+	result.RecentLogs = len(logs.Logs) > 0
+
+	for _, eventType := range sandboxGCActivityEventTypes {
+		events, err := api.ListObservabilityEvents(ctx, &sandbox0.SandboxObservabilityEventOptions{
+			SandboxObservabilityQueryOptions: sandbox0.SandboxObservabilityQueryOptions{StartTime: &start, EndTime: &now, Limit: 1},
+			EventType:                        eventType,
+		})
+		if err != nil {
+			return fail("events", err)
+		}
+		result.RecentEvents = result.RecentEvents || len(events.Events) > 0
+	}
+
+	var reasons []string
+	switch {
+	case result.CPUMax == nil:
+		reasons = append(reasons, "no cpu metrics")
+	case *result.CPUMax > thresholds.CPU:
+		reasons = append(reasons, fmt.Sprintf("cpu %.1f%%", *result.CPUMax*100))
+	}
+	switch {
+	case result.NetworkMax == nil:
+		reasons = append(reasons, "no network metrics")
+	case *result.NetworkMax > thresholds.Network:
+		reasons = append(reasons, fmt.Sprintf("network %s/s", output.FormatBytes(int64(*result.NetworkMax))))
+	}
+	if result.RecentLogs {
+		reasons = append(reasons, "logs")
+	}
+	if result.RecentEvents {
+		reasons = append(reasons, "process or file events")
+	}
+	result.Idle = len(reasons) == 0
+	result.Reason = strings.Join(reasons, ", ")
+	return result
+}
+
+// peakMetricValue returns the largest point of every series of metric, or
+// nil when the metric has no points.
+func peakMetricValue(metrics *apispec.SandboxRuntimeMetricsResponse, metric apispec.SandboxRuntimeMetricName) *float64 {
+	var peak *float64
+	for _, series := range metrics.Series {
+		if series.Metric != metric {
+			continue
+		}
+		for _, segment := range series.Segments {
+			for _, point := range segment.Points {
+				if peak == nil || point.Value > *peak {
+					value := point.Value
+					peak = &value
+				}
+			}
+		}
+	}
+	return peak
+}
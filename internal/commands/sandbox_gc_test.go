@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+type fakeSandboxActivity struct {
+	cpu, network []float64
+	logs         int
+	events       map[apispec.SandboxObservabilityEventType]int
+	metricsErr   error
+}
+
+func testMetricSeries(metric apispec.SandboxRuntimeMetricName, values []float64) apispec.SandboxRuntimeMetricSeries {
+	segment := apispec.SandboxRuntimeMetricSegment{}
+	for i, value := range values {
+		segment.Points = append(segment.Points, apispec.SandboxRuntimeMetricPoint{Time: time.Unix(int64(i*60), 0), Value: value})
+	}
+	return apispec.SandboxRuntimeMetricSeries{Metric: metric, Segments: []apispec.SandboxRuntimeMetricSegment{segment}}
+}
+
+func (f *fakeSandboxActivity) ListMetrics(context.Context, *sandbox0.SandboxObservabilityMetricOptions) (*apispec.SandboxRuntimeMetricsResponse, error) {
+	if f.metricsErr != nil {
+		return nil, f.metricsErr
+	}
+	resp := &apispec.SandboxRuntimeMetricsResponse{}
+	if f.cpu != nil {
+		resp.Series = append(resp.Series, testMetricSeries(apispec.SandboxRuntimeMetricNameSandboxCPUUtilization, f.cpu))
+	}
+	if f.network != nil {
+		// Receive and transmit are reported as separate series.
+		resp.Series = append(resp.Series,
+			testMetricSeries(apispec.SandboxRuntimeMetricNameSandboxNetworkIo, f.network),
+			testMetricSeries(apispec.SandboxRuntimeMetricNameSandboxNetworkIo, []float64{0}),
+		)
+	}
+	return resp, nil
+}
+
+func (f *fakeSandboxActivity) ListLogs(context.Context, *sandbox0.SandboxObservabilityLogOptions) (*apispec.SandboxObservabilityLogsResponse, error) {
+	return &apispec.SandboxObservabilityLogsResponse{Logs: make([]apispec.SandboxObservabilityLogEntry, f.logs)}, nil
+}
+
+func (f *fakeSandboxActivity) ListObservabilityEvents(_ context.Context, opts *sandbox0.SandboxObservabilityEventOptions) (*apispec.SandboxObservabilityEventsResponse, error) {
+	return &apispec.SandboxObservabilityEventsResponse{Events: make([]apispec.SandboxObservabilityEvent, f.events[opts.EventType])}, nil
+}
+
+func TestClassifySandboxIdle(t *testing.T) {
+	thresholds := sandboxIdleThresholds{Window: time.Hour, CPU: 0.05, Network: 1024}
+	sandbox := apispec.SandboxSummary{ID: "sb_1", TemplateID: "python"}
+	tests := map[string]struct {
+		api        *fakeSandboxActivity
+		wantIdle   bool
+		wantReason string
+	}{
+		"idle": {
+			api:      &fakeSandboxActivity{cpu: []float64{0.01, 0.02}, network: []float64{100, 512}},
+			wantIdle: true,
+		},
+		"busy cpu": {
+			api:        &fakeSandboxActivity{cpu: []float64{0.01, 0.5}, network: []float64{0}},
+			wantReason: "cpu 50.0%",
+		},
+		"busy network and logs": {
+			api:        &fakeSandboxActivity{cpu: []float64{0}, network: []float64{4096}, logs: 1},
+			wantReason: "network 4.0 KiB/s, logs",
+		},
+		"file events": {
+			api:        &fakeSandboxActivity{cpu: []float64{0}, network: []float64{0}, events: map[apispec.SandboxObservabilityEventType]int{apispec.SandboxObservabilityEventTypeFile: 1}},
+			wantReason: "process or file events",
+		},
+		"no metrics": {
+			api:        &fakeSandboxActivity{},
+			wantReason: "no cpu metrics, no network metrics",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := classifySandboxIdle(context.Background(), tt.api, sandbox, thresholds, time.Now())
+			if result.Idle != tt.wantIdle || result.Reason != tt.wantReason || result.Error != "" {
+				t.Fatalf("result = %+v, want idle %v reason %q", result, tt.wantIdle, tt.wantReason)
+			}
+			if result.ID != "sb_1" || result.TemplateID != "python" || result.Result != "kept" {
+				t.Fatalf("result = %+v", result)
+			}
+		})
+	}
+
+	result := classifySandboxIdle(context.Background(), &fakeSandboxActivity{metricsErr: errors.New("backend unavailable")}, sandbox, thresholds, time.Now())
+	if result.Idle || result.Result != "failed" || result.Error != "metrics: backend unavailable" {
+		t.Fatalf("result = %+v, want metrics failure", result)
+	}
+}
+
+func TestSandboxGCOperationRejectsUnknown(t *testing.T) {
+	if _, err := sandboxGCOperation("archive"); err == nil {
+		t.Fatal("sandboxGCOperation(archive) error = nil, want error")
+	}
+}
@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	keepaliveInterval   time.Duration
+	keepaliveOnExit     string
+	keepaliveWarnBefore time.Duration
+)
+
+// sandboxKeepaliveCmd refreshes a sandbox TTL while a local command runs.
+var sandboxKeepaliveCmd = &cobra.Command{
+	Use:   "keepalive <sandbox-id> [-- <command> [args...]]",
+	Short: "Keep refreshing a sandbox TTL while a local command runs",
+	Long: `Refresh the sandbox TTL every --interval while a local command runs.
+
+Refreshing stops when the command exits, and keepalive exits with the
+command's exit code. Without a command, keepalive refreshes until it is
+interrupted. --on-exit pauses or deletes the sandbox afterwards.
+
+Refresh cannot extend the hard TTL; a warning is printed once the hard
+expiry is within --warn-before, and refreshing stops when it is reached.
+
+Examples:
+  s0 sandbox keepalive sb_abc123 -- go test ./e2e/...
+  s0 sandbox keepalive sb_abc123 --on-exit delete -- make integration
+  s0 sandbox keepalive sb_abc123 --interval 2m`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+		var command []string
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			if dash != 1 {
+				fmt.Fprintln(os.Stderr, "Error: expected a single sandbox ID before '--'")
+				os.Exit(1)
+			}
+			command = args[dash:]
+		} else if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, "Error: the local command must be preceded by '--'")
+			os.Exit(1)
+		}
+		if keepaliveInterval <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: --interval must be positive")
+			os.Exit(1)
+		}
+		switch keepaliveOnExit {
+		case "", "pause", "delete":
+		default:
+			fmt.Fprintf(os.Stderr, "Error: invalid --on-exit %q, expected pause or delete\n", keepaliveOnExit)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		exitCode := runSandboxKeepalive(cmd.Context(), client, sandboxID, command)
+		if keepaliveOnExit != "" {
+			if err := keepaliveExitAction(context.Background(), client, sandboxID, keepaliveOnExit); err != nil {
+				fmt.Fprintf(os.Stderr, "Error running --on-exit %s: %v\n", keepaliveOnExit, err)
+				if exitCode == 0 {
+					exitCode = 1
+				}
+			} else {
+				fmt.Fprintf(os.Stderr, "Sandbox %s %sd\n", sandboxID, keepaliveOnExit)
+			}
+		}
+		os.Exit(exitCode)
+	},
+}
+
+func init() {
+	sandboxKeepaliveCmd.Flags().DurationVar(&keepaliveInterval, "interval", time.Minute, "interval between TTL refreshes")
+	sandboxKeepaliveCmd.Flags().StringVar(&keepaliveOnExit, "on-exit", "", "pause or delete the sandbox when keepalive exits")
+	sandboxKeepaliveCmd.Flags().DurationVar(&keepaliveWarnBefore, "warn-before", 5*time.Minute, "warn when the hard TTL expires within this duration")
+	sandboxCmd.AddCommand(sandboxKeepaliveCmd)
+}
+
+// runSandboxKeepalive refreshes the sandbox until the local command exits, or
+// until interrupted when command is empty, and returns the exit code.
+func runSandboxKeepalive(ctx context.Context, client *sandbox0.Client, sandboxID string, command []string) int {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	refreshDone := make(chan error, 1)
+	go func() {
+		refreshDone <- keepSandboxRefreshed(ctx, client, sandboxID, keepaliveInterval, keepaliveWarnBefore, os.Stderr)
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, forwardingSignals()...)
+	defer signal.Stop(sigCh)
+
+	if len(command) == 0 {
+		select {
+		case <-sigCh:
+			return 0
+		case err := <-refreshDone:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error refreshing sandbox: %v\n", err)
+				return 1
+			}
+			return 0
+		}
+	}
+
+	local := exec.Command(command[0], command[1:]...)
+	local.Stdin = os.Stdin
+	local.Stdout = os.Stdout
+	local.Stderr = os.Stderr
+	if err := local.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error starting command: %v\n", err)
+		return 1
+	}
+	waitDone := make(chan error, 1)
+	go func() { waitDone <- local.Wait() }()
+
+	for {
+		select {
+		case sig := <-sigCh:
+			if keepaliveForwardsSignal(sig, inTerminalForeground(os.Stdin) || inTerminalForeground(os.Stderr)) {
+				_ = local.Process.Signal(sig)
+			}
+		case err := <-refreshDone:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: no longer refreshing sandbox %s: %v\n", sandboxID, err)
+			}
+			refreshDone = nil
+		case err := <-waitDone:
+			if err == nil {
+				return 0
+			}
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				if code, signaled := signaledExitCode(exitErr.ProcessState); signaled {
+					return code
+				}
+				if exitErr.ExitCode() >= 0 {
+					return exitErr.ExitCode()
+				}
+			}
+			fmt.Fprintf(os.Stderr, "Error running command: %v\n", err)
+			return 1
+		}
+	}
+}
+
+// keepaliveForwardsSignal reports whether sig is forwarded to the command.
+// While keepalive runs in the terminal's foreground process group, an
+// interrupt comes from the terminal and already reached the command; sending
+// it again would look like a second Ctrl-C, which many tools treat as a
+// forced quit that skips cleanup. Other signals were sent to keepalive alone.
+func keepaliveForwardsSignal(sig os.Signal, foreground bool) bool {
+	return !foreground || sig != os.Interrupt
+}
+
+// sandboxRefresher is the subset of the client used by keepSandboxRefreshed.
+type sandboxRefresher interface {
+	RefreshSandbox(ctx context.Context, sandboxID string, request *apispec.SandboxRefreshRequest) (*apispec.RefreshResponse, error)
+}
+
+// keepSandboxRefreshed refreshes the sandbox immediately and then every
+// interval until ctx is done. Transient refresh failures are reported and
+// retried at the next tick; a missing sandbox is returned as an error. It
+// returns nil once the hard TTL has been reached.
+func keepSandboxRefreshed(ctx context.Context, client sandboxRefresher, sandboxID string, interval, warnBefore time.Duration, stderr io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	warned := false
+	for {
+		resp, err := client.RefreshSandbox(ctx, sandboxID, nil)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case isNotFoundError(err):
+			return err
+		case err != nil:
+			fmt.Fprintf(stderr, "Warning: refreshing sandbox %s failed: %v\n", sandboxID, err)
+		case !resp.HardExpiresAt.IsZero():
+			remaining := time.Until(resp.HardExpiresAt)
+			if remaining <= 0 {
+				fmt.Fprintf(stderr, "Warning: sandbox %s reached its hard TTL at %s; refresh cannot extend it\n", sandboxID, resp.HardExpiresAt.Local().Format(time.RFC3339))
+				return nil
+			}
+			if remaining <= warnBefore && !warned {
+				warned = true
+				fmt.Fprintf(stderr, "Warning: sandbox %s reaches its hard TTL in %s (at %s); refresh cannot extend it\n",
+					sandboxID, remaining.Round(time.Second), resp.HardExpiresAt.Local().Format(time.RFC3339))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// keepaliveExitAction pauses or deletes the sandbox once keepalive is done.
+func keepaliveExitAction(ctx context.Context, client *sandbox0.Client, sandboxID, action string) error {
+	var err error
+	if action == "delete" {
+		_, err = client.DeleteSandbox(ctx, sandboxID)
+	} else {
+		_, err = client.PauseSandbox(ctx, sandboxID)
+	}
+	return err
+}
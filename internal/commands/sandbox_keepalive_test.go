@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+type fakeSandboxRefresher struct {
+	calls     int
+	responses []func() (*apispec.RefreshResponse, error)
+}
+
+func (f *fakeSandboxRefresher) RefreshSandbox(context.Context, string, *apispec.SandboxRefreshRequest) (*apispec.RefreshResponse, error) {
+	respond := f.responses[min(f.calls, len(f.responses)-1)]
+	f.calls++
+	return respond()
+}
+
+func TestKeepSandboxRefreshedWarnsBeforeHardTTL(t *testing.T) {
+	hardExpiry := time.Now().Add(time.Minute)
+	near := func() (*apispec.RefreshResponse, error) {
+		return &apispec.RefreshResponse{SandboxID: "sb_1", ExpiresAt: hardExpiry, HardExpiresAt: hardExpiry}, nil
+	}
+	expired := func() (*apispec.RefreshResponse, error) {
+		return &apispec.RefreshResponse{SandboxID: "sb_1", HardExpiresAt: time.Now().Add(-time.Second)}, nil
+	}
+	client := &fakeSandboxRefresher{responses: []func() (*apispec.RefreshResponse, error){
+		func() (*apispec.RefreshResponse, error) { return nil, errors.New("connection reset") },
+		near, near, expired,
+	}}
+
+	var stderr bytes.Buffer
+	if err := keepSandboxRefreshed(context.Background(), client, "sb_1", time.Millisecond, 5*time.Minute, &stderr); err != nil {
+		t.Fatalf("keepSandboxRefreshed() error = %v", err)
+	}
+	if client.calls != 4 {
+		t.Fatalf("refresh calls = %d, want 4", client.calls)
+	}
+	out := stderr.String()
+	if strings.Count(out, "reaches its hard TTL") != 1 || !strings.Contains(out, "reached its hard TTL") || !strings.Contains(out, "connection reset") {
+		t.Fatalf("stderr = %q", out)
+	}
+}
+
+func TestKeepSandboxRefreshedStopsWhenSandboxIsGone(t *testing.T) {
+	client := &fakeSandboxRefresher{responses: []func() (*apispec.RefreshResponse, error){
+		func() (*apispec.RefreshResponse, error) {
+			return nil, &sandbox0.APIError{StatusCode: http.StatusNotFound}
+		},
+	}}
+	err := keepSandboxRefreshed(context.Background(), client, "sb_1", time.Millisecond, time.Minute, &bytes.Buffer{})
+	if !isNotFoundError(err) {
+		t.Fatalf("keepSandboxRefreshed() error = %v, want not found", err)
+	}
+}
+
+func TestRunSandboxKeepaliveForwardsExitCode(t *testing.T) {
+	oldInterval := keepaliveInterval
+	t.Cleanup(func() { keepaliveInterval = oldInterval })
+	keepaliveInterval = 10 * time.Millisecond
+
+	var refreshes atomic.Int32
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/sandboxes/sb_1/refresh" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		refreshes.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"success":true,"data":{"sandbox_id":"sb_1","expires_at":%q}}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	})
+
+	code := runSandboxKeepalive(context.Background(), client, "sb_1", []string{"sh", "-c", "sleep 0.1; exit 3"})
+	if code != 3 {
+		t.Fatalf("exit code = %d, want 3", code)
+	}
+	if refreshes.Load() < 2 {
+		t.Fatalf("refreshes = %d, want periodic refreshes while the command runs", refreshes.Load())
+	}
+	if code := runSandboxKeepalive(context.Background(), client, "sb_1", []string{"s0-keepalive-missing-command"}); code != 1 {
+		t.Fatalf("exit code for missing command = %d, want 1", code)
+	}
+}
+
+func TestKeepaliveForwardsSignal(t *testing.T) {
+	tests := []struct {
+		sig        os.Signal
+		foreground bool
+		want       bool
+	}{
+		{sig: os.Interrupt, foreground: true, want: false},
+		{sig: os.Interrupt, foreground: false, want: true},
+		{sig: syscall.SIGTERM, foreground: true, want: true},
+		{sig: syscall.SIGTERM, foreground: false, want: true},
+	}
+	for _, tt := range tests {
+		if got := keepaliveForwardsSignal(tt.sig, tt.foreground); got != tt.want {
+			t.Fatalf("keepaliveForwardsSignal(%v, %v) = %v, want %v", tt.sig, tt.foreground, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var portForwardAddress string
+
+// portMapping forwards a local port to a port inside the sandbox.
+type portMapping struct {
+	Local  int
+	Remote int
+}
+
+func (m portMapping) String() string {
+	return fmt.Sprintf("%d:%d", m.Local, m.Remote)
+}
+
+// sandboxPortForwardCmd forwards local TCP ports into a sandbox.
+var sandboxPortForwardCmd = &cobra.Command{
+	Use:   "port-forward <sandbox-id> <local-port>:<remote-port> [<local-port>:<remote-port>...]",
+	Short: "Forward local ports to a sandbox",
+	Long: `Listen on local ports and tunnel each connection to a port inside the sandbox.
+
+Connections are relayed over the authenticated sandbox context WebSocket, so the
+sandbox port is never exposed publicly. Each mapping accepts multiple concurrent
+connections. A single port such as 3000 forwards to the same remote port.
+
+The context WebSocket carries text, so each connection is relayed by a small
+python3 process inside the sandbox. The sandbox image must provide python3; the
+command checks for it before listening. Press Ctrl+C to stop forwarding.
+
+Examples:
+  s0 sandbox port-forward sb_abc123 8080:3000
+  s0 sandbox port-forward sb_abc123 5432 6379:6379`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+		mappings, err := parsePortMappings(args[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), forwardingSignals()...)
+		defer cancel()
+
+		if err := checkSandboxRelay(ctx, client, sandboxID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error forwarding ports: %v\n", err)
+			os.Exit(1)
+		}
+		opener := newSandboxRelayOpener(client, sandboxID)
+		if err := runPortForward(ctx, portForwardAddress, mappings, opener, os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error forwarding ports: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// parsePortMappings parses LOCAL:REMOTE or PORT arguments.
+func parsePortMappings(args []string) ([]portMapping, error) {
+	mappings := make([]portMapping, 0, len(args))
+	seen := map[int]bool{}
+	for _, arg := range args {
+		localText, remoteText, found := strings.Cut(arg, ":")
+		if !found {
+			remoteText = localText
+		}
+		local, err := parsePort(localText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port mapping %q: %w", arg, err)
+		}
+		remote, err := parsePort(remoteText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port mapping %q: %w", arg, err)
+		}
+		if seen[local] {
+			return nil, fmt.Errorf("local port %d is mapped more than once", local)
+		}
+		seen[local] = true
+		mappings = append(mappings, portMapping{Local: local, Remote: remote})
+	}
+	return mappings, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port must be between 1 and 65535")
+	}
+	return port, nil
+}
+
+// runPortForward listens on every mapping and relays accepted connections
+// until ctx is canceled, then closes listeners and active connections.
+func runPortForward(ctx context.Context, address string, mappings []portMapping, open sandboxRelayOpener, stdout, stderr io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	listeners := make([]net.Listener, 0, len(mappings))
+	defer func() {
+		for _, listener := range listeners {
+			_ = listener.Close()
+		}
+	}()
+	for _, mapping := range mappings {
+		listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(mapping.Local)))
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, listener)
+		fmt.Fprintf(stdout, "Forwarding from %s -> %d\n", listener.Addr(), mapping.Remote)
+	}
+
+	var wg sync.WaitGroup
+	for i, listener := range listeners {
+		wg.Add(1)
+		go func(listener net.Listener, mapping portMapping) {
+			defer wg.Done()
+			acceptPortForward(ctx, listener, mapping, open, stderr)
+		}(listener, mappings[i])
+	}
+
+	<-ctx.Done()
+	for _, listener := range listeners {
+		_ = listener.Close()
+	}
+	wg.Wait()
+	return nil
+}
+
+func acceptPortForward(ctx context.Context, listener net.Listener, mapping portMapping, open sandboxRelayOpener, stderr io.Writer) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() == nil && !errors.Is(err, net.ErrClosed) {
+				fmt.Fprintf(stderr, "Error accepting connection on %s: %v\n", listener.Addr(), err)
+			}
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := forwardPortConnection(ctx, conn, mapping, open); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(stderr, "Error forwarding %s: %v\n", mapping, err)
+			}
+		}()
+	}
+}
+
+func forwardPortConnection(ctx context.Context, local net.Conn, mapping portMapping, open sandboxRelayOpener) error {
+	defer local.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		_ = local.Close()
+	}()
+
+	conn, cleanup, err := open(ctx, "127.0.0.1", mapping.Remote)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return bridgeSandboxRelay(ctx, conn, local)
+}
+
+func init() {
+	sandboxPortForwardCmd.Flags().StringVar(&portForwardAddress, "address", "127.0.0.1", "local address to listen on")
+
+	sandboxCmd.AddCommand(sandboxPortForwardCmd)
+}
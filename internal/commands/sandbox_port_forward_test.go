@@ -0,0 +1,213 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestParsePortMappings(t *testing.T) {
+	got, err := parsePortMappings([]string{"8080:3000", "5432"})
+	if err != nil {
+		t.Fatalf("parsePortMappings() error = %v", err)
+	}
+	want := []portMapping{{Local: 8080, Remote: 3000}, {Local: 5432, Remote: 5432}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parsePortMappings() = %+v, want %+v", got, want)
+	}
+
+	for _, args := range [][]string{{"0:80"}, {"8080:abc"}, {"70000"}, {"8080:1", "8080:2"}} {
+		if _, err := parsePortMappings(args); err == nil {
+			t.Fatalf("parsePortMappings(%v) error = nil, want error", args)
+		}
+	}
+}
+
+// newRelayStandIn starts a WebSocket server that speaks the relay framing and
+// upper-cases everything it receives, standing in for a sandbox context.
+func newRelayStandIn(t *testing.T) (sandboxRelayOpener, func() int) {
+	t.Helper()
+	var mu sync.Mutex
+	opened := 0
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var pending string
+		for {
+			var msg execWSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			pending += msg.Data
+			for {
+				line, rest, found := strings.Cut(pending, "\n")
+				if !found {
+					break
+				}
+				pending = rest
+				if line == relayEOFLine {
+					exitCode := 0
+					_ = conn.WriteJSON(execWSMessage{Type: "done", ExitCode: &exitCode})
+					return
+				}
+				data, err := base64.StdEncoding.DecodeString(line)
+				if err != nil {
+					t.Errorf("invalid frame %q", line)
+					return
+				}
+				reply := base64.StdEncoding.EncodeToString(bytes.ToUpper(data))
+				// Split the frame across messages to exercise reassembly.
+				_ = conn.WriteJSON(execWSMessage{Type: "output", Source: "stdout", Data: reply[:1]})
+				_ = conn.WriteJSON(execWSMessage{Type: "output", Source: "stdout", Data: reply[1:] + "\n"})
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	opener := func(ctx context.Context, host string, port int) (*websocket.Conn, func(), error) {
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		mu.Lock()
+		opened++
+		mu.Unlock()
+		return conn, func() {}, nil
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return opened
+	}
+	return opener, count
+}
+
+func TestRunPortForwardRelaysConcurrentConnections(t *testing.T) {
+	opener, opened := newRelayStandIn(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runPortForward(ctx, "127.0.0.1", []portMapping{{Local: port, Remote: 3000}}, opener, io.Discard, io.Discard)
+	}()
+
+	var conn net.Conn
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		if conn, err = net.Dial("tcp", listener.Addr().String()); err == nil {
+			_ = conn.Close()
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("port-forward did not start listening: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, payload := range []string{"hello", "sandbox"} {
+		wg.Add(1)
+		go func(payload string) {
+			defer wg.Done()
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			if err != nil {
+				t.Errorf("Dial() error = %v", err)
+				return
+			}
+			defer conn.Close()
+			_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+			if _, err := conn.Write([]byte(payload)); err != nil {
+				t.Errorf("Write() error = %v", err)
+				return
+			}
+			_ = conn.(*net.TCPConn).CloseWrite()
+			got, err := io.ReadAll(conn)
+			if err != nil {
+				t.Errorf("ReadAll() error = %v", err)
+				return
+			}
+			if string(got) != strings.ToUpper(payload) {
+				t.Errorf("reply = %q, want %q", string(got), strings.ToUpper(payload))
+			}
+		}(payload)
+	}
+	wg.Wait()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("runPortForward() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("runPortForward() did not stop after cancellation")
+	}
+	if opened() < 2 {
+		t.Fatalf("opened relays = %d, want at least 2", opened())
+	}
+}
+
+func TestRelayExitError(t *testing.T) {
+	missing := relayMissingExe
+	if err := relayExitError(&missing, ""); err == nil || !strings.Contains(err.Error(), "python3") {
+		t.Fatalf("relayExitError(127) = %v, want python3 hint", err)
+	}
+	failed := 1
+	err := relayExitError(&failed, "Traceback\nConnectionRefusedError: [Errno 111] Connection refused\n")
+	if err == nil || !strings.Contains(err.Error(), "Connection refused") {
+		t.Fatalf("relayExitError(1) = %v, want last stderr line", err)
+	}
+	zero := 0
+	if err := relayExitError(&zero, ""); err != nil {
+		t.Fatalf("relayExitError(0) = %v, want nil", err)
+	}
+}
+
+func TestCheckSandboxRelayRequiresPython(t *testing.T) {
+	var deleted bool
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/sandboxes/sb_1/contexts":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":"ctx_1","type":"cmd","running":false,"paused":false,"created_at":"2026-01-01T00:00:00Z","exit_code":127}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/sandboxes/sb_1/contexts/ctx_1":
+			deleted = true
+			writeTestSuccess(t, w, map[string]bool{"deleted": true})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	err := checkSandboxRelay(context.Background(), client, "sb_1")
+	if !errors.Is(err, errSandboxRelayUnavailable) {
+		t.Fatalf("checkSandboxRelay() error = %v, want errSandboxRelayUnavailable", err)
+	}
+	if !deleted {
+		t.Fatal("checkSandboxRelay() did not delete the check context")
+	}
+}
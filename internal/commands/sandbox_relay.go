@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+const (
+	relayChunkSize  = 32 * 1024
+	relayEOFLine    = "."
+	relayMissingExe = 127
+)
+
+// sandboxRelayScript connects to a TCP port inside the sandbox and bridges it
+// to stdin/stdout. Context streams carry text, so each direction is framed as
+// one base64 line per chunk; a "." line half-closes the connection.
+const sandboxRelayScript = `import base64, socket, sys, threading
+s = socket.create_connection((sys.argv[1], int(sys.argv[2])))
+def up():
+    for line in sys.stdin.buffer:
+        line = line.strip()
+        if line == b".":
+            break
+        if line:
+            s.sendall(base64.b64decode(line))
+    try:
+        s.shutdown(socket.SHUT_WR)
+    except OSError:
+        pass
+threading.Thread(target=up, daemon=True).start()
+out = sys.stdout.buffer
+while True:
+    data = s.recv(32768)
+    if not data:
+        break
+    out.write(base64.b64encode(data) + b"\n")
+    out.flush()
+`
+
+// errSandboxRelayUnavailable reports a sandbox image without python3. The
+// context WebSocket only carries text to a process's stdin, so raw bytes are
+// relayed by a small python3 process inside the sandbox.
+var errSandboxRelayUnavailable = errors.New("python3 is required in the sandbox to relay connections")
+
+// checkSandboxRelay verifies that relay processes can run in sandboxID, so
+// commands can fail before they start listening.
+func checkSandboxRelay(ctx context.Context, client *sandbox0.Client, sandboxID string) error {
+	sandbox := client.Sandbox(sandboxID)
+	contextResp, err := sandbox.CreateContext(ctx, apispec.CreateContextRequest{
+		Type:          apispec.NewOptProcessType(apispec.ProcessTypeCmd),
+		Cmd:           apispec.NewOptCreateCMDContextRequest(apispec.CreateCMDContextRequest{Command: []string{"python3", "-c", "pass"}}),
+		WaitUntilDone: apispec.NewOptBool(true),
+	})
+	if err != nil {
+		return err
+	}
+	if contextResp == nil {
+		return fmt.Errorf("create context returned nil response")
+	}
+	defer func() {
+		deleteCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, _ = sandbox.DeleteContext(deleteCtx, contextResp.ID)
+	}()
+
+	code, ok := contextResp.ExitCode.Get()
+	if !ok || code == 0 {
+		return nil
+	}
+	exitCode := int(code)
+	return relayExitError(&exitCode, contextResp.Stderr.Or(""))
+}
+
+// sandboxRelayOpener opens a relay stream to host:port inside a sandbox. The
+// returned cleanup function releases server-side resources.
+type sandboxRelayOpener func(ctx context.Context, host string, port int) (*websocket.Conn, func(), error)
+
+// newSandboxRelayOpener starts relay processes as CMD contexts of sandboxID.
+func newSandboxRelayOpener(client *sandbox0.Client, sandboxID string) sandboxRelayOpener {
+	sandbox := client.Sandbox(sandboxID)
+	return func(ctx context.Context, host string, port int) (*websocket.Conn, func(), error) {
+		command := []string{"python3", "-u", "-c", sandboxRelayScript, host, strconv.Itoa(port)}
+		contextResp, err := sandbox.CreateContext(ctx, apispec.CreateContextRequest{
+			Type:          apispec.NewOptProcessType(apispec.ProcessTypeCmd),
+			Cmd:           apispec.NewOptCreateCMDContextRequest(apispec.CreateCMDContextRequest{Command: command}),
+			WaitUntilDone: apispec.NewOptBool(false),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if contextResp == nil {
+			return nil, nil, fmt.Errorf("create context returned nil response")
+		}
+
+		cleanup := func() {
+			deleteCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_, _ = sandbox.DeleteContext(deleteCtx, contextResp.ID)
+		}
+		conn, _, err := sandbox.ConnectWSContext(ctx, contextResp.ID)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		return conn, cleanup, nil
+	}
+}
+
+// bridgeSandboxRelay copies bytes between local and a relay stream until the
+// remote side finishes or ctx is canceled. Local EOF is propagated as a
+// half-close so request/response protocols keep working.
+func bridgeSandboxRelay(ctx context.Context, conn *websocket.Conn, local io.ReadWriter) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var writeMu sync.Mutex
+	writeJSON := func(msg execWSMessage) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteJSON(msg)
+	}
+
+	go func() {
+		<-ctx.Done()
+		writeMu.Lock()
+		writeControlClose(func(messageType int, data []byte) error {
+			return conn.WriteControl(messageType, data, time.Now().Add(time.Second))
+		})
+		writeMu.Unlock()
+		_ = conn.Close()
+	}()
+
+	go func() {
+		buf := make([]byte, relayChunkSize)
+		for {
+			n, err := local.Read(buf)
+			if n > 0 {
+				line := base64.StdEncoding.EncodeToString(buf[:n]) + "\n"
+				if writeErr := writeJSON(execWSMessage{Type: "input", Data: line}); writeErr != nil {
+					cancel()
+					return
+				}
+			}
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					_ = writeJSON(execWSMessage{Type: "input", Data: relayEOFLine + "\n"})
+				} else {
+					cancel()
+				}
+				return
+			}
+		}
+	}()
+
+	var pending []byte
+	var stderr strings.Builder
+	for {
+		var msg execWSMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if isNormalWSClose(err) || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if isTerminalDoneExecMessage(msg) {
+			return relayExitError(msg.ExitCode, stderr.String())
+		}
+		if msg.Type != "" && msg.Type != "output" {
+			continue
+		}
+		if msg.Source == "stderr" {
+			stderr.WriteString(msg.Data)
+			continue
+		}
+
+		pending = append(pending, msg.Data...)
+		for {
+			idx := bytes.IndexByte(pending, '\n')
+			if idx < 0 {
+				break
+			}
+			line := bytes.TrimSpace(pending[:idx])
+			pending = pending[idx+1:]
+			if len(line) == 0 {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(string(line))
+			if err != nil {
+				return fmt.Errorf("invalid relay frame: %w", err)
+			}
+			if _, err := local.Write(data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func relayExitError(exitCode *int, stderr string) error {
+	if exitCode == nil || *exitCode == 0 {
+		return nil
+	}
+	if *exitCode == relayMissingExe {
+		return errSandboxRelayUnavailable
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Errorf("relay exited with code %d: %s", *exitCode, last)
+	}
+	return fmt.Errorf("relay exited with code %d", *exitCode)
+}
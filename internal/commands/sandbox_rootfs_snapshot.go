@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
 
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
 	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
 	"github.com/spf13/cobra"
 )
@@ -163,6 +165,12 @@ var sandboxSnapshotRestoreCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if sandboxWait {
+			if err := waitForSandboxRestore(cmd.Context(), client, response, sandboxWaitTimeout); err != nil {
+				exitSandboxWaitError(err)
+			}
+		}
+
 		if err := getFormatter().Format(os.Stdout, response); err != nil {
 			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
 			os.Exit(1)
@@ -170,6 +178,27 @@ var sandboxSnapshotRestoreCmd = &cobra.Command{
 	},
 }
 
+// waitForSandboxRestore waits for the restore described by response to finish
+// and updates its status. Restore requires a paused sandbox, so paused alone
+// does not show that the restore is done. A restore that finished before it
+// returned reports paused or running; any other reported status means it is
+// still in progress, and the wait ends once the sandbox leaves that status.
+func waitForSandboxRestore(ctx context.Context, client *sandbox0.Client, response *apispec.RestoreSandboxRootFSResponse, timeout time.Duration) error {
+	switch response.Status {
+	case apispec.SandboxLifecycleStatusPaused, apispec.SandboxLifecycleStatusRunning:
+		return nil
+	}
+	status, err := waitForSandboxStatus(ctx, client, response.SandboxID, timeout,
+		string(apispec.SandboxLifecycleStatusPaused), string(apispec.SandboxLifecycleStatusRunning))
+	if err != nil {
+		return err
+	}
+	if current, ok := status.Status.Get(); ok {
+		response.Status = current
+	}
+	return nil
+}
+
 // sandboxForkCmd creates a paused sandbox fork from a paused source sandbox.
 var sandboxForkCmd = &cobra.Command{
 	Use:   "fork <sandbox-id>",
@@ -185,12 +214,27 @@ var sandboxForkCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		response, err := client.ForkSandbox(cmd.Context(), sandboxID, buildSandboxForkRequest(cmd))
+		var response *apispec.ForkSandboxResponse
+		err = retryClaimStartThrottled(cmd.Context(), sandboxRetryThrottled, "Sandbox fork", func() error {
+			var err error
+			response, err = client.ForkSandbox(cmd.Context(), sandboxID, buildSandboxForkRequest(cmd))
+			return err
+		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error forking sandbox: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error forking sandbox: %s\n", formatSandboxCreateError(err))
 			os.Exit(1)
 		}
 
+		if sandboxWait {
+			status, err := waitForSandboxStatus(cmd.Context(), client, response.Sandbox.ID, sandboxWaitTimeout, string(apispec.SandboxLifecycleStatusPaused))
+			if err != nil {
+				exitSandboxWaitError(err)
+			}
+			if current, ok := status.Status.Get(); ok {
+				response.Sandbox.Status = current
+			}
+		}
+
 		if err := getFormatter().Format(os.Stdout, response); err != nil {
 			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
 			os.Exit(1)
@@ -204,6 +248,9 @@ func init() {
 	sandboxSnapshotCreateCmd.Flags().StringVar(&sandboxRootFSSnapshotExpiresAt, "expires-at", "", "snapshot expiration timestamp (RFC3339)")
 	sandboxForkCmd.Flags().Int32Var(&sandboxForkTTL, "ttl", 0, "soft TTL in seconds for the forked sandbox")
 	sandboxForkCmd.Flags().Int32Var(&sandboxForkHardTTL, "hard-ttl", 0, "hard TTL in seconds for the forked sandbox")
+	addSandboxWaitFlags(sandboxForkCmd, "paused")
+	addSandboxRetryThrottledFlag(sandboxForkCmd)
+	addSandboxWaitFlags(sandboxSnapshotRestoreCmd, "paused or running")
 
 	sandboxSnapshotCmd.AddCommand(sandboxSnapshotListCmd)
 	sandboxSnapshotCmd.AddCommand(sandboxSnapshotGetCmd)
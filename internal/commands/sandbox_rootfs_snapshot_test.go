@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
 	"github.com/spf13/cobra"
 )
 
@@ -123,3 +125,24 @@ func newSandboxForkFlagsForTest() *cobra.Command {
 	cmd.Flags().Int32Var(&sandboxForkHardTTL, "hard-ttl", 0, "hard TTL in seconds for the forked sandbox")
 	return cmd
 }
+
+func TestWaitForSandboxRestore(t *testing.T) {
+	useFastSandboxWaitPolling(t)
+
+	// A restore reported as finished is not polled.
+	client, calls := newSandboxStatusTestClient(t, "paused")
+	response := &apispec.RestoreSandboxRootFSResponse{SandboxID: "sb_1", Status: apispec.SandboxLifecycleStatusPaused}
+	if err := waitForSandboxRestore(context.Background(), client, response, time.Second); err != nil || *calls != 0 {
+		t.Fatalf("waitForSandboxRestore() error = %v, calls = %d; want no polling", err, *calls)
+	}
+
+	// A restore in progress is polled until the sandbox settles.
+	client, calls = newSandboxStatusTestClient(t, "starting", "starting", "paused")
+	response = &apispec.RestoreSandboxRootFSResponse{SandboxID: "sb_1", Status: apispec.SandboxLifecycleStatusStarting}
+	if err := waitForSandboxRestore(context.Background(), client, response, time.Second); err != nil {
+		t.Fatalf("waitForSandboxRestore() error = %v", err)
+	}
+	if *calls != 3 || response.Status != apispec.SandboxLifecycleStatusPaused {
+		t.Fatalf("calls = %d, status = %s; want 3 and paused", *calls, response.Status)
+	}
+}
@@ -44,6 +44,7 @@ var (
 	sessionExpectedAttemptID string
 	sessionEventAfter        int64
 	sessionEventLimit        int
+	sessionEventAll          bool
 	sessionEventFollow       bool
 	sessionEventLastID       string
 )
@@ -271,6 +272,9 @@ var sandboxSessionEventsCmd = &cobra.Command{
 			return err
 		}
 		sandbox := client.Sandbox(args[0])
+		if sessionEventFollow && sessionEventAll {
+			return fmt.Errorf("--all cannot be used with --follow")
+		}
 		if sessionEventFollow {
 			stream, err := sandbox.WatchSessionEvents(cmd.Context(), args[1], &sandbox0.SessionEventStreamOptions{
 				After: sessionEventAfter, LastEventID: sessionEventLastID,
@@ -292,6 +296,14 @@ var sandboxSessionEventsCmd = &cobra.Command{
 				}
 			}
 		}
+		if sessionEventAll {
+			if err := listAllSessionEvents(cmd.Context(), sandbox, args[1], sandbox0.SessionEventOptions{
+				After: sessionEventAfter, Limit: sessionEventLimit,
+			}); err != nil {
+				return fmt.Errorf("list session events: %w", err)
+			}
+			return nil
+		}
 		page, err := sandbox.ListSessionEvents(cmd.Context(), args[1], &sandbox0.SessionEventOptions{
 			After: sessionEventAfter, Limit: sessionEventLimit,
 		})
@@ -349,6 +361,7 @@ func init() {
 	addSessionExpectedAttemptFlag(sandboxSessionResizeCmd)
 	sandboxSessionEventsCmd.Flags().Int64Var(&sessionEventAfter, "after", 0, "return events after this sequence")
 	sandboxSessionEventsCmd.Flags().IntVar(&sessionEventLimit, "limit", 1000, "maximum retained events to return")
+	sandboxSessionEventsCmd.Flags().BoolVar(&sessionEventAll, "all", false, "fetch every retained page, using --limit as the page size")
 	sandboxSessionEventsCmd.Flags().BoolVarP(&sessionEventFollow, "follow", "f", false, "follow retained and live events over SSE")
 	sandboxSessionEventsCmd.Flags().StringVar(&sessionEventLastID, "last-event-id", "", "SSE resume cursor; takes precedence over --after")
 }
@@ -528,7 +541,7 @@ func writeExecutionSessionEvent(w io.Writer, event *apispec.ExecutionSessionEven
 	if cfgFormat == "json" {
 		return json.NewEncoder(w).Encode(event)
 	}
-	if cfgFormat == "yaml" {
+	if isStructuredOutput() {
 		return getFormatter().Format(w, event)
 	}
 	attempt := "-"
@@ -0,0 +1,321 @@
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sessionAttachReplay     int
+	sessionAttachDetachKeys string
+)
+
+// errSessionDetached ends an attachment when the detach keys are typed.
+var errSessionDetached = errors.New("detached")
+
+// sessionAttachReconnectDelay is the pause before the event stream is
+// reopened after the server closed it.
+var sessionAttachReconnectDelay = 500 * time.Millisecond
+
+var sandboxSessionAttachCmd = &cobra.Command{
+	Use:   "attach <sandbox-id> <session-id>",
+	Short: "Attach the terminal to an execution session",
+	Long: `Attach the terminal to an execution session, like tmux attach.
+
+Recent output is replayed from the event journal, then live output is
+followed. Keystrokes are sent to the session's current attempt and terminal
+size changes resize its PTY. Type the --detach-keys sequence to detach and
+leave the session running. The command ends with the session's exit code
+when the session stops.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		detachKeys, err := parseDetachKeys(sessionAttachDetachKeys)
+		if err != nil {
+			return err
+		}
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			return err
+		}
+		sandbox := client.Sandbox(args[0])
+		sessionID := args[1]
+
+		// SIGINT and SIGTERM detach; in raw mode ctrl-c is sent to the session
+		// as input instead.
+		ctx, cancel := signal.NotifyContext(cmd.Context(), forwardingSignals()...)
+		defer cancel()
+
+		interactive := isTerminalFile(os.Stdin)
+		restoreTerminal, err := prepareTerminalForStreaming(interactive)
+		if err != nil {
+			return err
+		}
+		defer restoreTerminal()
+		if interactive {
+			go forwardSessionResize(ctx, sandbox, sessionID)
+		}
+
+		code, err := attachSession(ctx, sandbox, sessionID, os.Stdin, os.Stdout, os.Stderr, sessionAttachReplay, detachKeys)
+		restoreTerminal()
+		if errors.Is(err, errSessionDetached) || (err != nil && ctx.Err() != nil) {
+			fmt.Fprintf(os.Stderr, "\nDetached from session %s\n", sessionID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if code != 0 {
+			os.Exit(code)
+		}
+		return nil
+	},
+}
+
+func init() {
+	sandboxSessionCmd.AddCommand(sandboxSessionAttachCmd)
+	sandboxSessionAttachCmd.Flags().IntVar(&sessionAttachReplay, "replay", 1000, "number of recent journal events to replay; 0 shows only new output")
+	sandboxSessionAttachCmd.Flags().StringVar(&sessionAttachDetachKeys, "detach-keys", "ctrl-b,d", "key sequence that detaches, such as ctrl-p,ctrl-q; empty disables detaching")
+}
+
+// attachSession replays recent output of the session, then follows it while
+// forwarding input from in. It returns when the session stops, with its exit
+// code, or with errSessionDetached when the detach keys are read.
+func attachSession(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string, in io.Reader, stdout, stderr io.Writer, replay int, detachKeys []byte) (int, error) {
+	session, err := sandbox.GetSession(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("get session: %w", err)
+	}
+
+	after := max(session.Cursor.Latest-int64(max(replay, 0)), 0)
+	exitCode := 0
+	if replay > 0 {
+		page, err := sandbox.ListSessionEvents(ctx, sessionID, &sandbox0.SessionEventOptions{After: after, Limit: replay})
+		if err != nil {
+			return 0, fmt.Errorf("list session events: %w", err)
+		}
+		for i := range page.Events {
+			event := &page.Events[i]
+			after = max(after, event.Seq)
+			code, exited, err := handleSessionPipeEvent(event, stdout, stderr)
+			if exited {
+				exitCode = code
+			} else if err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		after = session.Cursor.Latest
+	}
+	if slices.Contains(workspaceSessionEndedPhases, session.Phase) {
+		// The replay may not reach back to the exit event, so the last
+		// attempt reported with the session decides the exit code.
+		return sessionExitCode(session, exitCode), nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go func() {
+		cancel(forwardSessionInput(ctx, sandbox, sessionID, in, detachKeys))
+	}()
+
+	for {
+		stream, err := sandbox.WatchSessionEvents(ctx, sessionID, &sandbox0.SessionEventStreamOptions{After: after})
+		if err != nil {
+			return 0, attachStreamError(ctx, fmt.Errorf("watch session events: %w", err))
+		}
+		code, ended, err := followSessionEvents(ctx, sandbox, sessionID, stream, &after, stdout, stderr)
+		_ = stream.Close()
+		if err != nil {
+			return 0, attachStreamError(ctx, err)
+		}
+		if ended {
+			return code, nil
+		}
+		select {
+		case <-ctx.Done():
+			return 0, attachStreamError(ctx, ctx.Err())
+		case <-time.After(sessionAttachReconnectDelay):
+		}
+	}
+}
+
+// followSessionEvents writes output from stream until it closes or the
+// session stops. after tracks the last event written so a reconnect does
+// not repeat output.
+func followSessionEvents(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string, stream *sandbox0.SessionEventStream, after *int64, stdout, stderr io.Writer) (int, bool, error) {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("read session event: %w", err)
+		}
+		if event.Seq <= *after {
+			continue
+		}
+		*after = event.Seq
+		code, exited, err := handleSessionPipeEvent(event, stdout, stderr)
+		if !exited {
+			if err != nil {
+				return 0, false, err
+			}
+			continue
+		}
+		// An attempt exited; the session only stops if it will not restart.
+		session, getErr := sandbox.GetSession(ctx, sessionID)
+		if getErr != nil {
+			return 0, false, fmt.Errorf("get session: %w", getErr)
+		}
+		if slices.Contains(workspaceSessionEndedPhases, session.Phase) {
+			return sessionExitCode(session, code), true, err
+		}
+	}
+}
+
+// sessionExitCode returns the exit code of the session's last attempt, or
+// fallback when the session does not report one.
+func sessionExitCode(session *apispec.ExecutionSession, fallback int) int {
+	if attempt, ok := session.Attempt.Get(); ok {
+		if code, ok := attempt.ExitCode.Get(); ok {
+			return int(code)
+		}
+	}
+	return fallback
+}
+
+// attachStreamError reports the reason the attachment was cancelled, such
+// as errSessionDetached, instead of the resulting stream error.
+func attachStreamError(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	return err
+}
+
+// forwardSessionInput writes input from in to the session until the detach
+// keys are read, and returns the reason it stopped. End of input leaves the
+// attachment following output.
+func forwardSessionInput(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string, in io.Reader, detachKeys []byte) error {
+	detector := &detachDetector{keys: detachKeys}
+	buf := make([]byte, 4096)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			data, detached := detector.feed(buf[:n])
+			if len(data) > 0 {
+				if _, writeErr := sandbox.WriteSessionInput(ctx, sessionID, apispec.ExecutionSessionInputRequest{
+					InputID:    uuid.NewString(),
+					DataBase64: apispec.NewOptString(base64.StdEncoding.EncodeToString(data)),
+				}); writeErr != nil {
+					return fmt.Errorf("write session input: %w", writeErr)
+				}
+			}
+			if detached {
+				return errSessionDetached
+			}
+		}
+		if err != nil {
+			<-ctx.Done()
+			return nil
+		}
+	}
+}
+
+// forwardSessionResize sets the session's PTY to the terminal size, and
+// again whenever the terminal is resized.
+func forwardSessionResize(ctx context.Context, sandbox *sandbox0.Sandbox, sessionID string) {
+	resize := func() {
+		rows, cols := currentTerminalSize()
+		_, _ = sandbox.ResizeSessionTerminal(ctx, sessionID, apispec.ExecutionSessionTerminalResizeRequest{Rows: rows, Cols: cols})
+	}
+	resize()
+	signals := resizeSignals()
+	if len(signals) == 0 {
+		return
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+	defer signal.Stop(sigCh)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigCh:
+			resize()
+		}
+	}
+}
+
+// parseDetachKeys parses a comma-separated key sequence such as
+// "ctrl-b,d". Keys are single characters or ctrl- followed by a letter or
+// one of @[\]^_.
+func parseDetachKeys(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var keys []byte
+	for _, key := range strings.Split(value, ",") {
+		name, ctrl := strings.CutPrefix(strings.TrimSpace(key), "ctrl-")
+		switch {
+		case len(name) != 1:
+			return nil, fmt.Errorf("invalid detach key %q", key)
+		case !ctrl:
+			keys = append(keys, name[0])
+		case (name[0] >= 'a' && name[0] <= 'z') || (name[0] >= '@' && name[0] <= '_'):
+			keys = append(keys, name[0]&0x1f)
+		default:
+			return nil, fmt.Errorf("invalid detach key %q", key)
+		}
+	}
+	return keys, nil
+}
+
+// detachDetector finds the detach key sequence in terminal input. Bytes
+// that may start the sequence are held back until the next key shows
+// whether they belong to it.
+type detachDetector struct {
+	keys    []byte
+	matched int
+}
+
+// feed returns the input to forward, and whether the sequence completed.
+func (d *detachDetector) feed(data []byte) ([]byte, bool) {
+	if len(d.keys) == 0 {
+		return data, false
+	}
+	var out []byte
+	for _, b := range data {
+		if b == d.keys[d.matched] {
+			d.matched++
+			if d.matched == len(d.keys) {
+				d.matched = 0
+				return out, true
+			}
+			continue
+		}
+		// A broken sequence is forwarded as typed; the current key may
+		// start a new one.
+		out = append(out, d.keys[:d.matched]...)
+		d.matched = 0
+		if b == d.keys[0] {
+			d.matched = 1
+			continue
+		}
+		out = append(out, b)
+	}
+	return out, false
+}
@@ -0,0 +1,205 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func TestParseDetachKeys(t *testing.T) {
+	keys, err := parseDetachKeys("ctrl-b, d")
+	if err != nil || !bytes.Equal(keys, []byte{0x02, 'd'}) {
+		t.Fatalf("parseDetachKeys(ctrl-b,d) = %v, %v", keys, err)
+	}
+	keys, err = parseDetachKeys("ctrl-P,ctrl-q")
+	if err != nil || !bytes.Equal(keys, []byte{0x10, 0x11}) {
+		t.Fatalf("parseDetachKeys(ctrl-P,ctrl-q) = %v, %v", keys, err)
+	}
+	if keys, err := parseDetachKeys(""); err != nil || keys != nil {
+		t.Fatalf("parseDetachKeys(\"\") = %v, %v", keys, err)
+	}
+	for _, value := range []string{"ctrl-", "ctrl-1", "ab"} {
+		if _, err := parseDetachKeys(value); err == nil {
+			t.Fatalf("parseDetachKeys(%q) error = nil", value)
+		}
+	}
+}
+
+func TestDetachDetector(t *testing.T) {
+	d := &detachDetector{keys: []byte{0x02, 'd'}}
+	if out, detached := d.feed([]byte("ls\x02")); string(out) != "ls" || detached {
+		t.Fatalf("feed(ls ctrl-b) = %q, %v", out, detached)
+	}
+	// The prefix followed by another key is forwarded as typed.
+	if out, detached := d.feed([]byte("x\x02\x02")); string(out) != "\x02x\x02" || detached {
+		t.Fatalf("feed(x ctrl-b ctrl-b) = %q, %v", out, detached)
+	}
+	if out, detached := d.feed([]byte("drest")); len(out) != 0 || !detached {
+		t.Fatalf("feed(d) = %q, %v, want detach", out, detached)
+	}
+	if out, detached := (&detachDetector{}).feed([]byte("\x02d")); string(out) != "\x02d" || detached {
+		t.Fatalf("disabled detector = %q, %v", out, detached)
+	}
+}
+
+// fakeAttachServer serves a session, its event journal, and its input
+// endpoint. Live events are written to the event stream in order; when
+// holdStream is set the stream then stays open until the client leaves.
+type fakeAttachServer struct {
+	t          *testing.T
+	replay     []apispec.ExecutionSessionEvent
+	live       []apispec.ExecutionSessionEvent
+	holdStream bool
+	// attempt, when set, is reported as the session's last attempt.
+	attempt *apispec.ExecutionSessionAttempt
+
+	mu     sync.Mutex
+	phase  apispec.ExecutionSessionPhase
+	inputs bytes.Buffer
+}
+
+func (f *fakeAttachServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	base := "/api/v1/sandboxes/sb_1/sessions/ses_1"
+	switch r.URL.Path {
+	case base:
+		f.mu.Lock()
+		phase := f.phase
+		f.mu.Unlock()
+		session := &apispec.ExecutionSession{
+			ID: "ses_1", Spec: testSessionSpec("shell", "bash"), Phase: phase,
+			Cursor:    apispec.ExecutionSessionEventCursor{Earliest: 1, Latest: int64(len(f.replay))},
+			CreatedAt: now, UpdatedAt: now, LastActivityAt: now,
+		}
+		if f.attempt != nil {
+			session.Attempt = apispec.NewOptExecutionSessionAttempt(*f.attempt)
+		}
+		writeTestSuccess(f.t, w, session)
+	case base + "/events":
+		writeTestSuccess(f.t, w, &apispec.ExecutionSessionEventPage{
+			Events: f.replay,
+			Cursor: apispec.ExecutionSessionEventCursor{Earliest: 1, Latest: int64(len(f.replay))},
+		})
+	case base + "/events/stream":
+		w.Header().Set("Content-Type", "text/event-stream")
+		// Replay overlaps the journal page; the client must skip it.
+		for _, event := range append(f.replay, f.live...) {
+			raw, err := json.Marshal(&event)
+			if err != nil {
+				f.t.Errorf("marshal event: %v", err)
+			}
+			if event.ExitCode.Set {
+				f.mu.Lock()
+				f.phase = apispec.ExecutionSessionPhaseExited
+				f.mu.Unlock()
+			}
+			_, _ = fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.Seq, raw)
+		}
+		w.(http.Flusher).Flush()
+		if f.holdStream {
+			<-r.Context().Done()
+		}
+	case base + "/inputs":
+		var request apispec.ExecutionSessionInputRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			f.t.Errorf("decode input: %v", err)
+		}
+		data, _ := base64.StdEncoding.DecodeString(request.DataBase64.Or(""))
+		f.mu.Lock()
+		f.inputs.Write(data)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{"input_id":%q,"attempt_id":"att_1","accepted":true,"duplicate":false}}`, request.InputID)
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testOutputEvent(seq int64, data string) apispec.ExecutionSessionEvent {
+	return apispec.ExecutionSessionEvent{
+		Seq: seq, SessionID: "ses_1", Type: "output",
+		Stream:     apispec.NewOptExecutionSessionEventStream(apispec.ExecutionSessionEventStreamPty),
+		DataBase64: apispec.NewOptString(base64.StdEncoding.EncodeToString([]byte(data))),
+		OccurredAt: time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAttachSessionFollowsUntilExit(t *testing.T) {
+	exit := apispec.ExecutionSessionEvent{Seq: 3, SessionID: "ses_1", Type: "exited", ExitCode: apispec.NewOptInt32(3), OccurredAt: time.Now()}
+	server := &fakeAttachServer{
+		t:      t,
+		phase:  apispec.ExecutionSessionPhaseRunning,
+		replay: []apispec.ExecutionSessionEvent{testOutputEvent(1, "hello\r\n")},
+		live:   []apispec.ExecutionSessionEvent{testOutputEvent(2, "world\r\n"), exit},
+	}
+	client := newTestSDKClient(t, server.ServeHTTP)
+
+	var stdout bytes.Buffer
+	code, err := attachSession(context.Background(), client.Sandbox("sb_1"), "ses_1", strings.NewReader(""), &stdout, io.Discard, 100, []byte{0x02, 'd'})
+	if err != nil {
+		t.Fatalf("attachSession() error = %v", err)
+	}
+	if code != 3 {
+		t.Fatalf("exit code = %d, want 3", code)
+	}
+	if stdout.String() != "hello\r\nworld\r\n" {
+		t.Fatalf("stdout = %q, want replay then live output without duplicates", stdout.String())
+	}
+}
+
+func TestAttachSessionEndedUsesAttemptExitCode(t *testing.T) {
+	server := &fakeAttachServer{
+		t:       t,
+		phase:   apispec.ExecutionSessionPhaseExited,
+		replay:  []apispec.ExecutionSessionEvent{testOutputEvent(1, "done\r\n")},
+		attempt: &apispec.ExecutionSessionAttempt{ID: "att_1", Number: 1, ExitCode: apispec.NewOptInt32(5)},
+	}
+	client := newTestSDKClient(t, server.ServeHTTP)
+
+	for _, replay := range []int{0, 100} {
+		code, err := attachSession(context.Background(), client.Sandbox("sb_1"), "ses_1", strings.NewReader(""), io.Discard, io.Discard, replay, nil)
+		if err != nil {
+			t.Fatalf("attachSession(replay=%d) error = %v", replay, err)
+		}
+		if code != 5 {
+			t.Fatalf("attachSession(replay=%d) exit code = %d, want the last attempt's 5", replay, code)
+		}
+	}
+}
+
+func TestAttachSessionDetaches(t *testing.T) {
+	server := &fakeAttachServer{
+		t:          t,
+		phase:      apispec.ExecutionSessionPhaseRunning,
+		replay:     []apispec.ExecutionSessionEvent{testOutputEvent(1, "$ ")},
+		holdStream: true,
+	}
+	client := newTestSDKClient(t, server.ServeHTTP)
+
+	var stdout bytes.Buffer
+	_, err := attachSession(context.Background(), client.Sandbox("sb_1"), "ses_1", strings.NewReader("ls\r\x02d"), &stdout, io.Discard, 100, []byte{0x02, 'd'})
+	if !errors.Is(err, errSessionDetached) {
+		t.Fatalf("attachSession() error = %v, want detached", err)
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if server.inputs.String() != "ls\r" {
+		t.Fatalf("inputs = %q, want keystrokes before the detach keys", server.inputs.String())
+	}
+	if server.phase != apispec.ExecutionSessionPhaseRunning {
+		t.Fatalf("phase = %s, detaching must leave the session running", server.phase)
+	}
+}
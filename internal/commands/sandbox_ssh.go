@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/sandbox0-ai/s0/internal/config"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sshUser         string
+	sshIdentityFile string
+)
+
+// sandboxSSHCmd opens an SSH session to a sandbox.
+var sandboxSSHCmd = &cobra.Command{
+	Use:   "ssh <sandbox-id> [-- <command> [args...]]",
+	Short: "Open an SSH session to a sandbox",
+	Long: `Open an SSH session to a sandbox using the local OpenSSH client.
+
+Authentication uses the public keys registered with 's0 user ssh-key add'.
+The connection is proxied through 's0 sandbox ssh-proxy', so no extra SSH
+configuration is required. Arguments after '--' run as a remote command.
+
+Examples:
+  s0 sandbox ssh sb_abc123
+  s0 sandbox ssh sb_abc123 -i ~/.ssh/id_ed25519 -- uname -a`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+		remoteCommand := args[1:]
+
+		sshPath, err := exec.LookPath("ssh")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: ssh client not found in PATH\n")
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		user, err := resolveSandboxSSHUser(cmd.Context(), client, sandboxID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting sandbox: %v\n", err)
+			os.Exit(1)
+		}
+		proxyCommand, err := sandboxSSHProxyCommand()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		sshCmd := exec.Command(sshPath, buildSandboxSSHArgs(sandboxID, user, proxyCommand, sshIdentityFile, remoteCommand)...)
+		sshCmd.Stdin = os.Stdin
+		sshCmd.Stdout = os.Stdout
+		sshCmd.Stderr = os.Stderr
+
+		// ssh handles interrupts itself; keep them from terminating the wrapper.
+		signal.Ignore(forwardingSignals()...)
+		if err := sshCmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			fmt.Fprintf(os.Stderr, "Error running ssh: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// sandboxSSHConfigCmd prints an OpenSSH configuration block for sandboxes.
+var sandboxSSHConfigCmd = &cobra.Command{
+	Use:   "ssh-config <sandbox-id> [<sandbox-id>...]",
+	Short: "Print OpenSSH configuration for sandboxes",
+	Long: `Print OpenSSH Host blocks that connect through 's0 sandbox ssh-proxy'.
+
+Append the output to ~/.ssh/config to use ssh, scp, rsync -e ssh, or VS Code
+Remote-SSH with the sandbox ID as the host name.
+
+Examples:
+  s0 sandbox ssh-config sb_abc123 >> ~/.ssh/config
+  scp ./data.csv sb_abc123:/workspace/`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+		proxyCommand, err := sandboxSSHProxyCommand()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		hosts := make([]sandboxSSHHost, 0, len(args))
+		for _, sandboxID := range args {
+			user, err := resolveSandboxSSHUser(cmd.Context(), client, sandboxID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting sandbox %s: %v\n", sandboxID, err)
+				os.Exit(1)
+			}
+			hosts = append(hosts, sandboxSSHHost{Alias: sandboxID, User: user})
+		}
+
+		fmt.Print(renderSandboxSSHConfig(hosts, proxyCommand, sshIdentityFile))
+	},
+}
+
+// sandboxSSHProxyCmd bridges stdin/stdout to a sandbox SSH endpoint.
+var sandboxSSHProxyCmd = &cobra.Command{
+	Use:   "ssh-proxy <sandbox-id>",
+	Short: "Bridge stdin/stdout to a sandbox SSH endpoint",
+	Long: `Bridge stdin and stdout to the SSH endpoint of a sandbox.
+
+This command is meant to be used as an OpenSSH ProxyCommand, for example
+'ProxyCommand s0 sandbox ssh-proxy %h'. It connects to the SSH endpoint reported
+for the sandbox by its home-region gateway. When the endpoint has no host, the
+host of the resolved gateway is used. Nothing needs to be installed in the
+sandbox image.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+
+		client, target, err := getClientRawWithTarget(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, cancel := signal.NotifyContext(cmd.Context(), forwardingSignals()...)
+		defer cancel()
+
+		if err := runSandboxSSHProxy(ctx, client, target.BaseURL, sandboxID, os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error proxying SSH connection: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// sandboxSSHHost is one Host block of generated SSH configuration.
+type sandboxSSHHost struct {
+	Alias string
+	User  string
+}
+
+// resolveSandboxSSHUser returns the SSH user name, preferring --user over the
+// user name reported by the sandbox.
+func resolveSandboxSSHUser(ctx context.Context, client *sandbox0.Client, sandboxID string) (string, error) {
+	if strings.TrimSpace(sshUser) != "" {
+		return strings.TrimSpace(sshUser), nil
+	}
+	sandbox, err := client.GetSandbox(ctx, sandboxID)
+	if err != nil {
+		return "", err
+	}
+	if conn, ok := sandbox.SSH.Get(); ok {
+		return conn.Username, nil
+	}
+	return "", nil
+}
+
+// sandboxSSHProxyCommand returns the ProxyCommand that invokes this binary,
+// preserving the selected config file and profile.
+func sandboxSSHProxyCommand() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("locate s0 executable: %w", err)
+	}
+	return buildSandboxSSHProxyCommand(executable, *config.GetConfigFile(), *config.GetProfileVar()), nil
+}
+
+func buildSandboxSSHProxyCommand(executable, configFile, profile string) string {
+	parts := []string{shellQuote(executable)}
+	if configFile != "" {
+		parts = append(parts, "--config", shellQuote(configFile))
+	}
+	if profile != "" {
+		parts = append(parts, "--profile", shellQuote(profile))
+	}
+	parts = append(parts, "sandbox", "ssh-proxy", "%h")
+	return strings.Join(parts, " ")
+}
+
+func buildSandboxSSHArgs(sandboxID, user, proxyCommand, identityFile string, remoteCommand []string) []string {
+	args := []string{"-o", "ProxyCommand=" + proxyCommand}
+	if user != "" {
+		args = append(args, "-l", user)
+	}
+	if identityFile != "" {
+		args = append(args, "-i", identityFile)
+	}
+	args = append(args, sandboxID)
+	if len(remoteCommand) > 0 {
+		args = append(args, "--")
+		args = append(args, remoteCommand...)
+	}
+	return args
+}
+
+func renderSandboxSSHConfig(hosts []sandboxSSHHost, proxyCommand, identityFile string) string {
+	var b strings.Builder
+	for i, host := range hosts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Host %s\n", host.Alias)
+		if host.User != "" {
+			fmt.Fprintf(&b, "  User %s\n", host.User)
+		}
+		fmt.Fprintf(&b, "  ProxyCommand %s\n", proxyCommand)
+		if identityFile != "" {
+			fmt.Fprintf(&b, "  IdentityFile %s\n", identityFile)
+			b.WriteString("  IdentitiesOnly yes\n")
+		}
+	}
+	return b.String()
+}
+
+// shellQuote quotes value for the POSIX shell that OpenSSH uses to run
+// ProxyCommand.
+func shellQuote(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@+=,", r))
+	}) < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// runSandboxSSHProxy connects stdin/stdout to the SSH endpoint of the sandbox
+// as reported by the gateway at gatewayURL.
+func runSandboxSSHProxy(ctx context.Context, client *sandbox0.Client, gatewayURL, sandboxID string, stdin io.Reader, stdout io.Writer) error {
+	sandbox, err := client.GetSandbox(ctx, sandboxID)
+	if err != nil {
+		return err
+	}
+	endpoint, err := sandboxSSHEndpoint(sandbox, gatewayURL)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return err
+	}
+	return pipeSSHConnection(ctx, conn, stdin, stdout)
+}
+
+// sandboxSSHEndpoint returns the host:port of the sandbox SSH endpoint. An
+// endpoint without a host is served by the gateway at gatewayURL.
+func sandboxSSHEndpoint(sandbox *apispec.Sandbox, gatewayURL string) (string, error) {
+	conn, ok := sandbox.SSH.Get()
+	if !ok || conn.Port <= 0 {
+		return "", fmt.Errorf("sandbox %s does not report an SSH endpoint", sandbox.ID)
+	}
+	host := conn.Host
+	if host == "" {
+		parsed, err := url.Parse(gatewayURL)
+		if err != nil || parsed.Hostname() == "" {
+			return "", fmt.Errorf("sandbox %s reports no SSH host and gateway URL %q has none", sandbox.ID, gatewayURL)
+		}
+		host = parsed.Hostname()
+	}
+	return net.JoinHostPort(host, strconv.Itoa(conn.Port)), nil
+}
+
+// pipeSSHConnection copies between conn and stdio until the server closes
+// the connection. Local EOF half-closes the connection.
+func pipeSSHConnection(ctx context.Context, conn net.Conn, stdin io.Reader, stdout io.Writer) error {
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	go func() {
+		_, _ = io.Copy(conn, stdin)
+		if tcpConn, ok := conn.(interface{ CloseWrite() error }); ok {
+			_ = tcpConn.CloseWrite()
+		}
+	}()
+
+	_, err := io.Copy(stdout, conn)
+	if err != nil && (ctx.Err() != nil || errors.Is(err, net.ErrClosed)) {
+		return nil
+	}
+	return err
+}
+
+func init() {
+	sandboxSSHCmd.Flags().StringVarP(&sshUser, "user", "l", "", "SSH user name (defaults to the user reported by the sandbox)")
+	sandboxSSHCmd.Flags().StringVarP(&sshIdentityFile, "identity-file", "i", "", "private key for a registered SSH public key")
+	sandboxSSHConfigCmd.Flags().StringVarP(&sshUser, "user", "l", "", "SSH user name (defaults to the user reported by the sandbox)")
+	sandboxSSHConfigCmd.Flags().StringVarP(&sshIdentityFile, "identity-file", "i", "", "private key for a registered SSH public key")
+
+	sandboxCmd.AddCommand(sandboxSSHCmd)
+	sandboxCmd.AddCommand(sandboxSSHConfigCmd)
+	sandboxCmd.AddCommand(sandboxSSHProxyCmd)
+}
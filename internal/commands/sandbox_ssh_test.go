@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func TestSandboxSSHCommandRegistration(t *testing.T) {
+	subcommands := map[string]bool{}
+	for _, cmd := range sandboxCmd.Commands() {
+		subcommands[cmd.Name()] = true
+	}
+	for _, name := range []string{"ssh", "ssh-config", "ssh-proxy"} {
+		if !subcommands[name] {
+			t.Fatalf("expected subcommand %q to be registered", name)
+		}
+	}
+}
+
+func TestBuildSandboxSSHProxyCommand(t *testing.T) {
+	got := buildSandboxSSHProxyCommand("/usr/local/bin/s0", "", "")
+	if got != "/usr/local/bin/s0 sandbox ssh-proxy %h" {
+		t.Fatalf("proxy command = %q", got)
+	}
+
+	got = buildSandboxSSHProxyCommand("/Users/me/My Tools/s0", "/tmp/s0.yaml", "prod")
+	want := "'/Users/me/My Tools/s0' --config /tmp/s0.yaml --profile prod sandbox ssh-proxy %h"
+	if got != want {
+		t.Fatalf("proxy command = %q, want %q", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":      "plain",
+		"with space": "'with space'",
+		"it's":       `'it'\''s'`,
+		"":           "''",
+		"/a/b-c_d.e": "/a/b-c_d.e",
+	}
+	for input, want := range tests {
+		if got := shellQuote(input); got != want {
+			t.Fatalf("shellQuote(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestBuildSandboxSSHArgs(t *testing.T) {
+	got := buildSandboxSSHArgs("sb_1", "sandbox", "s0 sandbox ssh-proxy %h", "~/.ssh/id", []string{"uname", "-a"})
+	want := []string{"-o", "ProxyCommand=s0 sandbox ssh-proxy %h", "-l", "sandbox", "-i", "~/.ssh/id", "sb_1", "--", "uname", "-a"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("buildSandboxSSHArgs() = %#v, want %#v", got, want)
+	}
+}
+
+func TestRenderSandboxSSHConfig(t *testing.T) {
+	got := renderSandboxSSHConfig([]sandboxSSHHost{
+		{Alias: "sb_1", User: "sandbox"},
+		{Alias: "sb_2"},
+	}, "s0 sandbox ssh-proxy %h", "~/.ssh/id_ed25519")
+	want := `Host sb_1
+  User sandbox
+  ProxyCommand s0 sandbox ssh-proxy %h
+  IdentityFile ~/.ssh/id_ed25519
+  IdentitiesOnly yes
+
+Host sb_2
+  ProxyCommand s0 sandbox ssh-proxy %h
+  IdentityFile ~/.ssh/id_ed25519
+  IdentitiesOnly yes
+`
+	if got != want {
+		t.Fatalf("renderSandboxSSHConfig() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPipeSSHConnection(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		_, _ = conn.Write([]byte("SSH-2.0-stand-in\r\n" + string(data)))
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	var stdout bytes.Buffer
+	if err := pipeSSHConnection(context.Background(), conn, strings.NewReader("SSH-2.0-client\r\n"), &stdout); err != nil {
+		t.Fatalf("pipeSSHConnection() error = %v", err)
+	}
+	if stdout.String() != "SSH-2.0-stand-in\r\nSSH-2.0-client\r\n" {
+		t.Fatalf("stdout = %q", stdout.String())
+	}
+}
+
+func TestSandboxSSHEndpoint(t *testing.T) {
+	sandbox := &apispec.Sandbox{ID: "sb_1"}
+	if _, err := sandboxSSHEndpoint(sandbox, "https://api.sandbox0.ai"); err == nil {
+		t.Fatal("sandboxSSHEndpoint() error = nil for a sandbox without SSH")
+	}
+
+	sandbox.SSH = apispec.NewOptSandboxSSHConnection(apispec.SandboxSSHConnection{Host: "ssh.aws-us-east-1.sandbox0.ai", Port: 2222})
+	got, err := sandboxSSHEndpoint(sandbox, "https://api.sandbox0.ai")
+	if err != nil || got != "ssh.aws-us-east-1.sandbox0.ai:2222" {
+		t.Fatalf("sandboxSSHEndpoint() = %q, %v", got, err)
+	}
+
+	// Without a host, the endpoint is served by the resolved gateway.
+	sandbox.SSH = apispec.NewOptSandboxSSHConnection(apispec.SandboxSSHConnection{Port: 2222})
+	got, err = sandboxSSHEndpoint(sandbox, "https://aws-us-east-1.sandbox0.ai:8443")
+	if err != nil || got != "aws-us-east-1.sandbox0.ai:2222" {
+		t.Fatalf("sandboxSSHEndpoint() = %q, %v", got, err)
+	}
+}
@@ -1,12 +1,14 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	sandbox0 "github.com/sandbox0-ai/sdk-go"
 	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
@@ -70,6 +72,53 @@ func TestFormatSandboxCreateErrorNonThrottled(t *testing.T) {
 	}
 }
 
+func TestRetryClaimStartThrottledHonorsRetryAfter(t *testing.T) {
+	var delays []time.Duration
+	sleep := throttleRetrySleep
+	throttleRetrySleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() { throttleRetrySleep = sleep })
+
+	throttled := &sandbox0.APIError{StatusCode: 429, Code: sandbox0.CodeClaimStartThrottled, RetryAfterSeconds: 2}
+	calls := 0
+	err := retryClaimStartThrottled(context.Background(), time.Minute, "Sandbox claim", func() error {
+		calls++
+		if calls < 3 {
+			return throttled
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("retryClaimStartThrottled() = %v after %d calls, want nil after 3", err, calls)
+	}
+	for _, delay := range delays {
+		if delay < 2*time.Second || delay > 2400*time.Millisecond {
+			t.Fatalf("delay = %s, want Retry-After of 2s plus at most 20%% jitter", delay)
+		}
+	}
+
+	calls = 0
+	err = retryClaimStartThrottled(context.Background(), time.Second, "Sandbox claim", func() error {
+		calls++
+		return throttled
+	})
+	if !errors.Is(err, throttled) || calls != 1 {
+		t.Fatalf("retry beyond budget = %v after %d calls, want throttled error after 1", err, calls)
+	}
+
+	calls = 0
+	failure := errors.New("request failed")
+	err = retryClaimStartThrottled(context.Background(), time.Minute, "Sandbox claim", func() error {
+		calls++
+		return failure
+	})
+	if !errors.Is(err, failure) || calls != 1 {
+		t.Fatalf("non-throttled error = %v after %d calls, want no retry", err, calls)
+	}
+}
+
 func TestBuildSandboxCreateConfig(t *testing.T) {
 	resetSandboxFlagsForTest()
 
@@ -98,9 +147,9 @@ network:
               valueTemplate: "Bearer {{ .token }}"
 `)
 
-		request, err := buildSandboxCreateRequest()
+		request, err := buildSandboxCreateRequest(nil)
 		if err != nil {
-			t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+			t.Fatalf("buildSandboxCreateRequest(nil) error = %v", err)
 		}
 		template, ok := request.Template.Get()
 		if !ok || template != "default" {
@@ -132,9 +181,9 @@ hard_ttl: 120
 		sandboxTTL = 300
 		sandboxMemory = "512Mi"
 
-		request, err := buildSandboxCreateRequest()
+		request, err := buildSandboxCreateRequest(nil)
 		if err != nil {
-			t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+			t.Fatalf("buildSandboxCreateRequest(nil) error = %v", err)
 		}
 		config, ok := request.Config.Get()
 		if !ok {
@@ -170,9 +219,9 @@ config:
   ttl: 90
 `)
 
-		request, err := buildSandboxCreateRequest()
+		request, err := buildSandboxCreateRequest(nil)
 		if err != nil {
-			t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+			t.Fatalf("buildSandboxCreateRequest(nil) error = %v", err)
 		}
 		template, ok := request.Template.Get()
 		if !ok || template != "from-file" {
@@ -195,9 +244,9 @@ config:
 		sandboxTemplate = "default"
 		sandboxMounts = []string{"vol_abc:/workspace/bootstrap-data"}
 
-		request, err := buildSandboxCreateRequest()
+		request, err := buildSandboxCreateRequest(nil)
 		if err != nil {
-			t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+			t.Fatalf("buildSandboxCreateRequest(nil) error = %v", err)
 		}
 		if len(request.Mounts) != 1 {
 			t.Fatalf("mount count = %d, want 1", len(request.Mounts))
@@ -217,9 +266,9 @@ mounts:
 `)
 		sandboxMounts = []string{"vol_flag:/workspace/from-flag"}
 
-		request, err := buildSandboxCreateRequest()
+		request, err := buildSandboxCreateRequest(nil)
 		if err != nil {
-			t.Fatalf("buildSandboxCreateRequest() error = %v", err)
+			t.Fatalf("buildSandboxCreateRequest(nil) error = %v", err)
 		}
 		template, ok := request.Template.Get()
 		if !ok || template != "flag-template" {
@@ -242,9 +291,9 @@ mounts:
 		sandboxTemplate = "default"
 		sandboxMounts = []string{"missing-separator"}
 
-		_, err := buildSandboxCreateRequest()
+		_, err := buildSandboxCreateRequest(nil)
 		if err == nil {
-			t.Fatal("buildSandboxCreateRequest() error = nil, want error")
+			t.Fatal("buildSandboxCreateRequest(nil) error = nil, want error")
 		}
 	})
 
@@ -253,9 +302,9 @@ mounts:
 		sandboxTemplate = "default"
 		sandboxMounts = []string{"vol_abc:workspace/relative"}
 
-		_, err := buildSandboxCreateRequest()
+		_, err := buildSandboxCreateRequest(nil)
 		if err == nil {
-			t.Fatal("buildSandboxCreateRequest() error = nil, want error")
+			t.Fatal("buildSandboxCreateRequest(nil) error = nil, want error")
 		}
 	})
 }
@@ -527,17 +576,32 @@ func resetSandboxFlagsForTest() {
 	sandboxConfigFile = ""
 	sandboxMounts = nil
 	sandboxSnapshotID = ""
+	sandboxWait = false
+	sandboxWaitTimeout = 0
+	sandboxRetryThrottled = 0
+	sandboxAliasName = ""
+	sandboxDevcontainer = ""
+	sandboxBulkAll = false
+	sandboxBulkStatus = ""
+	sandboxBulkTemplateID = ""
+	sandboxBulkPaused = ""
+	sandboxBulkOlderThan = 0
+	sandboxBulkDryRun = false
+	sandboxBulkYes = false
+	sandboxBulkConcurrency = 4
 	sandboxListStatus = ""
 	sandboxListTemplateID = ""
 	sandboxListPaused = ""
 	sandboxListLimit = 0
 	sandboxListOffset = 0
+	sandboxListAll = false
 	sandboxObsLimit = 0
 	sandboxObsCursor = ""
 	sandboxObsStartTime = ""
 	sandboxObsEndTime = ""
 	sandboxObsSince = ""
 	sandboxObsWatch = false
+	sandboxObsAll = false
 	sandboxObsContextID = ""
 	sandboxObsStream = ""
 	sandboxObsNames = nil
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of wait operations. Other errors exit with 1.
+const (
+	sandboxWaitExitFailed  = 2
+	sandboxWaitExitTimeout = 3
+)
+
+// sandboxStatusDeleted is the wait target reached when the sandbox no longer
+// exists. It is not a lifecycle status reported by the API.
+const sandboxStatusDeleted = "deleted"
+
+var (
+	sandboxWait        bool
+	sandboxWaitTimeout time.Duration
+	sandboxWaitFor     string
+
+	// Polling backoff of waitForSandboxStatus.
+	sandboxWaitPollInterval    = 500 * time.Millisecond
+	sandboxWaitMaxPollInterval = 5 * time.Second
+)
+
+// errSandboxWaitTimeout is returned when a sandbox did not reach the target
+// status before the wait timeout.
+var errSandboxWaitTimeout = errors.New("timed out waiting for sandbox")
+
+// sandboxWaitFailedError reports that a sandbox reached a status from which
+// the wait target can no longer be reached.
+type sandboxWaitFailedError struct {
+	SandboxID string
+	Status    string
+	Reason    string
+}
+
+func (e *sandboxWaitFailedError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("sandbox %s %s", e.SandboxID, e.Reason)
+	}
+	return fmt.Sprintf("sandbox %s is %s", e.SandboxID, e.Status)
+}
+
+// sandboxWaitCmd waits for a sandbox to reach a status.
+var sandboxWaitCmd = &cobra.Command{
+	Use:   "wait <sandbox-id>",
+	Short: "Wait for a sandbox to reach a status",
+	Long: `Wait for a sandbox to reach a status, polling with backoff.
+
+Exits with 0 when the status is reached, 2 when the sandbox reaches a status
+from which the target cannot be reached (for example failed), and 3 on
+timeout.`,
+	Example: `  s0 sandbox wait sb_123 --for status=running
+  s0 sandbox wait sb_123 --for status=deleted --timeout 2m`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sandboxID := args[0]
+
+		target, err := parseSandboxWaitCondition(sandboxWaitFor)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		status, err := waitForSandboxStatus(cmd.Context(), client, sandboxID, sandboxWaitTimeout, target)
+		if err != nil {
+			exitSandboxWaitError(err)
+		}
+
+		if status == nil || !isStructuredOutput() {
+			fmt.Printf("Sandbox %s is %s\n", sandboxID, target)
+			return
+		}
+		if err := getFormatter().Format(os.Stdout, status); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	sandboxWaitCmd.Flags().StringVar(&sandboxWaitFor, "for", "status=running", "condition to wait for: status=running|paused|deleted")
+	sandboxWaitCmd.Flags().DurationVar(&sandboxWaitTimeout, "timeout", 5*time.Minute, "maximum time to wait")
+	sandboxCmd.AddCommand(sandboxWaitCmd)
+}
+
+// addSandboxWaitFlags registers --wait and --wait-timeout on a command that
+// starts an asynchronous sandbox transition.
+func addSandboxWaitFlags(cmd *cobra.Command, target string) {
+	cmd.Flags().BoolVar(&sandboxWait, "wait", false, fmt.Sprintf("wait until the sandbox is %s", target))
+	cmd.Flags().DurationVar(&sandboxWaitTimeout, "wait-timeout", 5*time.Minute, "maximum time to wait with --wait")
+}
+
+// parseSandboxWaitCondition parses a --for value such as status=running.
+func parseSandboxWaitCondition(condition string) (string, error) {
+	key, value, found := strings.Cut(condition, "=")
+	if !found || strings.TrimSpace(key) != "status" {
+		return "", fmt.Errorf("invalid --for %q, expected status=running|paused|deleted", condition)
+	}
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case string(apispec.SandboxLifecycleStatusRunning), string(apispec.SandboxLifecycleStatusPaused), sandboxStatusDeleted:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid --for status %q, expected running, paused, or deleted", value)
+}
+
+// waitForSandboxStatus polls StatusSandbox with exponential backoff until the
+// sandbox reaches one of targets. The returned status is nil when the target
+// is deleted. A failed or terminating sandbox that can no longer reach the
+// target returns a *sandboxWaitFailedError.
+func waitForSandboxStatus(ctx context.Context, client *sandbox0.Client, sandboxID string, timeout time.Duration, targets ...string) (*apispec.SandboxStatus, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	interval := sandboxWaitPollInterval
+	for {
+		status, err := client.StatusSandbox(ctx, sandboxID)
+		switch {
+		case err != nil && isNotFoundError(err):
+			if slices.Contains(targets, sandboxStatusDeleted) {
+				return nil, nil
+			}
+			return nil, &sandboxWaitFailedError{SandboxID: sandboxID, Status: sandboxStatusDeleted, Reason: "no longer exists"}
+		case err != nil && ctx.Err() != nil:
+			return nil, sandboxWaitContextError(ctx, sandboxID, targets)
+		case err != nil:
+			return nil, err
+		}
+
+		current, _ := status.Status.Get()
+		if slices.Contains(targets, string(current)) {
+			return status, nil
+		}
+		if sandboxWaitUnreachable(current, targets) {
+			return nil, &sandboxWaitFailedError{SandboxID: sandboxID, Status: string(current)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, sandboxWaitContextError(ctx, sandboxID, targets)
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, sandboxWaitMaxPollInterval)
+	}
+}
+
+// sandboxWaitUnreachable reports whether a sandbox in status current can no
+// longer reach any of targets.
+func sandboxWaitUnreachable(current apispec.SandboxLifecycleStatus, targets []string) bool {
+	switch current {
+	case apispec.SandboxLifecycleStatusFailed:
+		return true
+	case apispec.SandboxLifecycleStatusTerminating:
+		return !slices.Contains(targets, sandboxStatusDeleted)
+	}
+	return false
+}
+
+func sandboxWaitContextError(ctx context.Context, sandboxID string, targets []string) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w %s to be %s", errSandboxWaitTimeout, sandboxID, strings.Join(targets, " or "))
+	}
+	return ctx.Err()
+}
+
+// checkSandboxBootstrapMounts returns an error describing failed bootstrap
+// mounts of a claimed sandbox.
+func checkSandboxBootstrapMounts(sandbox *sandbox0.Sandbox) error {
+	var failures []string
+	for _, mount := range sandbox.BootstrapMounts {
+		if mount.State != apispec.MountStatusStateFailed {
+			continue
+		}
+		detail := mount.SandboxvolumeID + ":" + mount.MountPoint
+		if message, ok := mount.ErrorMessage.Get(); ok && message != "" {
+			detail += " (" + message + ")"
+		} else if code, ok := mount.ErrorCode.Get(); ok && code != "" {
+			detail += " (" + code + ")"
+		}
+		failures = append(failures, detail)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &sandboxWaitFailedError{
+		SandboxID: sandbox.ID,
+		Status:    sandbox.Status,
+		Reason:    "bootstrap mount failed: " + strings.Join(failures, ", "),
+	}
+}
+
+// exitSandboxWaitError prints a wait error and exits with the matching code.
+func exitSandboxWaitError(err error) {
+	fmt.Fprintf(os.Stderr, "Error waiting for sandbox: %v\n", err)
+	os.Exit(sandboxWaitExitCode(err))
+}
+
+// sandboxWaitExitCode returns the exit status for an error that may come
+// from waitForSandboxStatus.
+func sandboxWaitExitCode(err error) int {
+	var failed *sandboxWaitFailedError
+	switch {
+	case errors.Is(err, errSandboxWaitTimeout):
+		return sandboxWaitExitTimeout
+	case errors.As(err, &failed):
+		return sandboxWaitExitFailed
+	}
+	return 1
+}
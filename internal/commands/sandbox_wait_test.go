@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func useFastSandboxWaitPolling(t *testing.T) {
+	t.Helper()
+	interval, maxInterval := sandboxWaitPollInterval, sandboxWaitMaxPollInterval
+	sandboxWaitPollInterval, sandboxWaitMaxPollInterval = time.Millisecond, 2*time.Millisecond
+	t.Cleanup(func() {
+		sandboxWaitPollInterval, sandboxWaitMaxPollInterval = interval, maxInterval
+	})
+}
+
+func newSandboxStatusTestClient(t *testing.T, statuses ...string) (*sandbox0.Client, *int) {
+	t.Helper()
+	calls := 0
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sandboxes/sb_1/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if status == sandboxStatusDeleted {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":{"code":"not_found","message":"sandbox not found"}}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"success":true,"data":{"sandbox_id":"sb_1","status":%q}}`, status)
+	})
+	return client, &calls
+}
+
+func TestParseSandboxWaitCondition(t *testing.T) {
+	for condition, want := range map[string]string{
+		"status=running": "running",
+		"status=Paused":  "paused",
+		"status=deleted": "deleted",
+	} {
+		got, err := parseSandboxWaitCondition(condition)
+		if err != nil || got != want {
+			t.Fatalf("parseSandboxWaitCondition(%q) = %q, %v; want %q", condition, got, err, want)
+		}
+	}
+	for _, condition := range []string{"running", "phase=running", "status=starting"} {
+		if _, err := parseSandboxWaitCondition(condition); err == nil {
+			t.Fatalf("parseSandboxWaitCondition(%q) error = nil, want error", condition)
+		}
+	}
+}
+
+func TestWaitForSandboxStatus(t *testing.T) {
+	useFastSandboxWaitPolling(t)
+
+	t.Run("polls until the target status", func(t *testing.T) {
+		client, calls := newSandboxStatusTestClient(t, "starting", "starting", "running")
+		status, err := waitForSandboxStatus(context.Background(), client, "sb_1", time.Second, "running")
+		if err != nil {
+			t.Fatalf("waitForSandboxStatus() error = %v", err)
+		}
+		if got, _ := status.Status.Get(); got != apispec.SandboxLifecycleStatusRunning || *calls != 3 {
+			t.Fatalf("status = %q after %d calls, want running after 3", got, *calls)
+		}
+	})
+
+	t.Run("failed status is terminal", func(t *testing.T) {
+		client, _ := newSandboxStatusTestClient(t, "starting", "failed")
+		_, err := waitForSandboxStatus(context.Background(), client, "sb_1", time.Second, "running")
+		var failed *sandboxWaitFailedError
+		if !errors.As(err, &failed) || failed.Status != "failed" {
+			t.Fatalf("waitForSandboxStatus() error = %v, want failed status", err)
+		}
+	})
+
+	t.Run("not found satisfies deleted", func(t *testing.T) {
+		client, _ := newSandboxStatusTestClient(t, "terminating", "deleted")
+		status, err := waitForSandboxStatus(context.Background(), client, "sb_1", time.Second, sandboxStatusDeleted)
+		if err != nil || status != nil {
+			t.Fatalf("waitForSandboxStatus() = %v, %v; want nil, nil", status, err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		client, _ := newSandboxStatusTestClient(t, "starting")
+		_, err := waitForSandboxStatus(context.Background(), client, "sb_1", 20*time.Millisecond, "running")
+		if !errors.Is(err, errSandboxWaitTimeout) {
+			t.Fatalf("waitForSandboxStatus() error = %v, want timeout", err)
+		}
+	})
+}
+
+func TestCheckSandboxBootstrapMounts(t *testing.T) {
+	sandbox := &sandbox0.Sandbox{ID: "sb_1", Status: "starting", BootstrapMounts: []apispec.MountStatus{
+		{SandboxvolumeID: "vol_1", MountPoint: "/data", State: apispec.MountStatusStateMounted},
+		{SandboxvolumeID: "vol_2", MountPoint: "/cache", State: apispec.MountStatusStateFailed, ErrorMessage: apispec.NewOptString("volume busy")},
+	}}
+	err := checkSandboxBootstrapMounts(sandbox)
+	if err == nil || !strings.Contains(err.Error(), "vol_2:/cache (volume busy)") || strings.Contains(err.Error(), "vol_1") {
+		t.Fatalf("checkSandboxBootstrapMounts() error = %v", err)
+	}
+
+	sandbox.BootstrapMounts = sandbox.BootstrapMounts[:1]
+	if err := checkSandboxBootstrapMounts(sandbox); err != nil {
+		t.Fatalf("checkSandboxBootstrapMounts() error = %v, want nil", err)
+	}
+}
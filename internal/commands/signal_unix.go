@@ -25,3 +25,25 @@ func signalName(sig os.Signal) string {
 		return ""
 	}
 }
+
+// terminalTerminatingSignals are the signals that end the process while a
+// terminal is in raw mode and are not forwarded to a remote process.
+func terminalTerminatingSignals() []os.Signal {
+	return []os.Signal{syscall.SIGHUP, syscall.SIGQUIT}
+}
+
+func raiseSignal(sig os.Signal) {
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(os.Getpid(), s)
+	}
+}
+
+// signaledExitCode returns the shell's 128+signo exit code when the process
+// was killed by a signal.
+func signaledExitCode(state *os.ProcessState) (int, bool) {
+	status, ok := state.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return 0, false
+	}
+	return 128 + int(status.Signal()), true
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package commands
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestSignaledExitCode(t *testing.T) {
+	err := exec.Command("sh", "-c", "kill -TERM $$").Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Run() error = %v, want exit error", err)
+	}
+	if code, signaled := signaledExitCode(exitErr.ProcessState); !signaled || code != 143 {
+		t.Fatalf("signaledExitCode() = %d, %v; want 143, true", code, signaled)
+	}
+
+	err = exec.Command("sh", "-c", "exit 3").Run()
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("Run() error = %v, want exit error", err)
+	}
+	if _, signaled := signaledExitCode(exitErr.ProcessState); signaled {
+		t.Fatal("signaledExitCode() reported a signal for a normal exit")
+	}
+}
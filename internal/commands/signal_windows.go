@@ -18,3 +18,13 @@ func signalName(sig os.Signal) string {
 	}
 	return ""
 }
+
+func terminalTerminatingSignals() []os.Signal {
+	return nil
+}
+
+func raiseSignal(os.Signal) {}
+
+func signaledExitCode(*os.ProcessState) (int, bool) {
+	return 0, false
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// defaultTerminalRows and defaultTerminalCols are used when the terminal
+// size cannot be read.
+const (
+	defaultTerminalRows = 24
+	defaultTerminalCols = 80
+)
+
+// prepareTerminalForStreaming puts stdin in raw mode when a TTY is allocated
+// for a remote process, and returns a function that restores it. The
+// restore function may be called more than once.
+func prepareTerminalForStreaming(allocateTTY bool) (func(), error) {
+	if !allocateTTY || !isTerminalFile(os.Stdin) {
+		return func() {}, nil
+	}
+	return enterRawTerminal(os.Stdin)
+}
+
+// enterRawTerminal puts file in raw mode. The previous mode is also restored
+// if the process is killed by a terminating signal before the returned
+// function is called; deferring the function restores it on panic.
+func enterRawTerminal(file *os.File) (func(), error) {
+	state, err := makeTerminalRaw(file)
+	if err != nil {
+		return nil, fmt.Errorf("enable raw terminal mode: %w", err)
+	}
+	var once sync.Once
+	restore := func() {
+		once.Do(func() { _ = restoreTerminalState(file, state) })
+	}
+	stopGuard := restoreTerminalOnSignal(restore)
+	return func() {
+		stopGuard()
+		restore()
+	}, nil
+}
+
+// restoreTerminalOnSignal runs restore before the process is terminated by
+// one of terminalTerminatingSignals, then re-raises the signal so the
+// default action still applies. Signals that callers forward to the remote
+// process, such as SIGINT and SIGTERM, are left to the caller.
+func restoreTerminalOnSignal(restore func()) func() {
+	signals := terminalTerminatingSignals()
+	if len(signals) == 0 {
+		return func() {}
+	}
+	sigCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sigCh, signals...)
+	go func() {
+		select {
+		case sig := <-sigCh:
+			restore()
+			signal.Reset(sig)
+			raiseSignal(sig)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sigCh)
+			close(done)
+		})
+	}
+}
+
+// currentTerminalSize returns the size of the stdin terminal, or 24x80 when
+// it cannot be read.
+func currentTerminalSize() (int32, int32) {
+	if !isTerminalFile(os.Stdin) {
+		return defaultTerminalRows, defaultTerminalCols
+	}
+	rows, cols, err := terminalSize(os.Stdin)
+	if err != nil || rows <= 0 || cols <= 0 {
+		return defaultTerminalRows, defaultTerminalCols
+	}
+	return rows, cols
+}
+
+// setTerminalEcho turns echo of typed characters on stdin on or off.
+func setTerminalEcho(enabled bool) error {
+	return setTerminalFileEcho(os.Stdin, enabled)
+}
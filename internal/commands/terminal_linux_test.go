@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// openTestPTY opens a pseudo-terminal pair and returns the controlling and
+// terminal ends.
+func openTestPTY(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %v", err)
+	}
+	t.Cleanup(func() { _ = ptmx.Close() })
+	if err := unix.IoctlSetPointerInt(int(ptmx.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Fatalf("unlock pty: %v", err)
+	}
+	n, err := unix.IoctlGetInt(int(ptmx.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Fatalf("get pty number: %v", err)
+	}
+	tty, err := os.OpenFile("/dev/pts/"+strconv.Itoa(n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Fatalf("open pty: %v", err)
+	}
+	t.Cleanup(func() { _ = tty.Close() })
+	return ptmx, tty
+}
+
+func getTestTermios(t *testing.T, file *os.File) *unix.Termios {
+	t.Helper()
+	termios, err := unix.IoctlGetTermios(int(file.Fd()), ioctlReadTermios)
+	if err != nil {
+		t.Fatalf("get termios: %v", err)
+	}
+	return termios
+}
+
+func TestEnterRawTerminalRestores(t *testing.T) {
+	_, tty := openTestPTY(t)
+	before := getTestTermios(t, tty)
+	if before.Lflag&unix.ICANON == 0 || before.Lflag&unix.ECHO == 0 {
+		t.Fatalf("new pty should start in canonical echo mode, lflag = %#x", before.Lflag)
+	}
+
+	restore, err := enterRawTerminal(tty)
+	if err != nil {
+		t.Fatalf("enterRawTerminal() error = %v", err)
+	}
+	raw := getTestTermios(t, tty)
+	if raw.Lflag&(unix.ICANON|unix.ECHO|unix.ISIG) != 0 || raw.Iflag&unix.ICRNL != 0 || raw.Oflag&unix.OPOST != 0 {
+		t.Fatalf("raw mode flags not cleared: iflag=%#x oflag=%#x lflag=%#x", raw.Iflag, raw.Oflag, raw.Lflag)
+	}
+	if raw.Cc[unix.VMIN] != 1 || raw.Cc[unix.VTIME] != 0 {
+		t.Fatalf("raw mode VMIN/VTIME = %d/%d, want 1/0", raw.Cc[unix.VMIN], raw.Cc[unix.VTIME])
+	}
+
+	restore()
+	restore()
+	if after := getTestTermios(t, tty); *after != *before {
+		t.Fatalf("termios after restore = %+v, want %+v", after, before)
+	}
+}
+
+func TestEnterRawTerminalRestoresOnPanic(t *testing.T) {
+	_, tty := openTestPTY(t)
+	before := getTestTermios(t, tty)
+
+	func() {
+		defer func() { _ = recover() }()
+		restore, err := enterRawTerminal(tty)
+		if err != nil {
+			t.Fatalf("enterRawTerminal() error = %v", err)
+		}
+		defer restore()
+		panic("boom")
+	}()
+
+	if after := getTestTermios(t, tty); *after != *before {
+		t.Fatalf("termios after panic = %+v, want %+v", after, before)
+	}
+}
+
+func TestTerminalSize(t *testing.T) {
+	ptmx, tty := openTestPTY(t)
+	if err := unix.IoctlSetWinsize(int(ptmx.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: 42, Col: 132}); err != nil {
+		t.Fatalf("set winsize: %v", err)
+	}
+	rows, cols, err := terminalSize(tty)
+	if err != nil || rows != 42 || cols != 132 {
+		t.Fatalf("terminalSize() = %d, %d, %v; want 42, 132", rows, cols, err)
+	}
+}
+
+func TestSetTerminalFileEcho(t *testing.T) {
+	_, tty := openTestPTY(t)
+	if err := setTerminalFileEcho(tty, false); err != nil {
+		t.Fatalf("setTerminalFileEcho(false) error = %v", err)
+	}
+	if getTestTermios(t, tty).Lflag&unix.ECHO != 0 {
+		t.Fatal("echo is still enabled")
+	}
+	if err := setTerminalFileEcho(tty, true); err != nil {
+		t.Fatalf("setTerminalFileEcho(true) error = %v", err)
+	}
+	if getTestTermios(t, tty).Lflag&unix.ECHO == 0 {
+		t.Fatal("echo is still disabled")
+	}
+}
+
+func TestTerminalSizeRejectsNonTerminal(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "not-a-tty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, _, err := terminalSize(file); err == nil {
+		t.Fatal("terminalSize() error = nil for a regular file")
+	}
+	if _, err := enterRawTerminal(file); err == nil {
+		t.Fatal("enterRawTerminal() error = nil for a regular file")
+	}
+}
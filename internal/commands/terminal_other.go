@@ -0,0 +1,34 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package commands
+
+import (
+	"errors"
+	"os"
+)
+
+var errTerminalUnsupported = errors.New("terminal control is not supported on this platform")
+
+// terminalState is a saved terminal mode. Raw mode is not available on this
+// platform, so streaming runs with the terminal in its current mode.
+type terminalState struct{}
+
+func makeTerminalRaw(*os.File) (*terminalState, error) {
+	return &terminalState{}, nil
+}
+
+func restoreTerminalState(*os.File, *terminalState) error {
+	return nil
+}
+
+func terminalSize(*os.File) (int32, int32, error) {
+	return 0, 0, errTerminalUnsupported
+}
+
+func setTerminalFileEcho(*os.File, bool) error {
+	return errTerminalUnsupported
+}
+
+func inTerminalForeground(*os.File) bool {
+	return false
+}
@@ -0,0 +1,71 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package commands
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalState is a saved terminal mode.
+type terminalState struct {
+	termios unix.Termios
+}
+
+// makeTerminalRaw puts file in raw mode, like cfmakeraw(3), and returns the
+// previous mode.
+func makeTerminalRaw(file *os.File) (*terminalState, error) {
+	fd := int(file.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+	state := &terminalState{termios: *termios}
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, termios); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func restoreTerminalState(file *os.File, state *terminalState) error {
+	return unix.IoctlSetTermios(int(file.Fd()), ioctlWriteTermios, &state.termios)
+}
+
+func terminalSize(file *os.File) (int32, int32, error) {
+	ws, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(ws.Row), int32(ws.Col), nil
+}
+
+func setTerminalFileEcho(file *os.File, enabled bool) error {
+	fd := int(file.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		termios.Lflag |= unix.ECHO
+	} else {
+		termios.Lflag &^= unix.ECHO
+	}
+	return unix.IoctlSetTermios(fd, ioctlWriteTermios, termios)
+}
+
+// inTerminalForeground reports whether this process belongs to the foreground
+// process group of the terminal file. Keys such as Ctrl-C then signal the
+// whole group, including child processes.
+func inTerminalForeground(file *os.File) bool {
+	pgrp, err := unix.IoctlGetInt(int(file.Fd()), unix.TIOCGPGRP)
+	return err == nil && pgrp == unix.Getpgrp()
+}
@@ -0,0 +1,627 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topInterval time.Duration
+	topSort     string
+	topReverse  bool
+)
+
+// topSortKeys name the dashboard columns for sorting, in the order of
+// output.SandboxTopColumns; key 1 sorts by the first column and so on.
+var topSortKeys = []string{"id", "template", "status", "age", "ttl", "cpu", "memory"}
+
+// topMetricsConcurrency bounds the metric queries of one refresh.
+const topMetricsConcurrency = 8
+
+// topMetricsWindow is how far back a refresh looks for the latest metrics.
+const topMetricsWindow = 5 * time.Minute
+
+// topShell is the command run by the exec key.
+var topShell = []string{"/bin/sh"}
+
+// topCmd shows a live dashboard of the team's sandboxes.
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show a live dashboard of sandboxes",
+	Long: `Show every sandbox of the current team with its status, template, age,
+remaining TTL, and latest CPU and memory usage, refreshed every --interval.
+
+Keys:
+  up/down, k/j   select a sandbox
+  1-7            sort by column; press again to reverse
+  p, r           pause or resume the selected sandbox
+  d              delete the selected sandbox (asks for confirmation)
+  e              open a shell in the selected sandbox
+  q, ctrl-c      quit
+
+When stdin or stdout is not a terminal, the table is printed again every
+--interval in the selected output format until interrupted.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if topInterval <= 0 {
+			fmt.Fprintln(os.Stderr, "Error: --interval must be positive")
+			os.Exit(1)
+		}
+		if !slices.Contains(topSortKeys, topSort) {
+			fmt.Fprintf(os.Stderr, "Error: invalid --sort %q, expected one of %s\n", topSort, strings.Join(topSortKeys, ", "))
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		if isTerminalFile(os.Stdin) && isTerminalFile(os.Stdout) {
+			err = runTopTUI(cmd.Context(), client)
+		} else {
+			err = runTopPlain(cmd.Context(), client)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	topCmd.Flags().DurationVar(&topInterval, "interval", 3*time.Second, "refresh interval")
+	topCmd.Flags().StringVar(&topSort, "sort", "cpu", "sort column ("+strings.Join(topSortKeys, ", ")+")")
+	topCmd.Flags().BoolVar(&topReverse, "reverse", false, "reverse the sort order")
+	rootCmd.AddCommand(topCmd)
+}
+
+// collectSandboxTop lists the team's sandboxes and the latest CPU and memory
+// values of those that are running.
+func collectSandboxTop(ctx context.Context, client *sandbox0.Client, now time.Time) (output.SandboxTop, error) {
+	limit := 100
+	sandboxes, err := selectSandboxes(ctx, client, sandbox0.ListSandboxesOptions{Limit: &limit}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := runBounded(ctx, sandboxes, topMetricsConcurrency, func(ctx context.Context, sandbox apispec.SandboxSummary) output.SandboxTopRow {
+		row := newSandboxTopRow(sandbox, now)
+		if sandbox.Paused || sandbox.Status != apispec.SandboxLifecycleStatusRunning {
+			return row
+		}
+		start := now.Add(-topMetricsWindow)
+		metrics, err := client.Sandbox(sandbox.ID).ListMetrics(ctx, &sandbox0.SandboxObservabilityMetricOptions{
+			StartTime: &start,
+			EndTime:   &now,
+			Metrics:   []apispec.SandboxRuntimeMetricName{apispec.SandboxRuntimeMetricNameSandboxCPUUtilization, apispec.SandboxRuntimeMetricNameSandboxMemoryUsage},
+		})
+		if err != nil {
+			// Missing metrics are shown as "-" rather than failing the refresh.
+			return row
+		}
+		row.CPU = latestMetricValue(metrics, apispec.SandboxRuntimeMetricNameSandboxCPUUtilization)
+		row.Memory = latestMetricValue(metrics, apispec.SandboxRuntimeMetricNameSandboxMemoryUsage)
+		return row
+	})
+	return rows, nil
+}
+
+func newSandboxTopRow(sandbox apispec.SandboxSummary, now time.Time) output.SandboxTopRow {
+	row := output.SandboxTopRow{
+		ID:         sandbox.ID,
+		TemplateID: sandbox.TemplateID,
+		Status:     string(sandbox.Status),
+		CreatedAt:  sandbox.CreatedAt,
+		Age:        int64(now.Sub(sandbox.CreatedAt).Seconds()),
+	}
+	var expiry time.Time
+	for _, t := range []time.Time{sandbox.ExpiresAt, sandbox.HardExpiresAt} {
+		if !t.IsZero() && (expiry.IsZero() || t.Before(expiry)) {
+			expiry = t
+		}
+	}
+	if !expiry.IsZero() {
+		ttl := int64(expiry.Sub(now).Seconds())
+		row.TTL = &ttl
+	}
+	return row
+}
+
+// latestMetricValue returns the most recent point of metric, or nil when the
+// metric has no points.
+func latestMetricValue(metrics *apispec.SandboxRuntimeMetricsResponse, metric apispec.SandboxRuntimeMetricName) *float64 {
+	var latest *apispec.SandboxRuntimeMetricPoint
+	for _, series := range metrics.Series {
+		if series.Metric != metric {
+			continue
+		}
+		for _, segment := range series.Segments {
+			for i, point := range segment.Points {
+				if latest == nil || point.Time.After(latest.Time) {
+					latest = &segment.Points[i]
+				}
+			}
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	value := latest.Value
+	return &value
+}
+
+// sortSandboxTop sorts rows by the named column. Missing values sort last
+// in either direction, and ties keep ID order.
+func sortSandboxTop(rows output.SandboxTop, column string, desc bool) {
+	optional := func(a, b *float64) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 2
+		case b == nil:
+			return -2
+		case *a < *b:
+			return -1
+		case *a > *b:
+			return 1
+		}
+		return 0
+	}
+	seconds := func(v *int64) *float64 {
+		if v == nil {
+			return nil
+		}
+		f := float64(*v)
+		return &f
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		var c int
+		switch column {
+		case "template":
+			c = strings.Compare(a.TemplateID, b.TemplateID)
+		case "status":
+			c = strings.Compare(a.Status, b.Status)
+		case "age":
+			c = optional(seconds(&a.Age), seconds(&b.Age))
+		case "ttl":
+			c = optional(seconds(a.TTL), seconds(b.TTL))
+		case "cpu":
+			c = optional(a.CPU, b.CPU)
+		case "memory":
+			c = optional(a.Memory, b.Memory)
+		}
+		if c == 2 || c == -2 {
+			// A missing value is never moved before a present one.
+			return c < 0
+		}
+		if desc {
+			c = -c
+		}
+		if c == 0 {
+			return a.ID < b.ID
+		}
+		return c < 0
+	})
+}
+
+// topDefaultDescending reports whether a column sorts largest first when it
+// is selected, like the usage columns of top(1).
+func topDefaultDescending(column string) bool {
+	return column == "age" || column == "cpu" || column == "memory"
+}
+
+// runTopPlain prints the dashboard every interval until interrupted.
+func runTopPlain(ctx context.Context, client *sandbox0.Client) error {
+	ctx, cancel := signal.NotifyContext(ctx, forwardingSignals()...)
+	defer cancel()
+	formatter := getFormatter()
+	ticker := time.NewTicker(topInterval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		rows, err := collectSandboxTop(ctx, client, now)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		sortSandboxTop(rows, topSort, topDefaultDescending(topSort) != topReverse)
+		if !isStructuredOutput() {
+			fmt.Fprintf(os.Stdout, "%s  %d %s\n", now.Format(time.TimeOnly), len(rows), pluralizeSandbox(len(rows)))
+		}
+		if err := formatter.Format(os.Stdout, rows); err != nil {
+			return err
+		}
+		if !isStructuredOutput() {
+			fmt.Fprintln(os.Stdout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// topActionKind is an operation requested by a key press.
+type topActionKind int
+
+const (
+	topActionNone topActionKind = iota
+	topActionQuit
+	topActionPause
+	topActionResume
+	topActionDelete
+	topActionExec
+)
+
+// topAction is an operation on the sandbox that was selected when the key
+// was pressed.
+type topAction struct {
+	kind      topActionKind
+	sandboxID string
+}
+
+// topView is the interactive dashboard state.
+type topView struct {
+	rows      output.SandboxTop
+	selected  string
+	sortBy    string
+	desc      bool
+	status    string
+	updatedAt time.Time
+	// confirmDelete is the sandbox awaiting delete confirmation.
+	confirmDelete string
+}
+
+func (v *topView) setRows(rows output.SandboxTop, now time.Time) {
+	sortSandboxTop(rows, v.sortBy, v.desc)
+	v.rows = rows
+	v.updatedAt = now
+	if v.selectedIndex() < 0 && len(rows) > 0 {
+		v.selected = rows[0].ID
+	}
+}
+
+// selectedIndex returns the row of the selected sandbox, or -1.
+func (v *topView) selectedIndex() int {
+	for i, row := range v.rows {
+		if row.ID == v.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (v *topView) move(delta int) {
+	if len(v.rows) == 0 {
+		return
+	}
+	i := min(max(v.selectedIndex()+delta, 0), len(v.rows)-1)
+	v.selected = v.rows[i].ID
+}
+
+// handleKey applies navigation and sorting keys and returns the operation
+// requested by any other key.
+func (v *topView) handleKey(key string) topAction {
+	if v.confirmDelete != "" {
+		id := v.confirmDelete
+		v.confirmDelete = ""
+		if key == "y" || key == "Y" {
+			return topAction{kind: topActionDelete, sandboxID: id}
+		}
+		v.status = "Delete cancelled"
+		return topAction{}
+	}
+
+	switch key {
+	case "q", "ctrl-c":
+		return topAction{kind: topActionQuit}
+	case "up", "k":
+		v.move(-1)
+	case "down", "j":
+		v.move(1)
+	case "1", "2", "3", "4", "5", "6", "7":
+		column := topSortKeys[key[0]-'1']
+		if column == v.sortBy {
+			v.desc = !v.desc
+		} else {
+			v.sortBy, v.desc = column, topDefaultDescending(column)
+		}
+		sortSandboxTop(v.rows, v.sortBy, v.desc)
+	case "p", "r", "d", "e":
+		if v.selectedIndex() < 0 {
+			v.status = "No sandbox selected"
+			return topAction{}
+		}
+		switch key {
+		case "p":
+			return topAction{kind: topActionPause, sandboxID: v.selected}
+		case "r":
+			return topAction{kind: topActionResume, sandboxID: v.selected}
+		case "e":
+			return topAction{kind: topActionExec, sandboxID: v.selected}
+		default:
+			v.confirmDelete = v.selected
+			v.status = fmt.Sprintf("Delete %s? [y/N]", v.selected)
+		}
+	}
+	return topAction{}
+}
+
+// render draws the dashboard for a terminal of the given size, with the
+// selected row highlighted and scrolled into view.
+func (v *topView) render(rows, cols int) string {
+	var table bytes.Buffer
+	_ = output.NewFormatter(output.FormatTable).Format(&table, v.rows)
+	lines := strings.Split(strings.TrimRight(table.String(), "\n"), "\n")
+
+	direction := "asc"
+	if v.desc {
+		direction = "desc"
+	}
+	header := fmt.Sprintf("s0 top  %d %s  sort: %s %s  updated %s",
+		len(v.rows), pluralizeSandbox(len(v.rows)), v.sortBy, direction, v.updatedAt.Format(time.TimeOnly))
+	footer := "up/down select  1-7 sort  p pause  r resume  d delete  e shell  q quit"
+	if v.status != "" {
+		footer = v.status
+	}
+
+	// The title, table header, and footer take three lines.
+	visible := max(rows-3, 1)
+	body := []string{}
+	if len(v.rows) > 0 {
+		selected := max(v.selectedIndex(), 0)
+		offset := max(selected-visible+1, 0)
+		for i := offset; i < len(v.rows) && i < offset+visible; i++ {
+			line := truncateTopLine(lines[i+1], cols)
+			if i == selected {
+				line = "\x1b[7m" + line + "\x1b[0m"
+			}
+			body = append(body, line)
+		}
+		lines = append([]string{truncateTopLine(lines[0], cols)}, body...)
+	}
+
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	b.WriteString(truncateTopLine(header, cols))
+	for _, line := range lines {
+		b.WriteString("\r\n")
+		b.WriteString(line)
+	}
+	b.WriteString(fmt.Sprintf("\x1b[%d;1H", rows))
+	b.WriteString(truncateTopLine(footer, cols))
+	return b.String()
+}
+
+func truncateTopLine(line string, cols int) string {
+	if cols > 0 && len(line) > cols {
+		return line[:cols]
+	}
+	return line
+}
+
+// parseTopKeys splits terminal input into key names.
+func parseTopKeys(data []byte) []string {
+	var keys []string
+	for i := 0; i < len(data); i++ {
+		switch {
+		case data[i] == 0x03:
+			keys = append(keys, "ctrl-c")
+		case data[i] == 0x1b && i+2 < len(data) && data[i+1] == '[':
+			switch data[i+2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			}
+			i += 2
+		default:
+			keys = append(keys, string(data[i]))
+		}
+	}
+	return keys
+}
+
+// runTopTUI runs the interactive dashboard until the user quits.
+func runTopTUI(ctx context.Context, client *sandbox0.Client) error {
+	// Quit on SIGINT and SIGTERM so the terminal is restored on the way out.
+	ctx, cancel := signal.NotifyContext(ctx, forwardingSignals()...)
+	defer cancel()
+
+	// One goroutine owns stdin for the whole session so that a shell opened
+	// with the exec key can be fed from it without a competing reader.
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				select {
+				case input <- bytes.Clone(buf[:n]):
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				close(input)
+				return
+			}
+		}
+	}()
+
+	restore, err := enterTopScreen()
+	if err != nil {
+		return err
+	}
+	defer func() { restore() }()
+
+	view := &topView{sortBy: topSort, desc: topDefaultDescending(topSort) != topReverse}
+	// Refreshes run in the background so keys stay responsive; the view is
+	// only touched by this loop.
+	refreshed := make(chan topRefresh, 1)
+	refreshing := false
+	refresh := func() {
+		if refreshing {
+			return
+		}
+		refreshing = true
+		go func() {
+			now := time.Now()
+			rows, err := collectSandboxTop(ctx, client, now)
+			refreshed <- topRefresh{rows: rows, at: now, err: err}
+		}()
+	}
+	draw := func() {
+		rows, cols := currentTerminalSize()
+		_, _ = io.WriteString(os.Stdout, view.render(int(rows), int(cols)))
+	}
+
+	refresh()
+	ticker := time.NewTicker(topInterval)
+	defer ticker.Stop()
+	resize := make(chan os.Signal, 1)
+	if signals := resizeSignals(); len(signals) > 0 {
+		signal.Notify(resize, signals...)
+		defer signal.Stop(resize)
+	}
+	draw()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resize:
+		case <-ticker.C:
+			refresh()
+			continue
+		case result := <-refreshed:
+			refreshing = false
+			if result.err != nil {
+				view.status = "Refresh failed: " + result.err.Error()
+			} else {
+				view.setRows(result.rows, result.at)
+			}
+		case data, ok := <-input:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseTopKeys(data) {
+				view.status = ""
+				action := view.handleKey(key)
+				switch action.kind {
+				case topActionQuit:
+					return nil
+				case topActionPause, topActionResume, topActionDelete:
+					view.status = runTopLifecycleAction(ctx, client, action)
+					refresh()
+				case topActionExec:
+					restore()
+					shellErr := runTopShell(ctx, client, action.sandboxID, input)
+					if restore, err = enterTopScreen(); err != nil {
+						restore = func() {}
+						return err
+					}
+					view.status = fmt.Sprintf("Shell in %s closed", action.sandboxID)
+					if shellErr != nil {
+						view.status = fmt.Sprintf("Shell in %s failed: %v", action.sandboxID, shellErr)
+					}
+					refresh()
+				}
+			}
+		}
+		draw()
+	}
+}
+
+// topRefresh carries the result of a background refresh to the main loop.
+type topRefresh struct {
+	rows output.SandboxTop
+	at   time.Time
+	err  error
+}
+
+func runTopLifecycleAction(ctx context.Context, client *sandbox0.Client, action topAction) string {
+	op := map[topActionKind]sandboxBulkOperation{
+		topActionPause:  sandboxBulkPause,
+		topActionResume: sandboxBulkResume,
+		topActionDelete: sandboxBulkDelete,
+	}[action.kind]
+	if err := op.run(ctx, client, action.sandboxID); err != nil {
+		return fmt.Sprintf("Failed to %s %s: %v", op.verb, action.sandboxID, err)
+	}
+	return fmt.Sprintf("Sandbox %s %s", action.sandboxID, op.done)
+}
+
+// runTopShell opens an interactive shell in the sandbox, feeding it the
+// terminal input read by the dashboard.
+func runTopShell(ctx context.Context, client *sandbox0.Client, sandboxID string, input <-chan []byte) error {
+	fmt.Fprintf(os.Stdout, "Opening %s in %s; exit the shell to return to s0 top.\n", strings.Join(topShell, " "), sandboxID)
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			case data, ok := <-input:
+				if !ok {
+					_ = pw.Close()
+					return
+				}
+				if _, err := pw.Write(data); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	err := runStreamingExecWithInput(ctx, client, sandboxID, topShell, nil, pr)
+	_ = pr.Close()
+	close(done)
+	wg.Wait()
+	var exitErr *execExitCodeError
+	if errors.As(err, &exitErr) {
+		// A non-zero exit status of the shell is not an error of top.
+		return nil
+	}
+	return err
+}
+
+// enterTopScreen switches the terminal to raw mode and the alternate screen
+// and returns a function that switches back.
+func enterTopScreen() (func(), error) {
+	restoreTerminal, err := prepareTerminalForStreaming(true)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.WriteString(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	return func() {
+		_, _ = io.WriteString(os.Stdout, "\x1b[?25h\x1b[?1049l")
+		restoreTerminal()
+	}, nil
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandbox0-ai/s0/internal/output"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func TestCollectSandboxTop(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	client := newTestSDKClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sandboxes":
+			writeTestSuccess(t, w, map[string]any{
+				"sandboxes": []apispec.SandboxSummary{
+					{ID: "sb_run", TemplateID: "python", Status: apispec.SandboxLifecycleStatusRunning,
+						CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(30 * time.Minute), HardExpiresAt: now.Add(10 * time.Minute)},
+					{ID: "sb_paused", TemplateID: "node", Status: apispec.SandboxLifecycleStatusRunning, Paused: true,
+						CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+				},
+				"count": 2, "has_more": false,
+			})
+		case "/api/v1/sandboxes/sb_run/metrics":
+			writeTestSuccess(t, w, &apispec.SandboxRuntimeMetricsResponse{StartTime: now.Add(-topMetricsWindow), EndTime: now, StepSeconds: 60,
+				Freshness: apispec.SandboxRuntimeMetricFreshness{Status: apispec.SandboxRuntimeMetricFreshnessStatusFresh},
+				Series: []apispec.SandboxRuntimeMetricSeries{
+					{
+						Metric: apispec.SandboxRuntimeMetricNameSandboxCPUUtilization, Kind: apispec.SandboxRuntimeMetricKindGauge,
+						Unit: apispec.SandboxRuntimeMetricUnitRatio, Statistic: apispec.SandboxRuntimeMetricStatisticAverage,
+						Segments: []apispec.SandboxRuntimeMetricSegment{{Points: []apispec.SandboxRuntimeMetricPoint{
+							{Time: now.Add(-time.Minute), Value: 0.25},
+							{Time: now.Add(-2 * time.Minute), Value: 0.9},
+						}}},
+					},
+				}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	rows, err := collectSandboxTop(context.Background(), client, now)
+	if err != nil {
+		t.Fatalf("collectSandboxTop() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %+v, want 2", rows)
+	}
+	run := rows[0]
+	if run.Age != 7200 || run.TTL == nil || *run.TTL != 600 {
+		t.Fatalf("sb_run age/ttl = %d/%v, want 7200/600", run.Age, run.TTL)
+	}
+	if run.CPU == nil || *run.CPU != 0.25 || run.Memory != nil {
+		t.Fatalf("sb_run cpu/memory = %v/%v, want latest cpu 0.25 and no memory", run.CPU, run.Memory)
+	}
+	if rows[1].CPU != nil || rows[1].TTL == nil || *rows[1].TTL != 3600 {
+		t.Fatalf("sb_paused = %+v, want ttl 3600 and no metrics", rows[1])
+	}
+}
+
+func TestSortSandboxTop(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	rows := output.SandboxTop{
+		{ID: "a", CPU: nil},
+		{ID: "b", CPU: value(0.5)},
+		{ID: "c", CPU: value(0.1)},
+		{ID: "d", CPU: value(0.5)},
+	}
+	ids := func() string {
+		var out []string
+		for _, row := range rows {
+			out = append(out, row.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	sortSandboxTop(rows, "cpu", true)
+	if got := ids(); got != "b,d,c,a" {
+		t.Fatalf("cpu desc = %s, want b,d,c,a", got)
+	}
+	sortSandboxTop(rows, "cpu", false)
+	if got := ids(); got != "c,b,d,a" {
+		t.Fatalf("cpu asc = %s, want c,b,d,a", got)
+	}
+}
+
+func TestTopViewHandleKey(t *testing.T) {
+	view := &topView{sortBy: "id"}
+	view.setRows(output.SandboxTop{{ID: "sb_b", Status: "running"}, {ID: "sb_a", Status: "failed"}}, time.Now())
+	if view.selected != "sb_a" {
+		t.Fatalf("selected = %q, want first row sb_a", view.selected)
+	}
+
+	view.handleKey("down")
+	view.handleKey("down")
+	if view.selected != "sb_b" {
+		t.Fatalf("selected after down = %q, want sb_b", view.selected)
+	}
+
+	view.handleKey("3")
+	if view.sortBy != "status" || view.desc || view.rows[0].ID != "sb_a" || view.selected != "sb_b" {
+		t.Fatalf("sort by status = %s desc=%v rows=%+v selected=%s", view.sortBy, view.desc, view.rows, view.selected)
+	}
+	view.handleKey("3")
+	if !view.desc || view.rows[0].ID != "sb_b" {
+		t.Fatalf("second press should reverse, rows = %+v", view.rows)
+	}
+
+	if action := view.handleKey("d"); action.kind != topActionNone || view.confirmDelete != "sb_b" {
+		t.Fatalf("d should ask for confirmation, action = %+v", action)
+	}
+	if action := view.handleKey("n"); action.kind != topActionNone || view.confirmDelete != "" {
+		t.Fatalf("n should cancel delete, action = %+v", action)
+	}
+	view.handleKey("d")
+	if action := view.handleKey("y"); action != (topAction{kind: topActionDelete, sandboxID: "sb_b"}) {
+		t.Fatalf("y action = %+v, want delete sb_b", action)
+	}
+	if action := view.handleKey("e"); action != (topAction{kind: topActionExec, sandboxID: "sb_b"}) {
+		t.Fatalf("e action = %+v, want exec sb_b", action)
+	}
+	if action := view.handleKey("ctrl-c"); action.kind != topActionQuit {
+		t.Fatalf("ctrl-c action = %+v, want quit", action)
+	}
+}
+
+func TestTopViewRenderHighlightsSelection(t *testing.T) {
+	view := &topView{sortBy: "id"}
+	var rows output.SandboxTop
+	for _, id := range []string{"sb_1", "sb_2", "sb_3", "sb_4", "sb_5"} {
+		rows = append(rows, output.SandboxTopRow{ID: id, Status: "running"})
+	}
+	view.setRows(rows, time.Now())
+	view.selected = "sb_5"
+
+	screen := view.render(6, 80)
+	if !strings.Contains(screen, "\x1b[7m sb_5") {
+		t.Fatalf("selected row is not highlighted:\n%q", screen)
+	}
+	if strings.Contains(screen, "sb_1") || !strings.Contains(screen, "sb_3") {
+		t.Fatalf("render should scroll the selection into view:\n%q", screen)
+	}
+}
+
+func TestParseTopKeys(t *testing.T) {
+	got := strings.Join(parseTopKeys([]byte("j\x1b[A\x1b[Bq\x03")), " ")
+	if got != "j up down q ctrl-c" {
+		t.Fatalf("parseTopKeys() = %q", got)
+	}
+}
@@ -9,11 +9,13 @@ import (
 )
 
 var (
-	volumeFilesRecursive       bool
-	volumeFilesUploadRecursive bool
-	volumeFilesParents         bool
-	volumeFilesStdin           bool
-	volumeFilesData            string
+	volumeFilesRecursive        bool
+	volumeFilesUploadRecursive  bool
+	volumeFilesUploadTransfer   fileTransferFlags
+	volumeFilesDownloadTransfer fileTransferFlags
+	volumeFilesParents          bool
+	volumeFilesStdin            bool
+	volumeFilesData             string
 )
 
 var volumeFilesCmd = &cobra.Command{
@@ -188,8 +190,13 @@ var volumeFilesMvCmd = &cobra.Command{
 var volumeFilesUploadCmd = &cobra.Command{
 	Use:   "upload <volume-id> <local-path> <remote-path>",
 	Short: "Upload a local file or directory",
-	Long:  `Upload a local file to a volume path. Use --recursive to upload a local directory.`,
-	Args:  cobra.ExactArgs(3),
+	Long: `Upload a local file to a volume path. Use --recursive to upload a local directory.
+
+Files are streamed with bounded memory and retried after network failures.
+Use --resume to skip files whose remote copy already has the same size and is
+not older, so rerunning an interrupted upload only sends what is missing. Use
+--verify to compare sha256 checksums once a single-file upload completes.`,
+	Args: cobra.ExactArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
 		volumeID := args[0]
 		localPath := args[1]
@@ -206,11 +213,36 @@ var volumeFilesUploadCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "Error reading local path: %v\n", err)
 			os.Exit(1)
 		}
+		opts, err := volumeFilesUploadTransfer.options(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fs := volumeFileSystem{client: client, volumeID: volumeID}
 		if info.IsDir() {
 			if !volumeFilesUploadRecursive {
 				fmt.Fprintf(os.Stderr, "Error uploading file: local path is a directory; use --recursive\n")
 				os.Exit(1)
 			}
+			if opts.Resume {
+				// Resuming compares each file with the volume, so the
+				// directory is uploaded file by file instead of as one archive.
+				opts.Progress = false
+				result, err := uploadSandboxDirectory(cmd.Context(), fs, localPath, remotePath, opts)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error uploading directory: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf(
+					"Directory uploaded to %s (files=%d directories=%d skipped=%d bytes=%d)\n",
+					remotePath,
+					result.Files,
+					result.Directories,
+					result.Skipped,
+					result.Bytes,
+				)
+				return
+			}
 			result, err := client.UploadVolumeDirectory(cmd.Context(), volumeID, localPath, remotePath)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error uploading directory: %v\n", err)
@@ -227,17 +259,18 @@ var volumeFilesUploadCmd = &cobra.Command{
 			return
 		}
 
-		data, err := os.ReadFile(localPath)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading local file: %v\n", err)
-			os.Exit(1)
-		}
-
-		_, err = client.WriteVolumeFile(cmd.Context(), volumeID, remotePath, data)
-		if err != nil {
+		if _, err := uploadFile(cmd.Context(), fs, localPath, remotePath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error uploading file: %v\n", err)
 			os.Exit(1)
 		}
+		if opts.Verify {
+			sum, err := verifyFileTransfer(cmd.Context(), fs, localPath, remotePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error verifying upload: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Verified sha256 %s\n", sum)
+		}
 
 		fmt.Printf("File uploaded to %s\n", remotePath)
 	},
@@ -246,28 +279,40 @@ var volumeFilesUploadCmd = &cobra.Command{
 var volumeFilesDownloadCmd = &cobra.Command{
 	Use:   "download <volume-id> <remote-path> <local-path>",
 	Short: "Download a file",
-	Long:  `Download a file from a volume to the local filesystem.`,
-	Args:  cobra.ExactArgs(3),
+	Long: `Download a file from a volume to the local filesystem.
+
+Files are streamed to a .s0part file that is renamed into place once complete.
+Use --resume to continue an interrupted download and --verify to compare sha256
+checksums once the download completes.`,
+	Args: cobra.ExactArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
 		volumeID := args[0]
 		remotePath := args[1]
 		localPath := args[2]
 
+		opts, err := volumeFilesDownloadTransfer.options(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		client, err := getClientRaw(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
 			os.Exit(1)
 		}
+		fs := volumeFileSystem{client: client, volumeID: volumeID}
 
-		data, err := client.ReadVolumeFile(cmd.Context(), volumeID, remotePath)
-		if err != nil {
+		if _, err := downloadFileStream(cmd.Context(), fs, remotePath, localPath, opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error downloading file: %v\n", err)
 			os.Exit(1)
 		}
-
-		if err := os.WriteFile(localPath, data, 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing local file: %v\n", err)
-			os.Exit(1)
+		if opts.Verify {
+			sum, err := verifyFileTransfer(cmd.Context(), fs, localPath, remotePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error verifying download: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Verified sha256 %s\n", sum)
 		}
 
 		fmt.Printf("File downloaded to %s\n", localPath)
@@ -371,6 +416,10 @@ func init() {
 
 	volumeFilesMkdirCmd.Flags().BoolVar(&volumeFilesParents, "parents", false, "create parent directories as needed")
 	volumeFilesUploadCmd.Flags().BoolVarP(&volumeFilesUploadRecursive, "recursive", "r", false, "upload a directory recursively")
+	addFileTransferFlags(volumeFilesUploadCmd, &volumeFilesUploadTransfer)
+	volumeFilesUploadCmd.Flags().BoolVar(&volumeFilesUploadTransfer.resume, "resume", false, "skip files whose remote copy is already up to date")
+	addFileTransferFlags(volumeFilesDownloadCmd, &volumeFilesDownloadTransfer)
+	volumeFilesDownloadCmd.Flags().BoolVar(&volumeFilesDownloadTransfer.resume, "resume", false, "resume a previously interrupted download")
 	volumeFilesWatchCmd.Flags().BoolVarP(&volumeFilesRecursive, "recursive", "r", false, "watch recursively")
 	volumeFilesWriteCmd.Flags().BoolVar(&volumeFilesStdin, "stdin", false, "read content from stdin")
 	volumeFilesWriteCmd.Flags().StringVar(&volumeFilesData, "data", "", "content to write directly")
@@ -0,0 +1,687 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+	"github.com/spf13/cobra"
+)
+
+// Workspace file and state locations, relative to the project directory.
+const (
+	defaultWorkspaceFile = "s0.yaml"
+	workspaceStateDir    = ".s0"
+	workspaceStateFile   = "state.json"
+)
+
+var (
+	workspaceFilePath  string
+	workspaceUpTimeout time.Duration
+)
+
+const workspaceExample = `Workspace file format (s0.yaml):
+  template: python-dev
+  config:                  # sandbox config, as in 'sandbox create -f'
+    ttl: 3600
+    resources:
+      memory: 2Gi
+  mounts:
+    - vol_abc123:/workspace/data
+  network:                 # network policy, as in 'sandbox network update -f'
+    mode: block-all
+    egress:
+      allowedDomains: [pypi.org]
+  services:                # services, as in 'sandbox service update --services-file'
+    - id: web
+      port: 8000
+      ingress:
+        public: true
+        routes:
+          - id: web
+            path_prefix: /
+            resume: true
+  files:
+    - source: ./src        # relative to s0.yaml
+      target: /workspace/src
+  sessions:                # execution session specs; name is required
+    - name: web
+      command: [python, -m, http.server, "8000"]`
+
+// workspaceSpec is the raw document of an s0.yaml file.
+type workspaceSpec struct {
+	Template   string              `json:"template"`
+	SnapshotID string              `json:"snapshot_id,omitempty"`
+	Config     json.RawMessage     `json:"config,omitempty"`
+	Mounts     []string            `json:"mounts,omitempty"`
+	Network    json.RawMessage     `json:"network,omitempty"`
+	Services   json.RawMessage     `json:"services,omitempty"`
+	Files      []workspaceFileSpec `json:"files,omitempty"`
+	Sessions   []json.RawMessage   `json:"sessions,omitempty"`
+}
+
+// workspaceFileSpec copies a local file or directory into the sandbox.
+// Directories are synced, so unchanged files are not uploaded again.
+type workspaceFileSpec struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// workspace is a parsed and validated s0.yaml file.
+type workspace struct {
+	// Dir is the directory holding the workspace file and its state.
+	Dir   string
+	Claim apispec.ClaimRequest
+	// Network and Services are nil when the file does not manage them.
+	Network  *apispec.SandboxNetworkPolicy
+	Services []apispec.SandboxAppService
+	Files    []workspaceFileSpec
+	Sessions []apispec.ExecutionSessionSpec
+}
+
+// workspaceState records what s0 up created, so later runs reuse it.
+type workspaceState struct {
+	Profile   string `json:"profile"`
+	TeamID    string `json:"team_id,omitempty"`
+	SandboxID string `json:"sandbox_id"`
+	// ClaimHash and ConfigHash fingerprint the claim fields and sandbox
+	// config the sandbox was last converged with.
+	ClaimHash  string `json:"claim_hash"`
+	ConfigHash string `json:"config_hash,omitempty"`
+	// Sessions maps session names to the session IDs created by s0 up.
+	Sessions map[string]string `json:"sessions,omitempty"`
+}
+
+// upCmd creates or reuses the sandbox described by s0.yaml.
+var upCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Create or update the sandbox described by s0.yaml",
+	Long: `Create or update the project sandbox described by an s0.yaml workspace file.
+
+The sandbox ID is recorded in .s0/state.json next to the workspace file, so
+later runs reuse the same sandbox instead of claiming a new one. Each run
+resumes the sandbox if it is paused, updates its config when it changed, and
+converges the network policy, services, files, and sessions. Sessions removed
+from the file are deleted. Changing the template, snapshot, or mounts requires
+'s0 down' first.
+
+` + workspaceExample + `
+
+Examples:
+  s0 up
+  s0 up -f envs/dev.yaml --timeout 10m`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ws, err := readWorkspaceFile(workspaceFilePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading workspace file: %v\n", err)
+			os.Exit(1)
+		}
+		profileName, teamID, err := aliasScope()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		state, err := loadWorkspaceState(ws.Dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading workspace state: %v\n", err)
+			os.Exit(1)
+		}
+		if state != nil && (state.Profile != profileName || state.TeamID != teamID) {
+			fmt.Fprintf(os.Stderr, "Error: workspace state belongs to profile %q (team %q); switch back or run 's0 down' there first\n", state.Profile, state.TeamID)
+			os.Exit(1)
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+
+		state, err = upWorkspaceSandbox(cmd.Context(), client, ws, state, profileName, teamID)
+		if err == nil {
+			err = convergeWorkspace(cmd.Context(), client, ws, state)
+		}
+		if state != nil {
+			if saveErr := saveWorkspaceState(ws.Dir, state); saveErr != nil {
+				fmt.Fprintf(os.Stderr, "Error saving workspace state: %v\n", saveErr)
+				os.Exit(1)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(sandboxWaitExitCode(err))
+		}
+		fmt.Printf("Workspace is up: %s\n", state.SandboxID)
+	},
+}
+
+// downCmd deletes the sandbox created by s0 up.
+var downCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Delete the sandbox created by s0 up",
+	Long: `Delete the sandbox recorded in the workspace state and remove the state file.
+Network policy, services, files, and sessions are removed with the sandbox.
+
+Examples:
+  s0 down
+  s0 down -f envs/dev.yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, err := workspaceDir(workspaceFilePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		state, err := loadWorkspaceState(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading workspace state: %v\n", err)
+			os.Exit(1)
+		}
+		if state == nil {
+			fmt.Println("Workspace is not up")
+			return
+		}
+
+		client, err := getClientRaw(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+			os.Exit(1)
+		}
+		if _, err := client.DeleteSandbox(cmd.Context(), state.SandboxID); err != nil && !isNotFoundError(err) {
+			fmt.Fprintf(os.Stderr, "Error deleting sandbox: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("sandbox/%s deleted\n", state.SandboxID)
+		if err := os.Remove(workspaceStatePath(dir)); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error removing workspace state: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{upCmd, downCmd} {
+		cmd.Flags().StringVarP(&workspaceFilePath, "file", "f", defaultWorkspaceFile, "workspace file")
+		rootCmd.AddCommand(cmd)
+	}
+	upCmd.Flags().DurationVar(&workspaceUpTimeout, "timeout", 5*time.Minute, "how long to wait for the sandbox to become running")
+	addSandboxRetryThrottledFlag(upCmd)
+}
+
+// workspaceDir returns the directory of the workspace file at path.
+func workspaceDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(abs), nil
+}
+
+// readWorkspaceFile reads and validates an s0.yaml file.
+func readWorkspaceFile(path string) (*workspace, error) {
+	data, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := workspaceDir(path)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := parseWorkspace(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	ws.Dir = dir
+	for i, file := range ws.Files {
+		if !filepath.IsAbs(file.Source) {
+			ws.Files[i].Source = filepath.Join(dir, file.Source)
+		}
+	}
+	return ws, nil
+}
+
+// parseWorkspace parses the YAML or JSON content of a workspace file.
+func parseWorkspace(data []byte) (*workspace, error) {
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	var spec workspaceSpec
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(spec.Template) == "" {
+		return nil, fmt.Errorf("template is required")
+	}
+	ws := &workspace{Claim: apispec.ClaimRequest{Template: apispec.NewOptString(strings.TrimSpace(spec.Template))}}
+	if spec.SnapshotID != "" {
+		ws.Claim.SnapshotID = apispec.NewOptString(spec.SnapshotID)
+	}
+	if len(spec.Config) > 0 {
+		var config apispec.SandboxConfig
+		if err := json.Unmarshal(spec.Config, &config); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		if config.Network.Set || len(config.Services) > 0 {
+			return nil, fmt.Errorf("config: declare network and services at the top level of the workspace file")
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("config: %w", err)
+		}
+		ws.Claim.Config = apispec.NewOptSandboxConfig(config)
+	}
+	if ws.Claim.Mounts, err = parseSandboxCreateMounts(spec.Mounts); err != nil {
+		return nil, fmt.Errorf("mounts: %w", err)
+	}
+	if err := ws.Claim.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sandbox claim: %w", err)
+	}
+
+	if len(spec.Network) > 0 {
+		if ws.Network, err = parseNetworkPolicyUpdateFile(spec.Network); err != nil {
+			return nil, fmt.Errorf("network: %w", err)
+		}
+	}
+	if len(spec.Services) > 0 {
+		wrapped, err := json.Marshal(map[string]json.RawMessage{"services": spec.Services})
+		if err != nil {
+			return nil, err
+		}
+		services, err := parseSandboxServices(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("services: %w", err)
+		}
+		ws.Services = services.Services
+	}
+
+	for i, file := range spec.Files {
+		if file.Source == "" || file.Target == "" {
+			return nil, fmt.Errorf("files[%d]: source and target are required", i)
+		}
+		if !strings.HasPrefix(file.Target, "/") {
+			return nil, fmt.Errorf("files[%d]: target %q must be absolute", i, file.Target)
+		}
+	}
+	ws.Files = spec.Files
+
+	names := map[string]bool{}
+	for i, rawSession := range spec.Sessions {
+		var session apispec.ExecutionSessionSpec
+		if err := json.Unmarshal(rawSession, &session); err != nil {
+			return nil, fmt.Errorf("sessions[%d]: %w", i, err)
+		}
+		name, _ := session.Name.Get()
+		if name == "" {
+			return nil, fmt.Errorf("sessions[%d]: name is required", i)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("sessions[%d]: duplicate session name %q", i, name)
+		}
+		names[name] = true
+		if len(session.Command) == 0 {
+			return nil, fmt.Errorf("sessions[%d]: command is required", i)
+		}
+		ws.Sessions = append(ws.Sessions, session)
+	}
+	return ws, nil
+}
+
+// claimHash fingerprints the claim fields that cannot change after the
+// sandbox is created.
+func (ws *workspace) claimHash() string {
+	parts := []string{ws.Claim.Template.Or(""), ws.Claim.SnapshotID.Or("")}
+	for _, mount := range ws.Claim.Mounts {
+		parts = append(parts, mount.SandboxvolumeID+":"+mount.MountPoint)
+	}
+	sort.Strings(parts[2:])
+	return hashWorkspaceValue(strings.Join(parts, "\n"))
+}
+
+// configHash fingerprints the sandbox config, or returns "" when the file
+// does not declare one.
+func (ws *workspace) configHash() (string, error) {
+	config, ok := ws.Claim.Config.Get()
+	if !ok {
+		return "", nil
+	}
+	data, err := json.Marshal(&config)
+	if err != nil {
+		return "", err
+	}
+	return hashWorkspaceValue(string(data)), nil
+}
+
+func hashWorkspaceValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func workspaceStatePath(dir string) string {
+	return filepath.Join(dir, workspaceStateDir, workspaceStateFile)
+}
+
+// loadWorkspaceState reads the state of the workspace in dir, or returns nil
+// when the workspace is not up.
+func loadWorkspaceState(dir string) (*workspaceState, error) {
+	data, err := os.ReadFile(workspaceStatePath(dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state workspaceState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", workspaceStatePath(dir), err)
+	}
+	if state.SandboxID == "" {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func saveWorkspaceState(dir string, state *workspaceState) error {
+	statePath := workspaceStatePath(dir)
+	if err := os.MkdirAll(filepath.Dir(statePath), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, append(data, '\n'), 0o600)
+}
+
+// upWorkspaceSandbox reuses the sandbox recorded in state when it still
+// exists, claiming a new one otherwise, and waits for it to run. The returned
+// state is non-nil once a sandbox exists, even when a later step fails.
+func upWorkspaceSandbox(ctx context.Context, client *sandbox0.Client, ws *workspace, state *workspaceState, profileName, teamID string) (*workspaceState, error) {
+	configHash, err := ws.configHash()
+	if err != nil {
+		return state, err
+	}
+
+	reused := false
+	if state != nil {
+		reused, err = reuseWorkspaceSandbox(ctx, client, ws, state, configHash)
+		if err != nil {
+			return state, err
+		}
+	}
+	if !reused {
+		var sandbox *sandbox0.Sandbox
+		err := retryClaimStartThrottled(ctx, sandboxRetryThrottled, "Sandbox claim", func() error {
+			var err error
+			sandbox, err = client.ClaimSandboxRequest(ctx, ws.Claim)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating sandbox: %s", formatSandboxCreateError(err))
+		}
+		state = &workspaceState{
+			Profile:    profileName,
+			TeamID:     teamID,
+			SandboxID:  sandbox.ID,
+			ClaimHash:  ws.claimHash(),
+			ConfigHash: configHash,
+		}
+		fmt.Printf("sandbox/%s created\n", sandbox.ID)
+		if err := checkSandboxBootstrapMounts(sandbox); err != nil {
+			return state, err
+		}
+	}
+
+	if _, err := waitForSandboxStatus(ctx, client, state.SandboxID, workspaceUpTimeout, string(apispec.SandboxLifecycleStatusRunning)); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// reuseWorkspaceSandbox prepares the sandbox recorded in state for reuse and
+// reports false when it no longer exists and a new one must be claimed.
+func reuseWorkspaceSandbox(ctx context.Context, client *sandbox0.Client, ws *workspace, state *workspaceState, configHash string) (bool, error) {
+	sandbox, err := client.GetSandbox(ctx, state.SandboxID)
+	if isNotFoundError(err) {
+		fmt.Fprintf(os.Stderr, "Sandbox %s no longer exists; creating a new one\n", state.SandboxID)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting sandbox %s: %w", state.SandboxID, err)
+	}
+	switch sandbox.Status {
+	case apispec.SandboxLifecycleStatusFailed, apispec.SandboxLifecycleStatusTerminating:
+		fmt.Fprintf(os.Stderr, "Sandbox %s is %s; creating a new one\n", state.SandboxID, sandbox.Status)
+		return false, nil
+	}
+
+	if state.ClaimHash != ws.claimHash() {
+		fmt.Fprintf(os.Stderr, "Warning: template, snapshot, or mounts changed since sandbox %s was created; run 's0 down' and 's0 up' to recreate it\n", state.SandboxID)
+	}
+	if sandbox.Paused || sandbox.Status == apispec.SandboxLifecycleStatusPaused {
+		err := retryClaimStartThrottled(ctx, sandboxRetryThrottled, "Sandbox resume "+state.SandboxID, func() error {
+			_, err := client.ResumeSandbox(ctx, state.SandboxID)
+			return err
+		})
+		if err != nil {
+			return false, fmt.Errorf("resuming sandbox %s: %s", state.SandboxID, formatSandboxCreateError(err))
+		}
+		fmt.Printf("sandbox/%s resumed\n", state.SandboxID)
+	} else {
+		fmt.Printf("sandbox/%s unchanged\n", state.SandboxID)
+	}
+
+	if config, ok := ws.Claim.Config.Get(); ok && configHash != state.ConfigHash {
+		request := apispec.SandboxUpdateRequest{Config: apispec.NewOptSandboxUpdateConfig(workspaceUpdateConfig(config))}
+		if _, err := client.UpdateSandbox(ctx, state.SandboxID, request); err != nil {
+			return true, fmt.Errorf("updating sandbox config: %w", err)
+		}
+		fmt.Printf("sandbox/%s configured\n", state.SandboxID)
+	}
+	state.ConfigHash = configHash
+	return true, nil
+}
+
+// workspaceUpdateConfig converts the claim config of a workspace to the
+// fields an existing sandbox accepts.
+func workspaceUpdateConfig(config apispec.SandboxConfig) apispec.SandboxUpdateConfig {
+	update := apispec.SandboxUpdateConfig{
+		Resources:  config.Resources,
+		TTL:        config.TTL,
+		HardTTL:    config.HardTTL,
+		AutoResume: config.AutoResume,
+	}
+	if env, ok := config.EnvVars.Get(); ok {
+		update.EnvVars = apispec.NewOptSandboxUpdateConfigEnvVars(apispec.SandboxUpdateConfigEnvVars(env))
+	}
+	return update
+}
+
+// convergeWorkspace applies the network policy, services, files, and
+// sessions of ws to the sandbox recorded in state.
+func convergeWorkspace(ctx context.Context, client *sandbox0.Client, ws *workspace, state *workspaceState) error {
+	var objects []manifestObject
+	if ws.Network != nil {
+		objects = append(objects, &networkPolicyManifestObject{sandboxID: state.SandboxID, policy: *ws.Network})
+	}
+	if ws.Services != nil {
+		objects = append(objects, &servicesManifestObject{sandboxID: state.SandboxID, services: ws.Services})
+	}
+	for _, object := range objects {
+		plan, err := planManifestObject(ctx, client, object)
+		if err != nil {
+			return fmt.Errorf("planning %s: %w", object.Ref(), err)
+		}
+		if plan.Action == manifestActionUnchanged {
+			fmt.Printf("%s unchanged\n", object.Ref())
+			continue
+		}
+		if err := object.Update(ctx, client); err != nil {
+			return fmt.Errorf("updating %s: %w", object.Ref(), err)
+		}
+		fmt.Printf("%s configured\n", object.Ref())
+	}
+
+	fs := newSandboxFileSystem(client, state.SandboxID)
+	for _, file := range ws.Files {
+		summary, err := syncWorkspaceFile(ctx, fs, file)
+		if err != nil {
+			return fmt.Errorf("uploading %s: %w", file.Source, err)
+		}
+		fmt.Printf("files/%s synced (uploaded=%d unchanged=%d)\n", strings.TrimPrefix(file.Target, "/"), summary.Uploaded, summary.Unchanged)
+	}
+
+	return convergeWorkspaceSessions(ctx, fs.Sandbox, ws.Sessions, state)
+}
+
+// syncWorkspaceFile uploads a local file, or syncs a local directory honoring
+// its .s0ignore file, to the target path.
+func syncWorkspaceFile(ctx context.Context, fs remoteFileSystem, file workspaceFileSpec) (sandboxSyncSummary, error) {
+	info, err := os.Stat(file.Source)
+	if err != nil {
+		return sandboxSyncSummary{}, err
+	}
+	opts := fileTransferOptions{Retries: defaultTransferRetries}
+	if !info.IsDir() {
+		if _, err := fs.Mkdir(ctx, path.Dir(file.Target), true); err != nil && !isSandboxFileExists(err) {
+			return sandboxSyncSummary{}, err
+		}
+		written, err := uploadFileStream(ctx, fs, file.Source, file.Target, opts)
+		if err != nil {
+			return sandboxSyncSummary{}, err
+		}
+		return sandboxSyncSummary{Uploaded: 1, Bytes: written}, nil
+	}
+
+	ignore, err := loadSandboxIgnore(file.Source)
+	if err != nil {
+		return sandboxSyncSummary{}, err
+	}
+	local, _, err := scanLocalTree(file.Source, ignore)
+	if err != nil {
+		return sandboxSyncSummary{}, err
+	}
+	remote, err := scanRemoteTree(ctx, fs, file.Target)
+	if err != nil {
+		return sandboxSyncSummary{}, err
+	}
+	actions, unchanged := planSandboxSync(local, remote, false, ignore)
+	summary, err := applySandboxSync(ctx, fs, file.Source, file.Target, actions, opts)
+	summary.Unchanged = unchanged
+	return summary, err
+}
+
+// workspaceSessionAPI is the session API of a sandbox used by s0 up.
+type workspaceSessionAPI interface {
+	ListSessions(ctx context.Context) ([]apispec.ExecutionSession, error)
+	CreateSession(ctx context.Context, spec apispec.ExecutionSessionSpec, opts *sandbox0.CreateSessionOptions) (*apispec.ExecutionSession, error)
+	DeleteSession(ctx context.Context, sessionID string) (*apispec.SuccessDeletedResponse, error)
+	CreateSessionAttempt(ctx context.Context, sessionID string, replaceCurrent bool) (*apispec.ExecutionSession, error)
+}
+
+// workspaceSessionEndedPhases are the phases in which a session no longer
+// runs its command and s0 up starts a new attempt.
+var workspaceSessionEndedPhases = []apispec.ExecutionSessionPhase{
+	apispec.ExecutionSessionPhaseStopped,
+	apispec.ExecutionSessionPhaseExited,
+	apispec.ExecutionSessionPhaseFailed,
+}
+
+// convergeWorkspaceSessions creates missing sessions, replaces sessions whose
+// spec changed, restarts ended sessions, and deletes sessions that s0 up
+// created but that were removed from the workspace file. Sessions are matched
+// by name.
+func convergeWorkspaceSessions(ctx context.Context, api workspaceSessionAPI, desired []apispec.ExecutionSessionSpec, state *workspaceState) error {
+	if len(desired) == 0 && len(state.Sessions) == 0 {
+		return nil
+	}
+	live, err := api.ListSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	byName := map[string]apispec.ExecutionSession{}
+	for _, session := range live {
+		if name, ok := session.Spec.Name.Get(); ok {
+			byName[name] = session
+		}
+	}
+
+	sessions := map[string]string{}
+	for _, spec := range desired {
+		name := spec.Name.Or("")
+		current, exists := byName[name]
+		if exists {
+			changed, err := workspaceSessionChanged(spec, current.Spec)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				if slices.Contains(workspaceSessionEndedPhases, current.Phase) {
+					if _, err := api.CreateSessionAttempt(ctx, current.ID, false); err != nil {
+						return fmt.Errorf("restarting session %s: %w", name, err)
+					}
+					fmt.Printf("session/%s restarted\n", name)
+				} else {
+					fmt.Printf("session/%s unchanged\n", name)
+				}
+				sessions[name] = current.ID
+				continue
+			}
+			if _, err := api.DeleteSession(ctx, current.ID); err != nil && !isNotFoundError(err) {
+				return fmt.Errorf("replacing session %s: %w", name, err)
+			}
+		}
+		created, err := api.CreateSession(ctx, spec, nil)
+		if err != nil {
+			return fmt.Errorf("creating session %s: %w", name, err)
+		}
+		sessions[name] = created.ID
+		if exists {
+			fmt.Printf("session/%s replaced\n", name)
+		} else {
+			fmt.Printf("session/%s created\n", name)
+		}
+	}
+
+	for name, id := range state.Sessions {
+		if _, keep := sessions[name]; keep {
+			continue
+		}
+		if _, err := api.DeleteSession(ctx, id); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("deleting session %s: %w", name, err)
+		}
+		fmt.Printf("session/%s deleted\n", name)
+	}
+	state.Sessions = sessions
+	return nil
+}
+
+// workspaceSessionChanged reports whether the declared fields of desired
+// differ from the live session spec.
+func workspaceSessionChanged(desired, live apispec.ExecutionSessionSpec) (bool, error) {
+	desiredValue, err := normalizeManifestValue(desired)
+	if err != nil {
+		return false, err
+	}
+	liveValue, err := normalizeManifestValue(live)
+	if err != nil {
+		return false, err
+	}
+	return len(diffManifestValues("spec", desiredValue, liveValue)) > 0, nil
+}
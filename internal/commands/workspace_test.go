@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+const testWorkspaceYAML = `template: python-dev
+config:
+  ttl: 3600
+  resources:
+    memory: 2Gi
+mounts:
+  - vol_abc123:/workspace/data
+network:
+  mode: block-all
+  egress:
+    allowedDomains: [pypi.org]
+services:
+  - id: web
+    port: 8000
+    ingress:
+      public: true
+      routes:
+        - id: web
+          path_prefix: /
+          resume: true
+files:
+  - source: ./src
+    target: /workspace/src
+sessions:
+  - name: web
+    command: [python, -m, http.server, "8000"]
+`
+
+func TestParseWorkspace(t *testing.T) {
+	ws, err := parseWorkspace([]byte(testWorkspaceYAML))
+	if err != nil {
+		t.Fatalf("parseWorkspace() error = %v", err)
+	}
+	if got := ws.Claim.Template.Or(""); got != "python-dev" {
+		t.Fatalf("template = %q, want python-dev", got)
+	}
+	config, ok := ws.Claim.Config.Get()
+	if !ok || config.TTL.Or(0) != 3600 {
+		t.Fatalf("config = %+v, want ttl 3600", ws.Claim.Config)
+	}
+	if len(ws.Claim.Mounts) != 1 || ws.Claim.Mounts[0].MountPoint != "/workspace/data" {
+		t.Fatalf("mounts = %+v", ws.Claim.Mounts)
+	}
+	if ws.Network == nil || ws.Network.Mode != apispec.SandboxNetworkPolicyModeBlockAll {
+		t.Fatalf("network = %+v, want block-all", ws.Network)
+	}
+	if len(ws.Services) != 1 || ws.Services[0].ID != "web" {
+		t.Fatalf("services = %+v", ws.Services)
+	}
+	if len(ws.Files) != 1 || len(ws.Sessions) != 1 || ws.Sessions[0].Name.Or("") != "web" {
+		t.Fatalf("files = %+v, sessions = %+v", ws.Files, ws.Sessions)
+	}
+
+	minimal, err := parseWorkspace([]byte("template: base\n"))
+	if err != nil {
+		t.Fatalf("parseWorkspace(minimal) error = %v", err)
+	}
+	if minimal.Network != nil || minimal.Services != nil {
+		t.Fatal("network and services should be unmanaged when omitted")
+	}
+}
+
+func TestParseWorkspaceRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]string{
+		"missing template":     "config:\n  ttl: 60\n",
+		"unknown field":        "template: base\nimage: python\n",
+		"network in config":    "template: base\nconfig:\n  network:\n    mode: block-all\n",
+		"relative mount":       "template: base\nmounts: [vol_1:data]\n",
+		"relative file target": "template: base\nfiles:\n  - source: .\n    target: app\n",
+		"unnamed session":      "template: base\nsessions:\n  - command: [sleep, '1']\n",
+		"duplicate session":    "template: base\nsessions:\n  - name: a\n    command: [a]\n  - name: a\n    command: [b]\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseWorkspace([]byte(data)); err == nil {
+				t.Fatal("parseWorkspace() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestReadWorkspaceFileAndState(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "s0.yaml")
+	if err := os.WriteFile(path, []byte(testWorkspaceYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ws, err := readWorkspaceFile(path)
+	if err != nil {
+		t.Fatalf("readWorkspaceFile() error = %v", err)
+	}
+	if want := filepath.Join(dir, "src"); ws.Files[0].Source != want {
+		t.Fatalf("file source = %q, want %q", ws.Files[0].Source, want)
+	}
+
+	state, err := loadWorkspaceState(ws.Dir)
+	if err != nil || state != nil {
+		t.Fatalf("loadWorkspaceState() = %+v, %v; want nil state", state, err)
+	}
+	want := &workspaceState{Profile: "default", SandboxID: "sb_1", ClaimHash: ws.claimHash(), Sessions: map[string]string{"web": "ses_1"}}
+	if err := saveWorkspaceState(ws.Dir, want); err != nil {
+		t.Fatalf("saveWorkspaceState() error = %v", err)
+	}
+	state, err = loadWorkspaceState(ws.Dir)
+	if err != nil {
+		t.Fatalf("loadWorkspaceState() error = %v", err)
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Fatalf("state = %+v, want %+v", state, want)
+	}
+}
+
+func TestWorkspaceClaimHashIgnoresMountOrder(t *testing.T) {
+	a, err := parseWorkspace([]byte("template: base\nmounts: [vol_1:/a, vol_2:/b]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := parseWorkspace([]byte("template: base\nmounts: [vol_2:/b, vol_1:/a]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := parseWorkspace([]byte("template: other\nmounts: [vol_1:/a, vol_2:/b]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.claimHash() != b.claimHash() || a.claimHash() == c.claimHash() {
+		t.Fatal("claim hash should depend on the template and mount set only")
+	}
+}
+
+type fakeWorkspaceSessions struct {
+	live  []apispec.ExecutionSession
+	calls []string
+}
+
+func (f *fakeWorkspaceSessions) ListSessions(context.Context) ([]apispec.ExecutionSession, error) {
+	return f.live, nil
+}
+
+func (f *fakeWorkspaceSessions) CreateSession(_ context.Context, spec apispec.ExecutionSessionSpec, _ *sandbox0.CreateSessionOptions) (*apispec.ExecutionSession, error) {
+	name := spec.Name.Or("")
+	f.calls = append(f.calls, "create "+name)
+	return &apispec.ExecutionSession{ID: "ses_new_" + name, Spec: spec}, nil
+}
+
+func (f *fakeWorkspaceSessions) DeleteSession(_ context.Context, sessionID string) (*apispec.SuccessDeletedResponse, error) {
+	f.calls = append(f.calls, "delete "+sessionID)
+	return &apispec.SuccessDeletedResponse{}, nil
+}
+
+func (f *fakeWorkspaceSessions) CreateSessionAttempt(_ context.Context, sessionID string, _ bool) (*apispec.ExecutionSession, error) {
+	f.calls = append(f.calls, "restart "+sessionID)
+	return &apispec.ExecutionSession{ID: sessionID}, nil
+}
+
+func testSessionSpec(name string, command ...string) apispec.ExecutionSessionSpec {
+	return apispec.ExecutionSessionSpec{Name: apispec.NewOptString(name), Command: command}
+}
+
+func TestConvergeWorkspaceSessions(t *testing.T) {
+	liveSpec := func(name string, command ...string) apispec.ExecutionSessionSpec {
+		spec := testSessionSpec(name, command...)
+		spec.Cwd = apispec.NewOptString("/home/user") // server-side default
+		return spec
+	}
+	api := &fakeWorkspaceSessions{live: []apispec.ExecutionSession{
+		{ID: "ses_web", Spec: liveSpec("web", "serve"), Phase: apispec.ExecutionSessionPhaseRunning},
+		{ID: "ses_worker", Spec: liveSpec("worker", "work"), Phase: apispec.ExecutionSessionPhaseExited},
+		{ID: "ses_db", Spec: liveSpec("db", "postgres"), Phase: apispec.ExecutionSessionPhaseRunning},
+		{ID: "ses_old", Spec: liveSpec("old", "legacy"), Phase: apispec.ExecutionSessionPhaseRunning},
+	}}
+	desired := []apispec.ExecutionSessionSpec{
+		testSessionSpec("web", "serve"),
+		testSessionSpec("worker", "work"),
+		testSessionSpec("db", "postgres", "-p", "5433"),
+		testSessionSpec("tests", "watch"),
+	}
+	state := &workspaceState{Sessions: map[string]string{"web": "ses_web", "old": "ses_old"}}
+
+	if err := convergeWorkspaceSessions(context.Background(), api, desired, state); err != nil {
+		t.Fatalf("convergeWorkspaceSessions() error = %v", err)
+	}
+
+	sort.Strings(api.calls)
+	wantCalls := []string{"create db", "create tests", "delete ses_db", "delete ses_old", "restart ses_worker"}
+	if strings.Join(api.calls, ",") != strings.Join(wantCalls, ",") {
+		t.Fatalf("calls = %q, want %q", api.calls, wantCalls)
+	}
+	wantSessions := map[string]string{"web": "ses_web", "worker": "ses_worker", "db": "ses_new_db", "tests": "ses_new_tests"}
+	if !reflect.DeepEqual(state.Sessions, wantSessions) {
+		t.Fatalf("state sessions = %v, want %v", state.Sessions, wantSessions)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+)
+
+// AliasFileName is the alias registry file, stored next to the config file.
+const AliasFileName = "aliases.yaml"
+
+// AliasPrefix marks an argument as an alias reference, e.g. "@api-dev".
+const AliasPrefix = "@"
+
+// noTeamScope is the team scope used when no current team is selected.
+const noTeamScope = "-"
+
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Aliases is the local registry of friendly names for resource IDs. Aliases
+// are scoped by profile and current team, so the same name can point at
+// different resources in different environments.
+type Aliases struct {
+	// Profiles maps profile name to team ID to alias name to resource ID.
+	Profiles map[string]map[string]map[string]string `json:"profiles,omitempty"`
+}
+
+// ValidateAliasName checks that name can be used as an alias.
+func ValidateAliasName(name string) error {
+	if !aliasNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// AliasFilePath returns the path of the alias registry.
+func AliasFilePath() string {
+	configPath := expandPath(cfgFile)
+	if configPath == "" {
+		configPath = expandPath(DefaultConfigFile)
+	}
+	return filepath.Join(filepath.Dir(configPath), AliasFileName)
+}
+
+// LoadAliases reads the alias registry. A missing file is an empty registry.
+func LoadAliases() (*Aliases, error) {
+	aliases := &Aliases{}
+	data, err := os.ReadFile(AliasFilePath())
+	if os.IsNotExist(err) {
+		return aliases, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aliases: %w", err)
+	}
+	if err := yaml.Unmarshal(data, aliases); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// Save writes the alias registry to disk.
+func (a *Aliases) Save() error {
+	path := AliasFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	data, err := yaml.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write aliases: %w", err)
+	}
+	return nil
+}
+
+// Get returns the resource ID of name in the profile and team scope.
+func (a *Aliases) Get(profileName, teamID, name string) (string, bool) {
+	id, ok := a.Profiles[profileName][aliasTeamScope(teamID)][name]
+	return id, ok
+}
+
+// Set records name as an alias for id in the profile and team scope.
+func (a *Aliases) Set(profileName, teamID, name, id string) {
+	if a.Profiles == nil {
+		a.Profiles = map[string]map[string]map[string]string{}
+	}
+	teams := a.Profiles[profileName]
+	if teams == nil {
+		teams = map[string]map[string]string{}
+		a.Profiles[profileName] = teams
+	}
+	scope := aliasTeamScope(teamID)
+	if teams[scope] == nil {
+		teams[scope] = map[string]string{}
+	}
+	teams[scope][name] = id
+}
+
+// Delete removes name from the profile and team scope and reports whether
+// it existed.
+func (a *Aliases) Delete(profileName, teamID, name string) bool {
+	scope := aliasTeamScope(teamID)
+	names := a.Profiles[profileName][scope]
+	if _, ok := names[name]; !ok {
+		return false
+	}
+	delete(names, name)
+	if len(names) == 0 {
+		delete(a.Profiles[profileName], scope)
+	}
+	if len(a.Profiles[profileName]) == 0 {
+		delete(a.Profiles, profileName)
+	}
+	return true
+}
+
+// List returns a copy of the aliases of the profile and team scope, keyed
+// by alias name.
+func (a *Aliases) List(profileName, teamID string) map[string]string {
+	aliases := map[string]string{}
+	for name, id := range a.Profiles[profileName][aliasTeamScope(teamID)] {
+		aliases[name] = id
+	}
+	return aliases
+}
+
+func aliasTeamScope(teamID string) string {
+	if teamID == "" {
+		return noTeamScope
+	}
+	return teamID
+}
@@ -26,6 +26,8 @@ type Profile struct {
 	Token                 string `yaml:"token" mapstructure:"token"`
 	RefreshToken          string `yaml:"refresh-token" mapstructure:"refresh-token"`
 	ExpiresAt             int64  `yaml:"expires-at" mapstructure:"expires-at"`
+	// OutputFormat overrides output.format for this profile.
+	OutputFormat string `yaml:"output-format,omitempty" mapstructure:"output-format"`
 }
 
 type CurrentTeamTarget struct {
@@ -37,6 +39,9 @@ type CurrentTeamTarget struct {
 // OutputConfig represents output formatting configuration.
 type OutputConfig struct {
 	Format string `yaml:"format" mapstructure:"format"`
+	// Commands maps a command path such as "sandbox logs" to its default
+	// format. A parent path such as "sandbox" applies to all its subcommands.
+	Commands map[string]string `yaml:"commands,omitempty" mapstructure:"commands"`
 }
 
 // Default values.
@@ -152,6 +157,28 @@ func (c *Config) GetActiveProfile() string {
 	return DefaultProfile
 }
 
+// GetOutputFormat returns the default output format for a command path
+// (without the program name, e.g. "sandbox logs").
+// Priority: output.commands (most specific path first) > profile output-format > output.format > default
+func (c *Config) GetOutputFormat(profileName, commandPath string) string {
+	fields := strings.Fields(strings.ToLower(commandPath))
+	for i := len(fields); i > 0; i-- {
+		key := strings.Join(fields[:i], " ")
+		for name, format := range c.Output.Commands {
+			if strings.Join(strings.Fields(strings.ToLower(name)), " ") == key && strings.TrimSpace(format) != "" {
+				return strings.TrimSpace(format)
+			}
+		}
+	}
+	if p, ok := c.Profiles[profileName]; ok && strings.TrimSpace(p.OutputFormat) != "" {
+		return strings.TrimSpace(p.OutputFormat)
+	}
+	if strings.TrimSpace(c.Output.Format) != "" {
+		return strings.TrimSpace(c.Output.Format)
+	}
+	return DefaultFormat
+}
+
 // GetProfile returns the specified profile configuration.
 func (c *Config) GetProfile(name string) (*Profile, error) {
 	p, ok := c.Profiles[name]
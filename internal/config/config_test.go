@@ -66,3 +66,104 @@ func TestGetConfiguredGatewayModeIgnoresProfileModeForEndpointOverride(t *testin
 		t.Fatalf("GetConfiguredGatewayMode() with flag override = %q, %v, want empty, false", mode, ok)
 	}
 }
+
+func TestGetOutputFormatPrefersCommandThenProfileThenGlobal(t *testing.T) {
+	configPath := t.TempDir() + "/config.yaml"
+	originalPath := *GetConfigFile()
+	t.Cleanup(func() { SetConfigFile(originalPath) })
+	SetConfigFile(configPath)
+
+	raw := `current-profile: default
+profiles:
+  default:
+    api-url: https://api.sandbox0.ai
+  ci:
+    output-format: yaml
+output:
+  format: json
+  commands:
+    sandbox logs: table
+    template: yaml
+`
+	if err := os.WriteFile(configPath, []byte(raw), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		profile string
+		command string
+		want    string
+	}{
+		{profile: "default", command: "sandbox logs", want: "table"},
+		{profile: "ci", command: "sandbox logs", want: "table"},
+		{profile: "default", command: "template get", want: "yaml"},
+		{profile: "default", command: "sandbox list", want: "json"},
+		{profile: "ci", command: "sandbox list", want: "yaml"},
+	}
+	for _, tt := range tests {
+		if got := cfg.GetOutputFormat(tt.profile, tt.command); got != tt.want {
+			t.Fatalf("GetOutputFormat(%q, %q) = %q, want %q", tt.profile, tt.command, got, tt.want)
+		}
+	}
+
+	if got := (&Config{}).GetOutputFormat("default", "sandbox list"); got != DefaultFormat {
+		t.Fatalf("GetOutputFormat() without config = %q, want %q", got, DefaultFormat)
+	}
+}
+
+func TestAliasesAreScopedByProfileAndTeam(t *testing.T) {
+	originalPath := *GetConfigFile()
+	t.Cleanup(func() { SetConfigFile(originalPath) })
+	SetConfigFile(t.TempDir() + "/config.yaml")
+
+	aliases, err := LoadAliases()
+	if err != nil {
+		t.Fatalf("LoadAliases() error = %v", err)
+	}
+	aliases.Set("staging", "team_a", "api-dev", "sb_staging")
+	aliases.Set("prod", "team_a", "api-dev", "sb_prod")
+	aliases.Set("prod", "", "api-dev", "sb_personal")
+	if err := aliases.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := LoadAliases()
+	if err != nil {
+		t.Fatalf("LoadAliases() error = %v", err)
+	}
+	for _, tt := range []struct{ profile, team, want string }{
+		{"staging", "team_a", "sb_staging"},
+		{"prod", "team_a", "sb_prod"},
+		{"prod", "", "sb_personal"},
+	} {
+		if got, ok := loaded.Get(tt.profile, tt.team, "api-dev"); !ok || got != tt.want {
+			t.Fatalf("Get(%q, %q) = %q, %v; want %q", tt.profile, tt.team, got, ok, tt.want)
+		}
+	}
+	if _, ok := loaded.Get("staging", "team_b", "api-dev"); ok {
+		t.Fatal("alias leaked into another team scope")
+	}
+	if !loaded.Delete("staging", "team_a", "api-dev") || loaded.Delete("staging", "team_a", "api-dev") {
+		t.Fatal("Delete() should remove the alias exactly once")
+	}
+	if _, ok := loaded.Profiles["staging"]; ok {
+		t.Fatal("empty profile scope was not pruned")
+	}
+}
+
+func TestValidateAliasName(t *testing.T) {
+	for _, name := range []string{"api-dev", "db.v2", "A_1"} {
+		if err := ValidateAliasName(name); err != nil {
+			t.Fatalf("ValidateAliasName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-x", "a:b", "a b", "@x"} {
+		if err := ValidateAliasName(name); err == nil {
+			t.Fatalf("ValidateAliasName(%q) error = nil, want error", name)
+		}
+	}
+}
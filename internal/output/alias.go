@@ -0,0 +1,39 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// AliasListItem is one local alias.
+type AliasListItem struct {
+	Name string `json:"name" yaml:"name"`
+	ID   string `json:"id" yaml:"id"`
+}
+
+// AliasList is the alias registry of one profile and team, sorted by name.
+type AliasList []AliasListItem
+
+// NewAliasList builds a sorted alias list from a name-to-ID map.
+func NewAliasList(aliases map[string]string) AliasList {
+	items := make(AliasList, 0, len(aliases))
+	for name, id := range aliases {
+		items = append(items, AliasListItem{Name: name, ID: id})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items
+}
+
+func (f *TableFormatter) formatAliasList(w io.Writer, aliases AliasList) error {
+	if len(aliases) == 0 {
+		_, _ = fmt.Fprintln(w, "No aliases found.")
+		return nil
+	}
+	t := f.newTable(w)
+	t.Header([]string{"NAME", "ID"})
+	for _, alias := range aliases {
+		_ = t.Append([]string{"@" + alias.Name, alias.ID})
+	}
+	return t.Render()
+}
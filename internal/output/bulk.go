@@ -0,0 +1,23 @@
+package output
+
+import "io"
+
+// SandboxOperationResult is the outcome of a lifecycle operation on one
+// sandbox of a bulk run.
+type SandboxOperationResult struct {
+	ID     string `json:"id" yaml:"id"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SandboxOperationResults is the per-sandbox summary of a bulk run.
+type SandboxOperationResults []SandboxOperationResult
+
+func (f *TableFormatter) formatSandboxOperationResults(w io.Writer, results SandboxOperationResults) error {
+	t := f.newTable(w)
+	t.Header([]string{"ID", "RESULT", "ERROR"})
+	for _, result := range results {
+		_ = t.Append([]string{result.ID, result.Result, valueOrDash(result.Error)})
+	}
+	return t.Render()
+}
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// noneValue is shown for custom columns that have no value.
+const noneValue = "<none>"
+
+// customColumn is one HEADER:expression pair.
+type customColumn struct {
+	header string
+	path   []jsonPathStep
+}
+
+// CustomColumnsFormatter renders list items as a table with user-defined
+// columns, e.g. 'ID:.id,STATUS:.status'.
+type CustomColumnsFormatter struct {
+	spec        string
+	noHeaders   bool
+	showSecrets bool
+}
+
+// Format writes one row per list item to the writer.
+func (f *CustomColumnsFormatter) Format(w io.Writer, data any) error {
+	columns, err := parseCustomColumns(f.spec)
+	if err != nil {
+		return err
+	}
+	items, err := genericItems(redactSensitiveData(data, f.showSecrets))
+	if err != nil {
+		return err
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	t := newTable(w)
+	if !f.noHeaders {
+		t.Header(headers)
+	}
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = customColumnValue(column, item)
+		}
+		_ = t.Append(row)
+	}
+	return t.Render()
+}
+
+// parseCustomColumns parses a comma-separated list of HEADER:expression
+// pairs. Expressions are JSONPath relative to each item, with or without
+// braces.
+func parseCustomColumns(spec string) ([]customColumn, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("custom-columns requires a spec such as ID:.id,STATUS:.status")
+	}
+
+	var columns []customColumn
+	for _, part := range strings.Split(spec, ",") {
+		header, expr, found := strings.Cut(part, ":")
+		header = strings.TrimSpace(header)
+		expr = strings.TrimSpace(expr)
+		if !found || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column %q, expected HEADER:expression", part)
+		}
+		expr = strings.TrimSuffix(strings.TrimPrefix(expr, "{"), "}")
+		path, err := parseJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, customColumn{header: header, path: path})
+	}
+	return columns, nil
+}
+
+func customColumnValue(column customColumn, item any) string {
+	values := evalJSONPath(column.path, item, item)
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		parts = append(parts, jsonPathValueString(value))
+	}
+	if len(parts) == 0 {
+		return noneValue
+	}
+	return strings.Join(parts, ",")
+}
+
+// nameFields are the identifier fields tried, in order, for -o name.
+var nameFields = []string{"id", "template_id", "session_id", "context_id", "name", "path", "user_id"}
+
+// NameFormatter prints the identifier of each list item, one per line.
+type NameFormatter struct{}
+
+// Format writes item identifiers to the writer.
+func (f *NameFormatter) Format(w io.Writer, data any) error {
+	items, err := genericItems(data)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		name, ok := itemName(item)
+		if !ok {
+			return fmt.Errorf("output format name is not supported for this result")
+		}
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func itemName(item any) (string, bool) {
+	if s, ok := item.(string); ok {
+		return s, true
+	}
+	object, ok := item.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	for _, field := range nameFields {
+		for key, value := range object {
+			if strings.EqualFold(key, field) || strings.EqualFold(key, strings.ReplaceAll(field, "_", "")) {
+				if s := jsonPathValueString(value); s != "" {
+					return s, true
+				}
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,168 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NDJSONFormatter writes one compact JSON object per list item and line, so
+// output can be streamed page by page into log pipelines.
+type NDJSONFormatter struct {
+	showSecrets bool
+}
+
+// Format writes each list item of data as a JSON line.
+func (f *NDJSONFormatter) Format(w io.Writer, data any) error {
+	for _, item := range listItems(redactSensitiveData(data, f.showSecrets)) {
+		line, err := marshalJSON(item)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DelimitedFormatter writes list items as CSV or TSV rows. Nested fields are
+// flattened into dotted column names and arrays are written as JSON. The
+// header is the union of the columns of every item, in first-seen order.
+type DelimitedFormatter struct {
+	comma       rune
+	noHeaders   bool
+	showSecrets bool
+}
+
+// Format writes one row per list item of data.
+func (f *DelimitedFormatter) Format(w io.Writer, data any) error {
+	items := listItems(redactSensitiveData(data, f.showSecrets))
+	var columns []string
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		keys, values, err := flattenItem(item)
+		if err != nil {
+			return err
+		}
+		columns = appendMissing(columns, keys)
+		rows = append(rows, values)
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = f.comma
+	if !f.noHeaders {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, values := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = values[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func appendMissing(columns, keys []string) []string {
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		seen[column] = true
+	}
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			columns = append(columns, key)
+		}
+	}
+	return columns
+}
+
+// flattenItem returns the flattened fields of item in their JSON field order.
+// Non-object items are returned as a single "value" column.
+func flattenItem(item any) ([]string, map[string]string, error) {
+	raw, err := marshalJSON(item)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := map[string]string{}
+	var keys []string
+	if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		if err := flattenJSONObject(raw, "", &keys, values); err != nil {
+			return nil, nil, err
+		}
+		return keys, values, nil
+	}
+	value, err := flatJSONValue(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []string{"value"}, map[string]string{"value": value}, nil
+}
+
+func flattenJSONObject(raw []byte, prefix string, keys *[]string, values map[string]string) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected JSON token %v", token)
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		var child json.RawMessage
+		if err := decoder.Decode(&child); err != nil {
+			return err
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(child), []byte("{")) {
+			if err := flattenJSONObject(child, key, keys, values); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := flatJSONValue(child)
+		if err != nil {
+			return err
+		}
+		*keys = append(*keys, key)
+		values[key] = value
+	}
+	return nil
+}
+
+// flatJSONValue renders a scalar as text and an array as compact JSON.
+func flatJSONValue(raw []byte) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	switch {
+	case bytes.Equal(raw, []byte("null")):
+		return "", nil
+	case bytes.HasPrefix(raw, []byte(`"`)):
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case bytes.HasPrefix(raw, []byte("[")):
+		var b bytes.Buffer
+		err := json.Compact(&b, raw)
+		return b.String(), err
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
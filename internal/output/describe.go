@@ -0,0 +1,144 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// SandboxDescription is the aggregated view of one sandbox printed by
+// sandbox describe. Sections that could not be fetched are nil and their
+// error is recorded in Errors by section name.
+type SandboxDescription struct {
+	Sandbox   *apispec.Sandbox                       `json:"sandbox"`
+	Status    *apispec.SandboxStatus                 `json:"status,omitempty"`
+	Mounts    []apispec.ClaimMountRequest            `json:"mounts"`
+	Network   *apispec.SandboxNetworkPolicy          `json:"network,omitempty"`
+	Services  *sandbox0.SandboxServicesResponse      `json:"services,omitempty"`
+	Contexts  []apispec.ContextResponse              `json:"contexts"`
+	Sessions  []apispec.ExecutionSession             `json:"sessions"`
+	Snapshots []apispec.SandboxRootFSSnapshot        `json:"snapshots"`
+	Events    []apispec.SandboxObservabilityEvent    `json:"events"`
+	Metrics   *apispec.SandboxRuntimeMetricsResponse `json:"metrics,omitempty"`
+	Errors    map[string]string                      `json:"errors,omitempty"`
+}
+
+func (f *TableFormatter) formatSandboxDescription(w io.Writer, d *SandboxDescription) error {
+	sections := []struct {
+		name   string
+		title  string
+		render func() error
+	}{
+		{"sandbox", "Sandbox", func() error { return f.formatSandbox(w, d.Sandbox) }},
+		{"status", "Status", func() error { return f.formatSandboxStatus(w, d.Status) }},
+		{"mounts", "Mounts", func() error { return f.formatMountSpecList(w, d.Mounts) }},
+		{"network", "Network Policy", func() error { return f.formatSandboxNetworkPolicy(w, d.Network) }},
+		{"services", "Services", func() error { return f.formatSandboxServices(w, d.Services) }},
+		{"contexts", "Running Contexts", func() error { return f.formatContextList(w, d.Contexts) }},
+		{"sessions", "Active Sessions", func() error { return f.formatExecutionSessionList(w, d.Sessions) }},
+		{"snapshots", "Snapshots", func() error { return f.formatSandboxRootFSSnapshots(w, d.Snapshots) }},
+		{"events", "Recent Audit Events", func() error { return f.formatDescribeEvents(w, d.Events) }},
+		{"metrics", "Latest Metrics", func() error { return f.formatLatestMetrics(w, d.Metrics) }},
+	}
+	for i, section := range sections {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintf(w, "%s:\n", section.title)
+		if msg, failed := d.Errors[section.name]; failed {
+			_, _ = fmt.Fprintf(w, "Error: %s\n", msg)
+			continue
+		}
+		if err := section.render(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatMountSpecList prints the volumes a sandbox was claimed with. Live
+// mount state is only reported in the claim response, so there is no state
+// column.
+func (f *TableFormatter) formatMountSpecList(w io.Writer, mounts []apispec.ClaimMountRequest) error {
+	if len(mounts) == 0 {
+		_, _ = fmt.Fprintln(w, "No mounted volumes.")
+		return nil
+	}
+	t := f.newTable(w)
+	t.Header([]string{"VOLUME ID", "MOUNT POINT"})
+	for _, m := range mounts {
+		_ = t.Append([]string{m.SandboxvolumeID, m.MountPoint})
+	}
+	return t.Render()
+}
+
+func (f *TableFormatter) formatDescribeEvents(w io.Writer, events []apispec.SandboxObservabilityEvent) error {
+	if len(events) == 0 {
+		_, _ = fmt.Fprintln(w, "No recent audit events.")
+		return nil
+	}
+	t := f.newTable(w)
+	t.Header([]string{"Occurred", "Type", "Outcome", "Actor", "Action", "Resource"})
+	for _, event := range events {
+		_ = t.Append([]string{
+			event.OccurredAt.Format(timeLayout),
+			string(event.EventType),
+			string(event.Outcome),
+			FormatSandboxAuditActor(event.Actor),
+			event.Action,
+			FormatSandboxAuditResource(event.Resource),
+		})
+	}
+	return t.Render()
+}
+
+// formatLatestMetrics prints the most recent point of each metric series.
+func (f *TableFormatter) formatLatestMetrics(w io.Writer, metrics *apispec.SandboxRuntimeMetricsResponse) error {
+	type latest struct {
+		metric string
+		unit   apispec.SandboxRuntimeMetricUnit
+		point  apispec.SandboxRuntimeMetricPoint
+	}
+	var rows []latest
+	for _, series := range metrics.Series {
+		var row *latest
+		for _, segment := range series.Segments {
+			for _, point := range segment.Points {
+				if row == nil || point.Time.After(row.point.Time) {
+					row = &latest{metric: string(series.Metric), unit: series.Unit, point: point}
+				}
+			}
+		}
+		if row != nil {
+			rows = append(rows, *row)
+		}
+	}
+	if len(rows) == 0 {
+		_, _ = fmt.Fprintln(w, "No recent metrics.")
+		return nil
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].metric < rows[j].metric })
+
+	t := f.newTable(w)
+	t.Header([]string{"Metric", "Value", "Time"})
+	for _, row := range rows {
+		_ = t.Append([]string{row.metric, formatMetricValue(row.point.Value, row.unit), row.point.Time.Format(timeLayout)})
+	}
+	return t.Render()
+}
+
+func formatMetricValue(value float64, unit apispec.SandboxRuntimeMetricUnit) string {
+	switch unit {
+	case apispec.SandboxRuntimeMetricUnitRatio:
+		return fmt.Sprintf("%.1f%%", value*100)
+	case apispec.SandboxRuntimeMetricUnitBytes:
+		return FormatBytes(int64(value))
+	case apispec.SandboxRuntimeMetricUnitBytesPerSecond:
+		return FormatBytes(int64(value)) + "/s"
+	default:
+		return fmt.Sprintf("%.6g %s", value, unit)
+	}
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"io"
+	"strconv"
+	"time"
+)
+
+// SandboxExecResult is the outcome of a command run on one sandbox of a
+// fleet exec.
+type SandboxExecResult struct {
+	ID string `json:"id" yaml:"id"`
+	// Result is "succeeded", "failed" (non-zero exit), or "error". With
+	// --fail-fast, runs stopped by an earlier failure are "cancelled" and
+	// sandboxes not reached are "skipped".
+	Result     string `json:"result" yaml:"result"`
+	ExitCode   *int   `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	DurationMS int64  `json:"duration_ms" yaml:"duration_ms"`
+	Stdout     string `json:"stdout,omitempty" yaml:"stdout,omitempty"`
+	Stderr     string `json:"stderr,omitempty" yaml:"stderr,omitempty"`
+	Error      string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SandboxExecResults is the per-sandbox summary of a fleet exec.
+type SandboxExecResults []SandboxExecResult
+
+func (f *TableFormatter) formatSandboxExecResults(w io.Writer, results SandboxExecResults) error {
+	t := f.newTable(w)
+	t.Header([]string{"ID", "RESULT", "EXIT CODE", "DURATION", "ERROR"})
+	for _, result := range results {
+		exitCode := "-"
+		if result.ExitCode != nil {
+			exitCode = strconv.Itoa(*result.ExitCode)
+		}
+		duration := "-"
+		if result.Result != "skipped" {
+			duration = (time.Duration(result.DurationMS) * time.Millisecond).String()
+		}
+		_ = t.Append([]string{result.ID, result.Result, exitCode, duration, valueOrDash(result.Error)})
+	}
+	return t.Render()
+}
@@ -2,17 +2,43 @@ package output
 
 import (
 	"io"
+	"strings"
 )
 
 // Format represents an output format.
 type Format string
 
 const (
-	FormatTable Format = "table"
-	FormatJSON  Format = "json"
-	FormatYAML  Format = "yaml"
+	FormatTable         Format = "table"
+	FormatWide          Format = "wide"
+	FormatJSON          Format = "json"
+	FormatYAML          Format = "yaml"
+	FormatName          Format = "name"
+	FormatJSONPath      Format = "jsonpath"
+	FormatGoTemplate    Format = "go-template"
+	FormatCustomColumns Format = "custom-columns"
+	FormatNDJSON        Format = "ndjson"
+	FormatCSV           Format = "csv"
+	FormatTSV           Format = "tsv"
 )
 
+// IsTable reports whether the format renders human-readable tables.
+func (f Format) IsTable() bool {
+	return f == FormatTable || f == FormatWide
+}
+
+// IsStreaming reports whether the format writes list items independently, so
+// a paged result can be formatted one page at a time. CSV and TSV are not
+// streaming: their header is the union of every item's columns, which is only
+// known once all pages have been read.
+func (f Format) IsStreaming() bool {
+	switch f {
+	case FormatNDJSON, FormatName:
+		return true
+	}
+	return false
+}
+
 // Formatter is the interface for output formatters.
 type Formatter interface {
 	// Format writes the formatted output to the writer.
@@ -22,6 +48,10 @@ type Formatter interface {
 // Options controls formatter behavior.
 type Options struct {
 	ShowSecrets bool
+	// Template is the argument of jsonpath, go-template, and custom-columns.
+	Template string
+	// NoHeaders omits header rows from table, custom-columns, csv, and tsv output.
+	NoHeaders bool
 }
 
 // NewFormatter creates a new formatter for the given format.
@@ -36,19 +66,59 @@ func NewFormatterWithOptions(format Format, opts Options) Formatter {
 		return &JSONFormatter{showSecrets: opts.ShowSecrets}
 	case FormatYAML:
 		return &YAMLFormatter{showSecrets: opts.ShowSecrets}
+	case FormatName:
+		return &NameFormatter{}
+	case FormatJSONPath:
+		return &JSONPathFormatter{template: opts.Template, showSecrets: opts.ShowSecrets}
+	case FormatGoTemplate:
+		return &GoTemplateFormatter{template: opts.Template, showSecrets: opts.ShowSecrets}
+	case FormatCustomColumns:
+		return &CustomColumnsFormatter{spec: opts.Template, noHeaders: opts.NoHeaders, showSecrets: opts.ShowSecrets}
+	case FormatNDJSON:
+		return &NDJSONFormatter{showSecrets: opts.ShowSecrets}
+	case FormatCSV:
+		return &DelimitedFormatter{comma: ',', noHeaders: opts.NoHeaders, showSecrets: opts.ShowSecrets}
+	case FormatTSV:
+		return &DelimitedFormatter{comma: '\t', noHeaders: opts.NoHeaders, showSecrets: opts.ShowSecrets}
+	case FormatWide:
+		return &TableFormatter{showSecrets: opts.ShowSecrets, wide: true, noHeaders: opts.NoHeaders}
 	default:
-		return &TableFormatter{showSecrets: opts.ShowSecrets}
+		return &TableFormatter{showSecrets: opts.ShowSecrets, noHeaders: opts.NoHeaders}
 	}
 }
 
 // ParseFormat parses a string into a Format.
 func ParseFormat(s string) Format {
-	switch s {
+	format, _ := ParseFormatSpec(s)
+	return format
+}
+
+// ParseFormatSpec parses an --output value such as "json" or
+// "jsonpath={.id}" into a Format and its template argument.
+func ParseFormatSpec(s string) (Format, string) {
+	name, arg, _ := strings.Cut(s, "=")
+	switch name {
 	case "json":
-		return FormatJSON
+		return FormatJSON, ""
 	case "yaml":
-		return FormatYAML
+		return FormatYAML, ""
+	case "wide":
+		return FormatWide, ""
+	case "name":
+		return FormatName, ""
+	case "ndjson", "jsonl":
+		return FormatNDJSON, ""
+	case "csv":
+		return FormatCSV, ""
+	case "tsv":
+		return FormatTSV, ""
+	case "jsonpath":
+		return FormatJSONPath, arg
+	case "go-template", "template":
+		return FormatGoTemplate, arg
+	case "custom-columns":
+		return FormatCustomColumns, arg
 	default:
-		return FormatTable
+		return FormatTable, ""
 	}
 }
@@ -0,0 +1,199 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+func testSandboxList() *sandbox0.ListSandboxesResponse {
+	created := time.Date(2026, 3, 23, 12, 0, 0, 0, time.UTC)
+	return &sandbox0.ListSandboxesResponse{
+		Sandboxes: []apispec.SandboxSummary{
+			{ID: "sb_1", TemplateID: "python", Status: apispec.SandboxLifecycleStatusRunning, CreatedAt: created, RuntimeGeneration: 3},
+			{ID: "sb_2", TemplateID: "node", Status: apispec.SandboxLifecycleStatusPaused, Paused: true, CreatedAt: created},
+		},
+		Count: 2,
+	}
+}
+
+func formatWith(t *testing.T, spec string, data any) string {
+	t.Helper()
+	format, template := ParseFormatSpec(spec)
+	var buf bytes.Buffer
+	if err := NewFormatterWithOptions(format, Options{Template: template}).Format(&buf, data); err != nil {
+		t.Fatalf("Format(%q) error = %v", spec, err)
+	}
+	return buf.String()
+}
+
+func TestParseFormatSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		format   Format
+		template string
+	}{
+		{spec: "json", format: FormatJSON},
+		{spec: "wide", format: FormatWide},
+		{spec: "jsonpath={.id}", format: FormatJSONPath, template: "{.id}"},
+		{spec: "go-template={{.id}}", format: FormatGoTemplate, template: "{{.id}}"},
+		{spec: "custom-columns=ID:.id,A=B:.b", format: FormatCustomColumns, template: "ID:.id,A=B:.b"},
+		{spec: "unknown", format: FormatTable},
+	}
+	for _, tt := range tests {
+		format, template := ParseFormatSpec(tt.spec)
+		if format != tt.format || template != tt.template {
+			t.Fatalf("ParseFormatSpec(%q) = %q, %q; want %q, %q", tt.spec, format, template, tt.format, tt.template)
+		}
+	}
+}
+
+func TestJSONPathFormatter(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{spec: "jsonpath={.sandboxes[*].id}", want: "sb_1 sb_2\n"},
+		{spec: "jsonpath=.sandboxes[0].template_id", want: "python\n"},
+		{spec: "jsonpath={.sandboxes[-1].id}", want: "sb_2\n"},
+		{spec: `jsonpath={range .sandboxes[*]}{.id}{"\t"}{.status}{"\n"}{end}`, want: "sb_1\trunning\nsb_2\tpaused\n"},
+		{spec: `jsonpath={.sandboxes[?(@.paused==true)].id}`, want: "sb_2\n"},
+		{spec: `jsonpath={.sandboxes[?(@.runtime_generation>1)].id}`, want: "sb_1\n"},
+		{spec: "jsonpath={.count}", want: "2\n"},
+		{spec: "jsonpath={..template_id}", want: "python node\n"},
+		{spec: "jsonpath={.missing}", want: "\n"},
+	}
+	for _, tt := range tests {
+		if got := formatWith(t, tt.spec, testSandboxList()); got != tt.want {
+			t.Fatalf("%s = %q, want %q", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"jsonpath={range .a}", "jsonpath={.a[}", "jsonpath="} {
+		format, template := ParseFormatSpec(spec)
+		if err := NewFormatterWithOptions(format, Options{Template: template}).Format(&bytes.Buffer{}, testSandboxList()); err == nil {
+			t.Fatalf("%s error = nil, want error", spec)
+		}
+	}
+}
+
+func TestGoTemplateFormatter(t *testing.T) {
+	got := formatWith(t, `go-template={{range .Sandboxes}}{{.id}}={{.status}}{{"\n"}}{{end}}`, testSandboxList())
+	if got != "sb_1=running\nsb_2=paused\n" {
+		t.Fatalf("go-template output = %q", got)
+	}
+}
+
+func TestCustomColumnsFormatter(t *testing.T) {
+	got := formatWith(t, "custom-columns=ID:.id,STATUS:{.status},CLUSTER:.cluster_id", testSandboxList())
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("custom-columns output has %d lines, want 3:\n%s", len(lines), got)
+	}
+	for i, want := range [][]string{{"ID", "STATUS", "CLUSTER"}, {"sb_1", "running", noneValue}, {"sb_2", "paused", noneValue}} {
+		if fields := strings.Fields(lines[i]); strings.Join(fields, " ") != strings.Join(want, " ") {
+			t.Fatalf("line %d = %q, want %v", i, lines[i], want)
+		}
+	}
+
+	format, template := ParseFormatSpec("custom-columns=ID")
+	if err := NewFormatterWithOptions(format, Options{Template: template}).Format(&bytes.Buffer{}, testSandboxList()); err == nil {
+		t.Fatal("custom-columns without expression error = nil, want error")
+	}
+}
+
+func TestNameFormatter(t *testing.T) {
+	if got := formatWith(t, "name", testSandboxList()); got != "sb_1\nsb_2\n" {
+		t.Fatalf("name output for sandboxes = %q", got)
+	}
+	templates := []apispec.Template{{TemplateID: "python"}, {TemplateID: "node"}}
+	if got := formatWith(t, "name", templates); got != "python\nnode\n" {
+		t.Fatalf("name output for templates = %q", got)
+	}
+	if got := formatWith(t, "name", &apispec.CredentialSourceMetadata{Name: "gh"}); got != "gh\n" {
+		t.Fatalf("name output for credential source = %q", got)
+	}
+}
+
+func TestWideTableAddsColumns(t *testing.T) {
+	table := formatWith(t, "table", testSandboxList())
+	wide := formatWith(t, "wide", testSandboxList())
+	if strings.Contains(table, "GENERATION") {
+		t.Fatalf("table output contains wide column:\n%s", table)
+	}
+	if !strings.Contains(wide, "GENERATION") || !strings.Contains(wide, "CLUSTER ID") {
+		t.Fatalf("wide output missing columns:\n%s", wide)
+	}
+}
+
+func TestNDJSONFormatterWritesOneItemPerLine(t *testing.T) {
+	got := formatWith(t, "ndjson", testSandboxList())
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ndjson lines = %d, want 2:\n%s", len(lines), got)
+	}
+	if !strings.HasPrefix(lines[0], `{"id":"sb_1","template_id":"python","status":"running"`) {
+		t.Fatalf("first line = %s", lines[0])
+	}
+}
+
+func TestDelimitedFormatterUsesUnionOfColumns(t *testing.T) {
+	formatter := NewFormatterWithOptions(FormatCSV, Options{})
+	volumes := []apispec.SandboxVolume{
+		{ID: "vol_1", Backend: apispec.VolumeBackendS3},
+		{ID: "vol_2", Backend: apispec.VolumeBackendS3, S3: apispec.NewOptSandboxVolumeS3Config(apispec.SandboxVolumeS3Config{
+			Bucket: "data, raw",
+		})},
+	}
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, volumes); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("csv lines = %d, want 3:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "id,team_id,user_id,") || !strings.Contains(lines[0], ",s3.bucket") {
+		t.Fatalf("header = %s, want columns first seen on a later item", lines[0])
+	}
+	if !strings.Contains(lines[2], `"data, raw"`) || strings.Contains(lines[1], "data") {
+		t.Fatalf("rows = %q, %q", lines[1], lines[2])
+	}
+	if strings.Count(lines[1], ",") != strings.Count(lines[0], ",") {
+		t.Fatalf("row %q does not fill every column of %q", lines[1], lines[0])
+	}
+}
+
+func TestCSVIsNotStreaming(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatTSV} {
+		if format.IsStreaming() {
+			t.Fatalf("%s.IsStreaming() = true, want pages merged so the header covers every column", format)
+		}
+	}
+}
+
+func TestDelimitedFormatterNoHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	formatter := NewFormatterWithOptions(FormatTSV, Options{NoHeaders: true})
+	if err := formatter.Format(&buf, []string{"a", "b"}); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if buf.String() != "a\nb\n" {
+		t.Fatalf("tsv output = %q, want %q", buf.String(), "a\nb\n")
+	}
+}
+
+func TestTableFormatterNoHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatterWithOptions(FormatTable, Options{NoHeaders: true}).Format(&buf, testSandboxList()); err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "TEMPLATE ID") || !strings.Contains(buf.String(), "sb_1") {
+		t.Fatalf("table without headers =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// SandboxGCResult is the idle classification of one sandbox by sandbox gc and
+// the action taken on it.
+type SandboxGCResult struct {
+	ID         string    `json:"id" yaml:"id"`
+	TemplateID string    `json:"template_id" yaml:"template_id"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	// CPUMax is the peak CPU utilization as a fraction of the CPU limit.
+	CPUMax *float64 `json:"cpu_max,omitempty" yaml:"cpu_max,omitempty"`
+	// NetworkMax is the peak network throughput in bytes per second.
+	NetworkMax   *float64 `json:"network_max,omitempty" yaml:"network_max,omitempty"`
+	RecentLogs   bool     `json:"recent_logs" yaml:"recent_logs"`
+	RecentEvents bool     `json:"recent_events" yaml:"recent_events"`
+	Idle         bool     `json:"idle" yaml:"idle"`
+	// Reason explains why a sandbox was kept, for example "cpu 12.0%".
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// SandboxGCReport is the per-sandbox report of a sandbox gc run.
+type SandboxGCReport []SandboxGCResult
+
+func (f *TableFormatter) formatSandboxGCReport(w io.Writer, report SandboxGCReport) error {
+	if len(report) == 0 {
+		_, _ = fmt.Fprintln(w, "No sandboxes found.")
+		return nil
+	}
+	t := f.newTable(w)
+	t.Header([]string{"ID", "TEMPLATE ID", "CREATED AT", "CPU MAX", "NETWORK MAX", "LOGS", "EVENTS", "IDLE", "RESULT", "REASON"})
+	for _, result := range report {
+		cpu, network := "-", "-"
+		if result.CPUMax != nil {
+			cpu = fmt.Sprintf("%.1f%%", *result.CPUMax*100)
+		}
+		if result.NetworkMax != nil {
+			network = FormatBytes(int64(*result.NetworkMax)) + "/s"
+		}
+		reason := result.Reason
+		if result.Error != "" {
+			reason = result.Error
+		}
+		_ = t.Append([]string{
+			result.ID,
+			result.TemplateID,
+			formatTimestamp(result.CreatedAt),
+			cpu,
+			network,
+			fmt.Sprintf("%v", result.RecentLogs),
+			fmt.Sprintf("%v", result.RecentEvents),
+			fmt.Sprintf("%v", result.Idle),
+			result.Result,
+			valueOrDash(reason),
+		})
+	}
+	return t.Render()
+}
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	sandbox0 "github.com/sandbox0-ai/sdk-go"
+	"github.com/sandbox0-ai/sdk-go/pkg/apispec"
+)
+
+// toGeneric converts data to its JSON representation as maps, slices, and
+// scalars. Numbers are kept as json.Number so large integers print exactly.
+func toGeneric(data any) (any, error) {
+	raw, err := marshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// marshalJSON encodes data as compact JSON. Struct values are encoded through
+// a pointer because generated API types implement json.Marshaler on pointer
+// receivers.
+func marshalJSON(data any) ([]byte, error) {
+	if rv := reflect.ValueOf(data); rv.Kind() == reflect.Struct {
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		data = ptr.Interface()
+	}
+	return json.Marshal(data)
+}
+
+// listItems returns the items of list-shaped data: slices and list responses
+// that wrap a slice with paging fields. Other data is returned as a single
+// item.
+func listItems(data any) []any {
+	switch v := data.(type) {
+	case *sandbox0.ListSandboxesResponse:
+		return sliceItems(v.Sandboxes)
+	case *apispec.SandboxRootFSSnapshotList:
+		return sliceItems(v.Snapshots)
+	case *apispec.ExecutionSessionEventPage:
+		return sliceItems(v.Events)
+	case *apispec.SandboxObservabilityEventsResponse:
+		return sliceItems(v.Events)
+	case *apispec.SandboxObservabilityLogsResponse:
+		return sliceItems(v.Logs)
+	case *sandbox0.SandboxServicesResponse:
+		return sliceItems(v.Services)
+	}
+	if rv := reflect.ValueOf(data); rv.Kind() == reflect.Slice {
+		items := make([]any, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Addr().Interface()
+		}
+		return items
+	}
+	return []any{data}
+}
+
+func sliceItems[T any](values []T) []any {
+	items := make([]any, len(values))
+	for i := range values {
+		items[i] = &values[i]
+	}
+	return items
+}
+
+// genericItems converts the list items of data to generic values.
+func genericItems(data any) ([]any, error) {
+	items := listItems(data)
+	generic := make([]any, 0, len(items))
+	for _, item := range items {
+		value, err := toGeneric(item)
+		if err != nil {
+			return nil, err
+		}
+		generic = append(generic, value)
+	}
+	return generic, nil
+}
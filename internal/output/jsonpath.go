@@ -0,0 +1,612 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSONPathFormatter renders data with a kubectl-style JSONPath template such
+// as '{.sandboxes[*].id}' or '{range .items[*]}{.id}{"\n"}{end}'.
+type JSONPathFormatter struct {
+	template    string
+	showSecrets bool
+}
+
+// Format writes the template output for data to the writer.
+func (f *JSONPathFormatter) Format(w io.Writer, data any) error {
+	template, err := parseJSONPathTemplate(f.template)
+	if err != nil {
+		return err
+	}
+	generic, err := toGeneric(redactSensitiveData(data, f.showSecrets))
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if err := template.execute(&b, generic, generic); err != nil {
+		return err
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// jsonPathNode is a literal, a path expression, or a range block.
+type jsonPathNode struct {
+	text     string
+	path     []jsonPathStep
+	isPath   bool
+	children []jsonPathNode
+	isRange  bool
+}
+
+type jsonPathTemplate struct {
+	nodes []jsonPathNode
+}
+
+// parseJSONPathTemplate parses a template. An expression without braces is
+// treated as a single path, so '.items[*].id' equals '{.items[*].id}'.
+func parseJSONPathTemplate(text string) (*jsonPathTemplate, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("jsonpath template is empty")
+	}
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+
+	stack := [][]jsonPathNode{nil}
+	var ranges []jsonPathNode
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: text})
+			break
+		}
+		if open > 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: text[:open]})
+		}
+		end := closingBrace(text, open)
+		if end < 0 {
+			return nil, fmt.Errorf("jsonpath: unclosed action in %q", text[open:])
+		}
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("jsonpath: {end} without {range}")
+			}
+			node := ranges[len(ranges)-1]
+			ranges = ranges[:len(ranges)-1]
+			node.children = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = append(stack[len(stack)-1], node)
+		case strings.HasPrefix(action, "range ") || action == "range":
+			path, err := parseJSONPath(strings.TrimSpace(strings.TrimPrefix(action, "range")))
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, jsonPathNode{path: path, isRange: true})
+			stack = append(stack, nil)
+		case strings.HasPrefix(action, `"`) || strings.HasPrefix(action, "'"):
+			literal, err := unquoteJSONPathString(action)
+			if err != nil {
+				return nil, fmt.Errorf("jsonpath: invalid string %s: %w", action, err)
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{text: literal})
+		default:
+			path, err := parseJSONPath(action)
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], jsonPathNode{path: path, isPath: true})
+		}
+	}
+	if len(ranges) > 0 {
+		return nil, fmt.Errorf("jsonpath: {range} without {end}")
+	}
+	return &jsonPathTemplate{nodes: stack[0]}, nil
+}
+
+// closingBrace returns the index of the brace closing text[open], skipping
+// braces inside quoted strings.
+func closingBrace(text string, open int) int {
+	var quote byte
+	for i := open + 1; i < len(text); i++ {
+		switch c := text[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+func unquoteJSONPathString(s string) (string, error) {
+	if strings.HasPrefix(s, "'") {
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return strconv.Unquote(s)
+}
+
+func (t *jsonPathTemplate) execute(b *strings.Builder, root, current any) error {
+	return executeJSONPathNodes(b, t.nodes, root, current)
+}
+
+func executeJSONPathNodes(b *strings.Builder, nodes []jsonPathNode, root, current any) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			for _, value := range evalJSONPath(node.path, root, current) {
+				if list, ok := value.([]any); ok {
+					for _, item := range list {
+						if err := executeJSONPathNodes(b, node.children, root, item); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				if err := executeJSONPathNodes(b, node.children, root, value); err != nil {
+					return err
+				}
+			}
+		case node.isPath:
+			values := evalJSONPath(node.path, root, current)
+			for i, value := range values {
+				if i > 0 {
+					b.WriteByte(' ')
+				}
+				b.WriteString(jsonPathValueString(value))
+			}
+		default:
+			b.WriteString(node.text)
+		}
+	}
+	return nil
+}
+
+// jsonPathValueString prints scalars as plain text and objects as JSON.
+func jsonPathValueString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// jsonPathStep is one step of a path expression.
+type jsonPathStep struct {
+	kind      jsonPathStepKind
+	name      string
+	index     int
+	start     *int
+	end       *int
+	filter    *jsonPathFilter
+	recursive bool
+}
+
+type jsonPathStepKind int
+
+const (
+	jsonPathRoot jsonPathStepKind = iota
+	jsonPathField
+	jsonPathWildcard
+	jsonPathIndex
+	jsonPathSlice
+	jsonPathFilterStep
+)
+
+// jsonPathFilter is a [?(@.path op value)] predicate. An empty op tests that
+// the path exists.
+type jsonPathFilter struct {
+	path  []jsonPathStep
+	op    string
+	value any
+}
+
+// parseJSONPath parses expressions such as '.a.b[0]', '$.items[*].id',
+// '..name', ".items[?(@.status=='running')].id", and "['key.with.dots']".
+func parseJSONPath(expr string) ([]jsonPathStep, error) {
+	steps, err := parseJSONPathSteps(strings.TrimSpace(expr))
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath: %w in %q", err, expr)
+	}
+	return steps, nil
+}
+
+func parseJSONPathSteps(expr string) ([]jsonPathStep, error) {
+	var steps []jsonPathStep
+	switch {
+	case strings.HasPrefix(expr, "$"):
+		steps = append(steps, jsonPathStep{kind: jsonPathRoot})
+		expr = expr[1:]
+	case strings.HasPrefix(expr, "@"):
+		expr = expr[1:]
+	}
+
+	for len(expr) > 0 {
+		recursive := false
+		switch expr[0] {
+		case '.':
+			if strings.HasPrefix(expr, "..") {
+				recursive = true
+				expr = expr[2:]
+			} else {
+				expr = expr[1:]
+			}
+			if expr == "" {
+				if recursive {
+					return nil, fmt.Errorf("missing field after ..")
+				}
+				return steps, nil
+			}
+			if expr[0] == '[' {
+				if recursive {
+					step, rest, err := parseJSONPathBracket(expr)
+					if err != nil {
+						return nil, err
+					}
+					step.recursive = true
+					steps = append(steps, step)
+					expr = rest
+				}
+				continue
+			}
+			if expr[0] == '*' {
+				steps = append(steps, jsonPathStep{kind: jsonPathWildcard, recursive: recursive})
+				expr = expr[1:]
+				continue
+			}
+			end := strings.IndexAny(expr, ".[")
+			if end < 0 {
+				end = len(expr)
+			}
+			name := expr[:end]
+			if name == "" {
+				return nil, fmt.Errorf("empty field name")
+			}
+			steps = append(steps, jsonPathStep{kind: jsonPathField, name: name, recursive: recursive})
+			expr = expr[end:]
+		case '[':
+			step, rest, err := parseJSONPathBracket(expr)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+			expr = rest
+		default:
+			return nil, fmt.Errorf("unexpected %q", expr)
+		}
+	}
+	return steps, nil
+}
+
+func parseJSONPathBracket(expr string) (jsonPathStep, string, error) {
+	end := closingBracket(expr)
+	if end < 0 {
+		return jsonPathStep{}, "", fmt.Errorf("unclosed [")
+	}
+	inner := strings.TrimSpace(expr[1:end])
+	rest := expr[end+1:]
+
+	switch {
+	case inner == "*":
+		return jsonPathStep{kind: jsonPathWildcard}, rest, nil
+	case strings.HasPrefix(inner, "?(") && strings.HasSuffix(inner, ")"):
+		filter, err := parseJSONPathFilter(strings.TrimSpace(inner[2 : len(inner)-1]))
+		if err != nil {
+			return jsonPathStep{}, "", err
+		}
+		return jsonPathStep{kind: jsonPathFilterStep, filter: filter}, rest, nil
+	case strings.HasPrefix(inner, "'") || strings.HasPrefix(inner, `"`):
+		name, err := unquoteJSONPathString(inner)
+		if err != nil {
+			return jsonPathStep{}, "", err
+		}
+		return jsonPathStep{kind: jsonPathField, name: name}, rest, nil
+	case strings.Contains(inner, ":"):
+		startText, endText, _ := strings.Cut(inner, ":")
+		if i := strings.IndexByte(endText, ':'); i >= 0 {
+			endText = endText[:i]
+		}
+		step := jsonPathStep{kind: jsonPathSlice}
+		if s := strings.TrimSpace(startText); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return jsonPathStep{}, "", fmt.Errorf("invalid slice start %q", s)
+			}
+			step.start = &n
+		}
+		if s := strings.TrimSpace(endText); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return jsonPathStep{}, "", fmt.Errorf("invalid slice end %q", s)
+			}
+			step.end = &n
+		}
+		return step, rest, nil
+	default:
+		n, err := strconv.Atoi(inner)
+		if err != nil {
+			return jsonPathStep{}, "", fmt.Errorf("invalid index %q", inner)
+		}
+		return jsonPathStep{kind: jsonPathIndex, index: n}, rest, nil
+	}
+}
+
+// closingBracket returns the index of the bracket closing expr[0], allowing
+// nested brackets and quoted strings inside filters.
+func closingBracket(expr string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseJSONPathFilter(expr string) (*jsonPathFilter, error) {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		left, right, found := strings.Cut(expr, op)
+		if !found {
+			continue
+		}
+		path, err := parseJSONPathSteps(strings.TrimSpace(left))
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseJSONPathLiteral(strings.TrimSpace(right))
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathFilter{path: path, op: op, value: value}, nil
+	}
+	path, err := parseJSONPathSteps(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonPathFilter{path: path}, nil
+}
+
+func parseJSONPathLiteral(s string) (any, error) {
+	switch {
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		return unquoteJSONPathString(s)
+	case s == "true" || s == "false":
+		return s == "true", nil
+	case s == "null":
+		return nil, nil
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return nil, fmt.Errorf("invalid filter value %q", s)
+	}
+	return json.Number(s), nil
+}
+
+// evalJSONPath evaluates steps against current. Missing fields produce no
+// results rather than an error, matching kubectl get.
+func evalJSONPath(steps []jsonPathStep, root, current any) []any {
+	values := []any{current}
+	for _, step := range steps {
+		if step.kind == jsonPathRoot {
+			values = []any{root}
+			continue
+		}
+		var next []any
+		for _, value := range values {
+			if step.recursive {
+				for _, descendant := range jsonPathDescendants(value) {
+					next = append(next, applyJSONPathStep(step, root, descendant)...)
+				}
+				continue
+			}
+			next = append(next, applyJSONPathStep(step, root, value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func applyJSONPathStep(step jsonPathStep, root, value any) []any {
+	switch step.kind {
+	case jsonPathField:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if field, ok := object[step.name]; ok {
+			return []any{field}
+		}
+		// Some SDK responses have no JSON tags and marshal with Go field
+		// names, so fall back to a case-insensitive match.
+		for key, field := range object {
+			if strings.EqualFold(key, step.name) {
+				return []any{field}
+			}
+		}
+		return nil
+	case jsonPathWildcard:
+		switch v := value.(type) {
+		case []any:
+			return append([]any(nil), v...)
+		case map[string]any:
+			keys := sortedKeys(v)
+			out := make([]any, 0, len(keys))
+			for _, key := range keys {
+				out = append(out, v[key])
+			}
+			return out
+		}
+		return nil
+	case jsonPathIndex:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		index := step.index
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil
+		}
+		return []any{list[index]}
+	case jsonPathSlice:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+		start, end := 0, len(list)
+		if step.start != nil {
+			start = clampJSONPathIndex(*step.start, len(list))
+		}
+		if step.end != nil {
+			end = clampJSONPathIndex(*step.end, len(list))
+		}
+		if start >= end {
+			return nil
+		}
+		return append([]any(nil), list[start:end]...)
+	case jsonPathFilterStep:
+		list, ok := value.([]any)
+		if !ok {
+			list = []any{value}
+		}
+		var out []any
+		for _, item := range list {
+			if step.filter.match(root, item) {
+				out = append(out, item)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func clampJSONPathIndex(index, length int) int {
+	if index < 0 {
+		index += length
+	}
+	return max(0, min(index, length))
+}
+
+// jsonPathDescendants returns value and all nested values in document order.
+func jsonPathDescendants(value any) []any {
+	out := []any{value}
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			out = append(out, jsonPathDescendants(item)...)
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			out = append(out, jsonPathDescendants(v[key])...)
+		}
+	}
+	return out
+}
+
+func (f *jsonPathFilter) match(root, item any) bool {
+	values := evalJSONPath(f.path, root, item)
+	if f.op == "" {
+		return len(values) > 0
+	}
+	if len(values) == 0 {
+		return f.op == "!="
+	}
+	cmp, comparable := compareJSONPathValues(values[0], f.value)
+	switch f.op {
+	case "==":
+		return comparable && cmp == 0
+	case "!=":
+		return !comparable || cmp != 0
+	case "<":
+		return comparable && cmp < 0
+	case "<=":
+		return comparable && cmp <= 0
+	case ">":
+		return comparable && cmp > 0
+	case ">=":
+		return comparable && cmp >= 0
+	}
+	return false
+}
+
+// compareJSONPathValues compares numbers numerically and everything else by
+// its text form.
+func compareJSONPathValues(a, b any) (int, bool) {
+	an, aNumber := a.(json.Number)
+	bn, bNumber := b.(json.Number)
+	if aNumber && bNumber {
+		af, errA := an.Float64()
+		bf, errB := bn.Float64()
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		switch {
+		case af < bf:
+			return -1, true
+		case af > bf:
+			return 1, true
+		}
+		return 0, true
+	}
+	if (a == nil) != (b == nil) {
+		return 0, false
+	}
+	return strings.Compare(jsonPathValueString(a), jsonPathValueString(b)), true
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -23,6 +23,10 @@ const timeLayout = "2006-01-02 15:04:05"
 // TableFormatter formats output as a table.
 type TableFormatter struct {
 	showSecrets bool
+	// wide adds secondary columns to list tables.
+	wide bool
+	// noHeaders omits header rows from list tables.
+	noHeaders bool
 }
 
 // Format writes the data as a table to the writer.
@@ -110,6 +114,18 @@ func (f *TableFormatter) Format(w io.Writer, data interface{}) error {
 		return f.formatTeamList(w, v)
 	case TeamList:
 		return f.formatTeamListWithCurrent(w, v)
+	case SandboxOperationResults:
+		return f.formatSandboxOperationResults(w, v)
+	case SandboxExecResults:
+		return f.formatSandboxExecResults(w, v)
+	case SandboxGCReport:
+		return f.formatSandboxGCReport(w, v)
+	case *SandboxDescription:
+		return f.formatSandboxDescription(w, v)
+	case SandboxTop:
+		return f.formatSandboxTop(w, v)
+	case AliasList:
+		return f.formatAliasList(w, v)
 	case apispec.Team:
 		return f.formatTeam(w, &v)
 	case *apispec.Team:
@@ -157,7 +173,7 @@ func (f *TableFormatter) formatTeamQuotaList(w io.Writer, quotas []apispec.TeamQ
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"DIMENSION", "KIND", "LIMIT", "INTERVAL", "BURST", "CURRENT", "REMAINING", "UNIT", "SOURCE"})
 	for _, quota := range quotas {
 		_ = t.Append([]string{
@@ -176,7 +192,7 @@ func (f *TableFormatter) formatTeamQuotaList(w io.Writer, quotas []apispec.TeamQ
 }
 
 func (f *TableFormatter) formatTeamQuota(w io.Writer, quota *apispec.TeamQuota) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Team ID:", quota.TeamID})
 	_ = t.Append([]string{"Dimension:", string(quota.Dimension)})
 	_ = t.Append([]string{"Kind:", string(quota.Kind)})
@@ -213,6 +229,23 @@ func formatTeamQuotaInt(value apispec.NilInt64) string {
 	return strconv.FormatInt(number, 10)
 }
 
+// table wraps tablewriter.Table so --no-headers applies to every list.
+type table struct {
+	*tablewriter.Table
+	noHeaders bool
+}
+
+// Header sets the header row unless headers are disabled.
+func (t *table) Header(elements ...any) {
+	if !t.noHeaders {
+		t.Table.Header(elements...)
+	}
+}
+
+func (f *TableFormatter) newTable(w io.Writer) *table {
+	return &table{Table: newTable(w), noHeaders: f.noHeaders}
+}
+
 func newTable(w io.Writer) *tablewriter.Table {
 	return tablewriter.NewTable(w, tablewriter.WithRendition(tw.Rendition{
 		Borders: tw.Border{Left: tw.Off, Right: tw.Off, Top: tw.Off, Bottom: tw.Off},
@@ -239,24 +272,36 @@ func (f *TableFormatter) formatTemplates(w io.Writer, templates []apispec.Templa
 		return nil
 	}
 
-	t := newTable(w)
-	t.Header([]string{"TEMPLATE ID", "SCOPE", "STATE", "STAGE", "CREATED AT"})
+	t := f.newTable(w)
+	headers := []string{"TEMPLATE ID", "SCOPE", "STATE", "STAGE", "CREATED AT"}
+	if f.wide {
+		headers = append(headers, "DISPLAY NAME", "OUTPUT IMAGE", "UPDATED AT")
+	}
+	t.Header(headers)
 
 	for _, tmpl := range templates {
 		creation := templateCreationDisplayFor(tmpl)
-		_ = t.Append([]string{
+		row := []string{
 			tmpl.TemplateID,
 			tmpl.Scope,
 			creation.state,
 			creation.stage,
 			tmpl.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
+		}
+		if f.wide {
+			row = append(row,
+				tmpl.Spec.DisplayName.Or("-"),
+				creation.outputImage,
+				tmpl.UpdatedAt.Format("2006-01-02 15:04:05"),
+			)
+		}
+		_ = t.Append(row)
 	}
 	return t.Render()
 }
 
 func (f *TableFormatter) formatTemplate(w io.Writer, tmpl *apispec.Template) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Template ID:", tmpl.TemplateID})
 	_ = t.Append([]string{"Scope:", tmpl.Scope})
 	if v, ok := tmpl.TeamID.Get(); ok {
@@ -314,23 +359,40 @@ func (f *TableFormatter) formatVolumes(w io.Writer, volumes []apispec.SandboxVol
 		return nil
 	}
 
-	t := newTable(w)
-	t.Header([]string{"ID", "TEAM ID", "BACKEND", "METERED STORAGE", "CREATED"})
+	t := f.newTable(w)
+	headers := []string{"ID", "TEAM ID", "BACKEND", "METERED STORAGE", "CREATED"}
+	if f.wide {
+		headers = append(headers, "USER ID", "ACCESS MODE", "SOURCE VOLUME", "STORAGE OBSERVED")
+	}
+	t.Header(headers)
 
 	for _, v := range volumes {
-		_ = t.Append([]string{
+		row := []string{
 			v.ID,
 			v.TeamID,
 			formatVolumeBackend(v.Backend),
 			formatVolumeMeteredStorage(v),
 			v.CreatedAt.Format("2006-01-02 15:04:05"),
-		})
+		}
+		if f.wide {
+			accessMode := "-"
+			if mode, ok := v.AccessMode.Get(); ok {
+				accessMode = string(mode)
+			}
+			row = append(row,
+				valueOrDash(v.UserID),
+				accessMode,
+				formatOptNilString(v.SourceVolumeID),
+				formatVolumeStorageObservedAt(v),
+			)
+		}
+		_ = t.Append(row)
 	}
 	return t.Render()
 }
 
 func (f *TableFormatter) formatVolume(w io.Writer, v *apispec.SandboxVolume) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", v.ID})
 	_ = t.Append([]string{"Team ID:", v.TeamID})
 	_ = t.Append([]string{"User ID:", v.UserID})
@@ -367,7 +429,7 @@ func formatVolumeMeteredStorage(volume apispec.SandboxVolume) string {
 		return "External"
 	}
 	if sizeBytes, ok := volume.MeteredStorageBytes.Get(); ok {
-		return formatBytes(sizeBytes)
+		return FormatBytes(sizeBytes)
 	}
 	return "Unavailable"
 }
@@ -388,7 +450,7 @@ func (f *TableFormatter) formatSnapshots(w io.Writer, snapshots []apispec.Snapsh
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "NAME", "SIZE", "CREATED"})
 
 	for _, s := range snapshots {
@@ -429,7 +491,7 @@ func optInt32OrDash(value apispec.OptInt32) string {
 }
 
 func (f *TableFormatter) formatSnapshot(w io.Writer, s *apispec.Snapshot) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", s.ID})
 	_ = t.Append([]string{"Volume ID:", s.VolumeID})
 	_ = t.Append([]string{"Name:", s.Name})
@@ -461,7 +523,7 @@ func (f *TableFormatter) formatSandboxRootFSSnapshots(w io.Writer, snapshots []a
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "SANDBOX ID", "NAME", "CREATED", "EXPIRES"})
 	for _, s := range snapshots {
 		_ = t.Append([]string{
@@ -476,7 +538,7 @@ func (f *TableFormatter) formatSandboxRootFSSnapshots(w io.Writer, snapshots []a
 }
 
 func (f *TableFormatter) formatSandboxRootFSSnapshot(w io.Writer, s *apispec.SandboxRootFSSnapshot) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", s.ID})
 	_ = t.Append([]string{"Sandbox ID:", s.SandboxID})
 	_ = t.Append([]string{"Name:", formatOptString(s.Name)})
@@ -487,7 +549,7 @@ func (f *TableFormatter) formatSandboxRootFSSnapshot(w io.Writer, s *apispec.San
 }
 
 func (f *TableFormatter) formatRestoreSandboxRootFSResponse(w io.Writer, r *apispec.RestoreSandboxRootFSResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Sandbox ID:", r.SandboxID})
 	_ = t.Append([]string{"Snapshot ID:", r.SnapshotID})
 	_ = t.Append([]string{"Status:", string(r.Status)})
@@ -495,7 +557,7 @@ func (f *TableFormatter) formatRestoreSandboxRootFSResponse(w io.Writer, r *apis
 }
 
 func (f *TableFormatter) formatForkSandboxResponse(w io.Writer, r *apispec.ForkSandboxResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Source Sandbox ID:", r.SourceSandboxID})
 	_ = t.Append([]string{"Fork Sandbox ID:", r.Sandbox.ID})
 	_ = t.Append([]string{"Template ID:", r.Sandbox.TemplateID})
@@ -505,7 +567,7 @@ func (f *TableFormatter) formatForkSandboxResponse(w io.Writer, r *apispec.ForkS
 }
 
 func (f *TableFormatter) formatSandbox(w io.Writer, s *apispec.Sandbox) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", s.ID})
 	_ = t.Append([]string{"Template ID:", s.TemplateID})
 	_ = t.Append([]string{"Team ID:", s.TeamID})
@@ -537,7 +599,7 @@ func (f *TableFormatter) formatSSHPublicKeyList(w io.Writer, keys []apispec.SSHP
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "NAME", "KEY TYPE", "FINGERPRINT", "CREATED AT"})
 	for _, key := range keys {
 		_ = t.Append([]string{
@@ -552,7 +614,7 @@ func (f *TableFormatter) formatSSHPublicKeyList(w io.Writer, keys []apispec.SSHP
 }
 
 func (f *TableFormatter) formatSSHPublicKey(w io.Writer, key *apispec.SSHPublicKey) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", key.ID})
 	_ = t.Append([]string{"Name:", key.Name})
 	_ = t.Append([]string{"Key Type:", key.KeyType})
@@ -564,7 +626,7 @@ func (f *TableFormatter) formatSSHPublicKey(w io.Writer, key *apispec.SSHPublicK
 }
 
 func (f *TableFormatter) formatSandboxStatus(w io.Writer, s *apispec.SandboxStatus) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	if v, ok := s.Status.Get(); ok {
 		_ = t.Append([]string{"Status:", string(v)})
 	}
@@ -584,7 +646,7 @@ func (f *TableFormatter) formatSandboxStatus(w io.Writer, s *apispec.SandboxStat
 }
 
 func (f *TableFormatter) formatRefreshResponse(w io.Writer, r *apispec.RefreshResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Sandbox ID:", r.SandboxID})
 	_ = t.Append([]string{"Soft Expires At:", formatTimestamp(r.ExpiresAt)})
 	_ = t.Append([]string{"Hard Expires At:", formatTimestamp(r.HardExpiresAt)})
@@ -592,7 +654,7 @@ func (f *TableFormatter) formatRefreshResponse(w io.Writer, r *apispec.RefreshRe
 }
 
 func (f *TableFormatter) formatSuccessMessage(w io.Writer, r *apispec.SuccessMessageResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Success:", fmt.Sprintf("%v", r.Success)})
 	if v, ok := r.Data.Get(); ok {
 		if msg, ok := v.Message.Get(); ok {
@@ -613,18 +675,31 @@ func (f *TableFormatter) formatSandboxList(w io.Writer, r *sandbox0.ListSandboxe
 		return nil
 	}
 
-	t := newTable(w)
-	t.Header([]string{"ID", "TEMPLATE ID", "STATUS", "PAUSED", "CREATED AT", "HARD EXPIRES AT"})
+	t := f.newTable(w)
+	headers := []string{"ID", "TEMPLATE ID", "STATUS", "PAUSED", "CREATED AT", "HARD EXPIRES AT"}
+	if f.wide {
+		headers = append(headers, "EXPIRES AT", "CLUSTER ID", "GENERATION", "UPDATED AT")
+	}
+	t.Header(headers)
 
 	for _, s := range r.Sandboxes {
-		_ = t.Append([]string{
+		row := []string{
 			s.ID,
 			s.TemplateID,
 			string(s.Status),
 			fmt.Sprintf("%v", s.Paused),
 			s.CreatedAt.Format(timeLayout),
 			formatTimestamp(s.HardExpiresAt),
-		})
+		}
+		if f.wide {
+			row = append(row,
+				formatTimestamp(s.ExpiresAt),
+				formatOptNilString(s.ClusterID),
+				strconv.FormatInt(s.RuntimeGeneration, 10),
+				formatTimestamp(s.UpdatedAt),
+			)
+		}
+		_ = t.Append(row)
 	}
 	if err := t.Render(); err != nil {
 		return err
@@ -639,7 +714,7 @@ func (f *TableFormatter) formatSandboxList(w io.Writer, r *sandbox0.ListSandboxe
 }
 
 func (f *TableFormatter) formatSDKSandbox(w io.Writer, s *sandbox0.Sandbox) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", s.ID})
 	_ = t.Append([]string{"Template:", s.Template})
 	_ = t.Append([]string{"Status:", s.Status})
@@ -653,7 +728,7 @@ func (f *TableFormatter) formatSDKSandbox(w io.Writer, s *sandbox0.Sandbox) erro
 }
 
 func (f *TableFormatter) formatRegistryCredentials(w io.Writer, c *client.RegistryCredentials) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	password := c.Password
 	if !f.showSecrets {
 		password = maskSecret(password)
@@ -675,7 +750,7 @@ func (f *TableFormatter) formatCredentialSourceList(w io.Writer, sources []apisp
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"NAME", "RESOLVER KIND", "VERSION", "STATUS", "CREATED AT", "UPDATED AT"})
 	for _, source := range sources {
 		_ = t.Append([]string{
@@ -691,7 +766,7 @@ func (f *TableFormatter) formatCredentialSourceList(w io.Writer, sources []apisp
 }
 
 func (f *TableFormatter) formatCredentialSource(w io.Writer, source *apispec.CredentialSourceMetadata) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Name:", source.Name})
 	_ = t.Append([]string{"Resolver Kind:", string(source.ResolverKind)})
 	_ = t.Append([]string{"Current Version:", formatOptInt64(source.CurrentVersion)})
@@ -717,7 +792,7 @@ func (f *TableFormatter) formatFileList(w io.Writer, files []apispec.FileInfo) e
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"NAME", "TYPE", "SIZE", "MODIFIED"})
 
 	for _, file := range files {
@@ -744,7 +819,7 @@ func (f *TableFormatter) formatFileList(w io.Writer, files []apispec.FileInfo) e
 }
 
 func (f *TableFormatter) formatFileInfo(w io.Writer, file *apispec.FileInfo) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	if name, ok := file.Name.Get(); ok {
 		_ = t.Append([]string{"Name:", name})
 	}
@@ -778,8 +853,12 @@ func (f *TableFormatter) formatContextList(w io.Writer, contexts []apispec.Conte
 		return nil
 	}
 
-	t := newTable(w)
-	t.Header([]string{"ID", "TYPE", "ALIAS", "RUNNING", "PAUSED", "CREATED"})
+	t := f.newTable(w)
+	headers := []string{"ID", "TYPE", "ALIAS", "RUNNING", "PAUSED", "CREATED"}
+	if f.wide {
+		headers = append(headers, "WORKING DIR")
+	}
+	t.Header(headers)
 
 	for _, ctx := range contexts {
 		alias := "-"
@@ -787,20 +866,24 @@ func (f *TableFormatter) formatContextList(w io.Writer, contexts []apispec.Conte
 			alias = lang
 		}
 
-		_ = t.Append([]string{
+		row := []string{
 			ctx.ID,
 			string(ctx.Type),
 			alias,
 			fmt.Sprintf("%v", ctx.Running),
 			fmt.Sprintf("%v", ctx.Paused),
 			ctx.CreatedAt,
-		})
+		}
+		if f.wide {
+			row = append(row, ctx.Cwd.Or("-"))
+		}
+		_ = t.Append(row)
 	}
 	return t.Render()
 }
 
 func (f *TableFormatter) formatContext(w io.Writer, ctx *apispec.ContextResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", ctx.ID})
 	_ = t.Append([]string{"Type:", string(ctx.Type)})
 	if alias, ok := ctx.Alias.Get(); ok {
@@ -823,7 +906,7 @@ func (f *TableFormatter) formatExecutionSessionList(w io.Writer, sessions []apis
 		_, _ = fmt.Fprintln(w, "No execution sessions found.")
 		return nil
 	}
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "Name", "Phase", "Attempt", "PID", "Restarts", "Latest event", "Updated"})
 	for _, session := range sessions {
 		name := "-"
@@ -853,7 +936,7 @@ func (f *TableFormatter) formatExecutionSessionList(w io.Writer, sessions []apis
 }
 
 func (f *TableFormatter) formatExecutionSession(w io.Writer, session *apispec.ExecutionSession) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", session.ID})
 	if name, ok := session.Spec.Name.Get(); ok {
 		_ = t.Append([]string{"Name:", name})
@@ -890,7 +973,7 @@ func (f *TableFormatter) formatExecutionSessionEvents(w io.Writer, page *apispec
 		_, _ = fmt.Fprintf(w, "No execution session events found (cursor %d..%d).\n", page.Cursor.Earliest, page.Cursor.Latest)
 		return nil
 	}
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"Seq", "Occurred", "Attempt", "Type", "Stream", "Data", "Reason"})
 	for _, event := range page.Events {
 		attempt := "-"
@@ -927,7 +1010,7 @@ func (f *TableFormatter) formatExecutionSessionEvents(w io.Writer, page *apispec
 }
 
 func (f *TableFormatter) formatExecutionSessionInput(w io.Writer, response *apispec.ExecutionSessionInputResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Input ID:", response.InputID})
 	_ = t.Append([]string{"Attempt ID:", response.AttemptID})
 	_ = t.Append([]string{"Accepted:", strconv.FormatBool(response.Accepted)})
@@ -936,7 +1019,7 @@ func (f *TableFormatter) formatExecutionSessionInput(w io.Writer, response *apis
 }
 
 func (f *TableFormatter) formatSandboxObservabilityEvents(w io.Writer, resp *apispec.SandboxObservabilityEventsResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"Occurred", "Event ID", "Source", "Type", "Phase / Outcome", "Actor", "Action", "Resource", "Operation", "Integrity"})
 	for _, event := range resp.Events {
 		_ = t.Append([]string{
@@ -965,7 +1048,7 @@ func (f *TableFormatter) formatSandboxObservabilityEvents(w io.Writer, resp *api
 }
 
 func (f *TableFormatter) formatSandboxObservabilityLogs(w io.Writer, resp *apispec.SandboxObservabilityLogsResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"Occurred", "Stream", "Context", "Message", "Cursor"})
 	for _, entry := range resp.Logs {
 		stream := ""
@@ -988,7 +1071,7 @@ func (f *TableFormatter) formatSandboxObservabilityLogs(w io.Writer, resp *apisp
 }
 
 func (f *TableFormatter) formatSandboxRuntimeMetrics(w io.Writer, resp *apispec.SandboxRuntimeMetricsResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"Time", "Metric", "Value", "Unit", "Statistic", "Dimensions"})
 	for _, series := range resp.Series {
 		dimensions := ""
@@ -1016,7 +1099,8 @@ func (f *TableFormatter) formatSandboxRuntimeMetrics(w io.Writer, resp *apispec.
 	return t.Render()
 }
 
-func formatBytes(bytes int64) string {
+// FormatBytes renders a byte count using binary units, for example "1.5 MiB".
+func FormatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
@@ -1031,7 +1115,7 @@ func formatBytes(bytes int64) string {
 
 //nolint:staticcheck // The CLI still displays legacy allow/deny fields for compatibility with older policies.
 func (f *TableFormatter) formatSandboxNetworkPolicy(w io.Writer, policy *apispec.SandboxNetworkPolicy) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"Mode:", string(policy.Mode)})
 	if egress, ok := policy.Egress.Get(); ok {
 		_ = t.Append([]string{"", ""})
@@ -1077,7 +1161,7 @@ func (f *TableFormatter) formatSandboxServices(w io.Writer, resp *sandbox0.Sandb
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"SERVICE", "PORT", "PUBLIC", "URL", "ROUTE", "PATH", "METHODS", "AUTH", "RATE LIMIT", "TIMEOUT", "RESUME", "PUBLISHABLE"})
 	for _, service := range resp.Services {
 		routes := service.Ingress.Routes
@@ -1186,7 +1270,7 @@ func (f *TableFormatter) formatMountStatusList(w io.Writer, mounts []apispec.Mou
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"VOLUME ID", "MOUNT POINT", "STATE", "MOUNTED AT", "DURATION", "ERROR"})
 
 	for _, m := range mounts {
@@ -1212,7 +1296,7 @@ func (f *TableFormatter) formatMountStatusList(w io.Writer, mounts []apispec.Mou
 		_ = t.Append([]string{
 			volumeID,
 			mountPoint,
-			string(m.State),
+			valueOrDash(string(m.State)),
 			formatTimestampText(mountedAt),
 			duration,
 			errorText,
@@ -1239,10 +1323,14 @@ func (f *TableFormatter) formatAPIKeyList(w io.Writer, keys []apispec.APIKey) er
 		return nil
 	}
 
-	t := newTable(w)
-	t.Header([]string{"ID", "NAME", "SCOPE", "TEAM ID", "USER ID", "ROLES", "ACTIVE", "EXPIRES AT", "LAST USED"})
+	t := f.newTable(w)
+	headers := []string{"ID", "NAME", "SCOPE", "TEAM ID", "USER ID", "ROLES", "ACTIVE", "EXPIRES AT", "LAST USED"}
+	if f.wide {
+		headers = append(headers, "CREATED BY", "USAGE COUNT", "CREATED AT")
+	}
+	t.Header(headers)
 	for _, k := range keys {
-		_ = t.Append([]string{
+		row := []string{
 			k.ID,
 			k.Name,
 			formatAPIKeyScope(k.Scope),
@@ -1252,13 +1340,17 @@ func (f *TableFormatter) formatAPIKeyList(w io.Writer, keys []apispec.APIKey) er
 			fmt.Sprintf("%v", k.IsActive),
 			formatTimestamp(k.ExpiresAt),
 			formatOptDateTime(k.LastUsedAt),
-		})
+		}
+		if f.wide {
+			row = append(row, valueOrDash(k.CreatedBy), formatOptInt64(k.UsageCount), formatTimestamp(k.CreatedAt))
+		}
+		_ = t.Append(row)
 	}
 	return t.Render()
 }
 
 func (f *TableFormatter) formatCreatedAPIKey(w io.Writer, k *apispec.CreateAPIKeyResponse) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", k.ID})
 	_ = t.Append([]string{"Name:", k.Name})
 	_ = t.Append([]string{"Scope:", formatAPIKeyScope(k.Scope)})
@@ -1293,7 +1385,7 @@ func (f *TableFormatter) formatTeamListWithCurrent(w io.Writer, teams TeamList)
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"CURRENT", "ID", "NAME", "SLUG", "OWNER ID", "CREATED AT"})
 	for _, item := range teams {
 		current := ""
@@ -1313,7 +1405,7 @@ func (f *TableFormatter) formatTeamListWithCurrent(w io.Writer, teams TeamList)
 }
 
 func (f *TableFormatter) formatTeam(w io.Writer, team *apispec.Team) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", team.ID})
 	_ = t.Append([]string{"Name:", team.Name})
 	_ = t.Append([]string{"Slug:", team.Slug})
@@ -1329,7 +1421,7 @@ func (f *TableFormatter) formatRegionList(w io.Writer, regions []apispec.Region)
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "DISPLAY NAME", "REGIONAL GATEWAY URL", "METERING EXPORT URL", "ENABLED"})
 	for _, region := range regions {
 		_ = t.Append([]string{
@@ -1344,7 +1436,7 @@ func (f *TableFormatter) formatRegionList(w io.Writer, regions []apispec.Region)
 }
 
 func (f *TableFormatter) formatRegion(w io.Writer, region *apispec.Region) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", region.ID})
 	_ = t.Append([]string{"Display Name:", formatOptString(region.DisplayName)})
 	_ = t.Append([]string{"Regional Gateway URL:", region.RegionalGatewayURL})
@@ -1359,7 +1451,7 @@ func (f *TableFormatter) formatTeamMemberList(w io.Writer, members []apispec.Tea
 		return nil
 	}
 
-	t := newTable(w)
+	t := f.newTable(w)
 	t.Header([]string{"ID", "USER ID", "EMAIL", "NAME", "ROLE", "JOINED AT"})
 	for _, m := range members {
 		_ = t.Append([]string{
@@ -1375,7 +1467,7 @@ func (f *TableFormatter) formatTeamMemberList(w io.Writer, members []apispec.Tea
 }
 
 func (f *TableFormatter) formatTeamMember(w io.Writer, m *apispec.TeamMember) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", m.ID})
 	_ = t.Append([]string{"User ID:", m.UserID})
 	_ = t.Append([]string{"Email:", formatOptString(m.Email)})
@@ -1387,7 +1479,7 @@ func (f *TableFormatter) formatTeamMember(w io.Writer, m *apispec.TeamMember) er
 }
 
 func (f *TableFormatter) formatUser(w io.Writer, u *apispec.User) error {
-	t := newTable(w)
+	t := f.newTable(w)
 	_ = t.Append([]string{"ID:", u.ID})
 	_ = t.Append([]string{"Email:", u.Email})
 	_ = t.Append([]string{"Name:", u.Name})
@@ -0,0 +1,41 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// GoTemplateFormatter renders data with a Go text/template. The template sees
+// the JSON representation of the data, so fields use their JSON names.
+type GoTemplateFormatter struct {
+	template    string
+	showSecrets bool
+}
+
+// Format writes the template output for data to the writer.
+func (f *GoTemplateFormatter) Format(w io.Writer, data any) error {
+	if strings.TrimSpace(f.template) == "" {
+		return fmt.Errorf("go-template is empty")
+	}
+	tmpl, err := template.New("output").Option("missingkey=zero").Parse(f.template)
+	if err != nil {
+		return fmt.Errorf("parse go-template: %w", err)
+	}
+	generic, err := toGeneric(redactSensitiveData(data, f.showSecrets))
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, generic); err != nil {
+		return fmt.Errorf("execute go-template: %w", err)
+	}
+	out := b.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
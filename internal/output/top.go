@@ -0,0 +1,50 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// SandboxTopRow is one sandbox of the s0 top dashboard.
+type SandboxTopRow struct {
+	ID         string    `json:"id" yaml:"id"`
+	TemplateID string    `json:"template_id" yaml:"template_id"`
+	Status     string    `json:"status" yaml:"status"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	Age        int64     `json:"age_seconds" yaml:"age_seconds"`
+	// TTL is the time left until the soft or hard expiry, whichever is first.
+	TTL *int64 `json:"ttl_seconds,omitempty" yaml:"ttl_seconds,omitempty"`
+	// CPU is the latest CPU utilization as a fraction of the CPU limit.
+	CPU    *float64 `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory *float64 `json:"memory_bytes,omitempty" yaml:"memory_bytes,omitempty"`
+}
+
+// SandboxTop is one refresh of the s0 top dashboard.
+type SandboxTop []SandboxTopRow
+
+// SandboxTopColumns are the dashboard columns, in table order.
+var SandboxTopColumns = []string{"ID", "TEMPLATE ID", "STATUS", "AGE", "TTL", "CPU", "MEMORY"}
+
+func (f *TableFormatter) formatSandboxTop(w io.Writer, rows SandboxTop) error {
+	if len(rows) == 0 {
+		_, _ = fmt.Fprintln(w, "No sandboxes found.")
+		return nil
+	}
+	t := f.newTable(w)
+	t.Header(SandboxTopColumns)
+	for _, row := range rows {
+		ttl, cpu, memory := "-", "-", "-"
+		if row.TTL != nil {
+			ttl = formatDuration(max(*row.TTL, 0))
+		}
+		if row.CPU != nil {
+			cpu = fmt.Sprintf("%.1f%%", *row.CPU*100)
+		}
+		if row.Memory != nil {
+			memory = FormatBytes(int64(*row.Memory))
+		}
+		_ = t.Append([]string{row.ID, row.TemplateID, row.Status, formatDuration(row.Age), ttl, cpu, memory})
+	}
+	return t.Render()
+}